@@ -9,7 +9,8 @@ type (
 	ArrayMap struct {
 		InfoHolder
 		MetaHolder
-		arr []Object
+		arr  []Object
+		hash uint32
 	}
 	ArrayMapIterator struct {
 		m       *ArrayMap
@@ -55,8 +56,8 @@ func (seq *ArrayMapSeq) ToString(escape bool) string {
 	return SeqToString(seq, escape)
 }
 
-func (seq *ArrayMapSeq) Pprint(w io.Writer, indent int) int {
-	return pprintSeq(seq, w, indent)
+func (seq *ArrayMapSeq) Pprint(pp *PrettyPrinter, w io.Writer, indent int) int {
+	return pprintSeq(pp, seq, w, indent)
 }
 
 func (seq *ArrayMapSeq) Format(w io.Writer, indent int) int {
@@ -122,7 +123,21 @@ func (v *ArrayMap) WithMeta(meta Map) Object {
 	return &res
 }
 
+// keyEquals is key.Equals(other) with a fast path for keywords, the most
+// common map keys, which are equal only if their (interned) names
+// are the same pointers.
+func keyEquals(key Object, other interface{}) bool {
+	if k, ok := key.(Keyword); ok {
+		o, ok := other.(Keyword)
+		return ok && o.name == k.name && o.ns == k.ns
+	}
+	return key.Equals(other)
+}
+
 func (m *ArrayMap) indexOf(key Object) int {
+	if k, ok := key.(Keyword); ok {
+		return m.indexOfKeyword(k)
+	}
 	for i := 0; i < len(m.arr); i += 2 {
 		if m.arr[i].Equals(key) {
 			return i
@@ -131,6 +146,17 @@ func (m *ArrayMap) indexOf(key Object) int {
 	return -1
 }
 
+// indexOfKeyword is indexOf specialized for keywords, comparing them
+// without calling Equals.
+func (m *ArrayMap) indexOfKeyword(k Keyword) int {
+	for i := 0; i < len(m.arr); i += 2 {
+		if other, ok := m.arr[i].(Keyword); ok && other.name == k.name && other.ns == k.ns {
+			return i
+		}
+	}
+	return -1
+}
+
 func (m *ArrayMap) Get(key Object) (bool, Object) {
 	i := m.indexOf(key)
 	if i != -1 {
@@ -141,6 +167,7 @@ func (m *ArrayMap) Get(key Object) (bool, Object) {
 
 func (m *ArrayMap) Set(key Object, value Object) {
 	i := m.indexOf(key)
+	m.hash = 0
 	if i != -1 {
 		m.arr[i+1] = value
 	} else {
@@ -154,6 +181,7 @@ func (m *ArrayMap) Add(key Object, value Object) bool {
 	if i != -1 {
 		return false
 	}
+	m.hash = 0
 	m.arr = append(m.arr, key)
 	m.arr = append(m.arr, value)
 	return true
@@ -164,6 +192,7 @@ func (m *ArrayMap) Plus(key Object, value Object) *ArrayMap {
 	if i != -1 {
 		return m
 	}
+	m.hash = 0
 	m.arr = append(m.arr, key)
 	m.arr = append(m.arr, value)
 	return m
@@ -175,6 +204,7 @@ func (m *ArrayMap) Count() int {
 
 func (m *ArrayMap) Clone() *ArrayMap {
 	result := ArrayMap{arr: make([]Object, len(m.arr), cap(m.arr))}
+	result.meta = m.meta
 	copy(result.arr, m.arr)
 	return &result
 }
@@ -187,7 +217,9 @@ func (m *ArrayMap) Assoc(key Object, value Object) Associative {
 		return res
 	}
 	if int64(len(m.arr)) >= HASHMAP_THRESHOLD {
-		return NewHashMap(m.arr...).Assoc(key, value)
+		res := NewHashMap(m.arr...)
+		res.meta = m.meta
+		return res.Assoc(key, value)
 	}
 	res := m.Clone()
 	res.arr = append(res.arr, key)
@@ -205,6 +237,7 @@ func (m *ArrayMap) EntryAt(key Object) *Vector {
 
 func (m *ArrayMap) Without(key Object) Map {
 	result := ArrayMap{arr: make([]Object, len(m.arr), cap(m.arr))}
+	result.meta = m.meta
 	var i, j int
 	for i, j = 0, 0; i < len(m.arr); i += 2 {
 		if m.arr[i].Equals(key) {
@@ -277,7 +310,10 @@ func (m *ArrayMap) GetType() *Type {
 }
 
 func (m *ArrayMap) Hash() uint32 {
-	return hashUnordered(m.Seq(), 1)
+	if m.hash == 0 {
+		m.hash = hashUnordered(m.Seq(), 1)
+	}
+	return m.hash
 }
 
 func (m *ArrayMap) Seq() Seq {
@@ -292,8 +328,8 @@ func (m *ArrayMap) Empty() Collection {
 	return EmptyArrayMap()
 }
 
-func (m *ArrayMap) Pprint(w io.Writer, indent int) int {
-	return pprintMap(m, w, indent)
+func (m *ArrayMap) Pprint(pp *PrettyPrinter, w io.Writer, indent int) int {
+	return pprintMap(pp, m, w, indent)
 }
 
 func (m *ArrayMap) Format(w io.Writer, indent int) int {
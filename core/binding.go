@@ -0,0 +1,113 @@
+package core
+
+import (
+	"sync"
+)
+
+// Dynamic bindings (see binding) belong to the goroutine establishing
+// them, and to the goroutines it starts with go or future, which get
+// the bindings in effect when they are started.
+//
+// As only the goroutine holding the GIL runs Joker code, RT.bindings
+// are the bindings of that goroutine, as RT.callstack is its callstack
+// (see GoroutineState). A goroutine releasing the GIL (e.g. to do some
+// I/O) gets its state back from Unlock and hands it back to Lock.
+
+type (
+	bindingFrame struct {
+		bindings map[*Var]Object
+		prev     *bindingFrame
+	}
+	InterpreterLock struct {
+		mu sync.Mutex
+	}
+)
+
+// Lock waits for the GIL, then makes s, returned by Unlock, the state of
+// the runtime. s is nil for a goroutine that hasn't run Joker code yet
+// (or doesn't need its state back), which starts afresh.
+func (gil *InterpreterLock) Lock(s *GoroutineState) {
+	gil.mu.Lock()
+	if s == nil {
+		s = newGoroutineState()
+	}
+	RT.GoroutineState = *s
+}
+
+// Unlock releases the GIL, returning the state of the goroutine that
+// held it.
+func (gil *InterpreterLock) Unlock() *GoroutineState {
+	s := RT.GoroutineState
+	gil.mu.Unlock()
+	return &s
+}
+
+// setValue sets the value v is bound to in the current goroutine or, if
+// it isn't bound, its root value.
+func (v *Var) setValue(val Object) {
+	if frame := RT.bindings.lookup(v); frame != nil {
+		frame.bindings[v] = val
+	} else {
+		v.Value = val
+	}
+}
+
+// lookup returns the innermost frame binding v, or nil.
+func (frame *bindingFrame) lookup(v *Var) *bindingFrame {
+	for f := frame; f != nil; f = f.prev {
+		if _, ok := f.bindings[v]; ok {
+			return f
+		}
+	}
+	return nil
+}
+
+var procPushThreadBindings = func(args []Object) Object {
+	CheckArity(args, 1, 1)
+	m := EnsureArgIsMap(args, 0)
+	frame := &bindingFrame{
+		bindings: make(map[*Var]Object, m.Count()),
+		prev:     RT.bindings,
+	}
+	for iter := m.Iter(); iter.HasNext(); {
+		p := iter.Next()
+		v := EnsureObjectIsVar(p.Key, "Binding keys must be vars, got %s")
+		v.hasBindings = true
+		frame.bindings[v] = p.Value
+	}
+	RT.bindings = frame
+	return NIL
+}
+
+var procPopThreadBindings = func(args []Object) Object {
+	CheckArity(args, 0, 0)
+	if RT.bindings == nil {
+		panic(RT.NewError("Pop without matching push"))
+	}
+	RT.bindings = RT.bindings.prev
+	return NIL
+}
+
+var procGetThreadBindings = func(args []Object) Object {
+	CheckArity(args, 0, 0)
+	res := EmptyArrayMap()
+	for f := RT.bindings; f != nil; f = f.prev {
+		for v, val := range f.bindings {
+			// Inner bindings are added first and take precedence.
+			res.Add(v, val)
+		}
+	}
+	return res
+}
+
+var procIsThreadBound = func(args []Object) Object {
+	CheckArity(args, 1, 1)
+	return Boolean{B: RT.bindings.lookup(EnsureArgIsVar(args, 0)) != nil}
+}
+
+// procHasRoot returns whether the Var has a root value, ignoring the
+// goroutine-local bindings that bound? also looks at.
+var procHasRoot = func(args []Object) Object {
+	CheckArity(args, 1, 1)
+	return Boolean{B: EnsureArgIsVar(args, 0).Value != nil}
+}
@@ -31,6 +31,7 @@ var tr = [][2]string{
 	{"%", "PCT"},
 	{".", "DOT"},
 	{":", "COLON"},
+	{"$", "DOLLAR"},
 }
 
 // Convert an "arbitrary" string (really, only valid Joker symbols are supported) to a form
@@ -9,17 +9,59 @@ import (
 
 var exitCallbacks []func()
 
+// Functions registered with joker.os/at-exit.
+var exitHooks []Callable
+
 func ExitJoker(rc int) {
-	for _, f := range exitCallbacks {
+	Shutdown()
+	os.Exit(rc)
+}
+
+// Shutdown runs the functions registered with AtExit, most recently
+// registered first, followed by the callbacks registered with OnExit.
+// Each of them runs at most once, even if Shutdown is called again
+// (e.g. by a hook calling exit). Must be called with the GIL locked.
+func Shutdown() {
+	for len(exitHooks) > 0 {
+		f := exitHooks[len(exitHooks)-1]
+		exitHooks = exitHooks[:len(exitHooks)-1]
+		runExitHook(f)
+	}
+	for len(exitCallbacks) > 0 {
+		f := exitCallbacks[0]
+		exitCallbacks = exitCallbacks[1:]
 		f()
 	}
-	os.Exit(rc)
+}
+
+func runExitHook(f Callable) {
+	s := RT.SaveCallState()
+	defer func() {
+		if r := recover(); r != nil {
+			switch r := RT.Recovered(r, s).(type) {
+			case Error:
+				fmt.Fprintln(GLOBAL_ENV.Err(), r)
+			default:
+				panic(r)
+			}
+		}
+	}()
+	f.Call([]Object{})
 }
 
 func OnExit(f func()) {
 	exitCallbacks = append(exitCallbacks, f)
 }
 
+// AtExit registers f to be called with no arguments when the program exits
+// normally, calls exit or receives SIGINT or SIGTERM.
+func AtExit(f Callable) {
+	if len(exitHooks) == 0 {
+		handleExitSignals()
+	}
+	exitHooks = append(exitHooks, f)
+}
+
 func writeIndent(w io.Writer, n int) {
 	space := []byte(" ")
 	for i := 0; i < n; i++ {
@@ -27,14 +69,19 @@ func writeIndent(w io.Writer, n int) {
 	}
 }
 
-func pprintObject(obj Object, indent int, w io.Writer) int {
-	switch obj := obj.(type) {
+func pprintObject(pp *PrettyPrinter, obj Object, indent int, w io.Writer) int {
+	switch p := obj.(type) {
 	case Pprinter:
-		return obj.Pprint(w, indent)
+		s := pp.flatString(obj)
+		if end := indent + utf8.RuneCountInString(s); end <= pp.Margin {
+			fmt.Fprint(w, s)
+			return end
+		}
+		return p.Pprint(pp, w, indent)
 	default:
 		s := obj.ToString(true)
 		fmt.Fprint(w, s)
-		return indent + len(s)
+		return indent + utf8.RuneCountInString(s)
 	}
 }
 
@@ -0,0 +1,170 @@
+package core
+
+// Fn bodies are compiled, on their first call, into trees of Go closures
+// that evaluate the common expressions (locals, vars, calls, if, let,
+// loop/recur) without a type switch and a deferred call per step; all
+// the other expressions fall back to Eval. COMPILE is cleared by
+// --no-compile to get the plain tree-walking Eval, e.g. for debugging.
+
+type compiledExpr func(env *LocalEnv) Object
+
+var COMPILE = true
+
+// run evaluates the arity's body in env, recurring as needed. The body
+// is compiled on the first call; compiled needs no synchronization, as
+// fns are only called by the goroutine holding the GIL.
+func (arity *FnArityExpr) run(env *LocalEnv) Object {
+	if !COMPILE {
+		return evalLoop(arity.body, env)
+	}
+	if arity.compiled == nil {
+		arity.compiled = compileLoop(arity.body)
+	}
+	return arity.compiled(env)
+}
+
+// tracked makes RT.currentExpr point to expr while f runs, so that
+// errors and stack frames get its position, as Eval does. As with Eval,
+// it's restored on panics by whatever recovers them (see CallState).
+func tracked(expr Expr, f compiledExpr) compiledExpr {
+	return func(env *LocalEnv) Object {
+		parentExpr := RT.currentExpr
+		RT.currentExpr = expr
+		res := f(env)
+		RT.currentExpr = parentExpr
+		return res
+	}
+}
+
+func compileAll(exprs []Expr) []compiledExpr {
+	res := make([]compiledExpr, len(exprs))
+	for i, expr := range exprs {
+		res[i] = compileExpr(expr)
+	}
+	return res
+}
+
+func compileBody(body []Expr) compiledExpr {
+	switch len(body) {
+	case 0:
+		return func(env *LocalEnv) Object {
+			return NIL
+		}
+	case 1:
+		return compileExpr(body[0])
+	}
+	exprs := compileAll(body)
+	last := len(exprs) - 1
+	return func(env *LocalEnv) Object {
+		for _, expr := range exprs[:last] {
+			expr(env)
+		}
+		return exprs[last](env)
+	}
+}
+
+func compileLoop(body []Expr) compiledExpr {
+	b := compileBody(body)
+	return func(env *LocalEnv) Object {
+		for {
+			res := b(env)
+			if bindings, ok := res.(RecurBindings); ok {
+				env = env.replaceFrame(bindings)
+				continue
+			}
+			return res
+		}
+	}
+}
+
+func compileExpr(expr Expr) compiledExpr {
+	switch expr := expr.(type) {
+	case *LiteralExpr:
+		obj := expr.obj
+		return func(env *LocalEnv) Object {
+			return obj
+		}
+	case *BindingExpr:
+		frame, index := expr.binding.frame, expr.binding.index
+		return func(env *LocalEnv) Object {
+			for i := env.frame; i > frame; i-- {
+				env = env.parent
+			}
+			return env.bindings[index]
+		}
+	case *VarRefExpr:
+		vr := expr.vr
+		return tracked(expr, func(env *LocalEnv) Object {
+			return vr.Resolve()
+		})
+	case *DoExpr:
+		return compileBody(expr.body)
+	case *IfExpr:
+		cond := compileExpr(expr.cond)
+		positive := compileExpr(expr.positive)
+		negative := compileExpr(expr.negative)
+		return func(env *LocalEnv) Object {
+			if ToBool(cond(env)) {
+				return positive(env)
+			}
+			return negative(env)
+		}
+	case *LetExpr:
+		n := len(expr.names)
+		values := compileAll(expr.values)
+		body := compileBody(expr.body)
+		return func(env *LocalEnv) Object {
+			env = env.addEmptyFrame(n)
+			for _, value := range values {
+				env.addBinding(value(env))
+			}
+			return body(env)
+		}
+	case *LoopExpr:
+		n := len(expr.names)
+		values := compileAll(expr.values)
+		body := compileLoop(expr.body)
+		return func(env *LocalEnv) Object {
+			env = env.addEmptyFrame(n)
+			for _, value := range values {
+				env.addBinding(value(env))
+			}
+			return body(env)
+		}
+	case *RecurExpr:
+		args := compileAll(expr.args)
+		return func(env *LocalEnv) Object {
+			res := make([]Object, len(args))
+			for i, arg := range args {
+				res[i] = arg(env)
+			}
+			return RecurBindings(res)
+		}
+	case *CallExpr:
+		return compileCall(expr)
+	}
+	return func(env *LocalEnv) Object {
+		return Eval(expr, env)
+	}
+}
+
+func compileCall(expr *CallExpr) compiledExpr {
+	callable := compileExpr(expr.callable)
+	args := compileAll(expr.args)
+	vref, _ := expr.callable.(*VarRefExpr)
+	return tracked(expr, func(env *LocalEnv) Object {
+		obj := callable(env)
+		f, ok := obj.(Callable)
+		if !ok {
+			panic(RT.NewErrorWithPos(obj.ToString(false)+" is not a Fn", expr.callable.Pos()))
+		}
+		argVals := make([]Object, len(args))
+		for i, arg := range args {
+			argVals[i] = arg(env)
+		}
+		if len(callHooks) > 0 && vref != nil {
+			return callWithHooks(vref.vr, f, argVals, expr.Pos())
+		}
+		return f.Call(argVals)
+	})
+}
@@ -0,0 +1,79 @@
+//go:build !gen_code
+// +build !gen_code
+
+package core
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+var coreDataOnce sync.Once
+
+// parseString parses the first form of s in the user namespace.
+func parseString(s string) Expr {
+	coreDataOnce.Do(func() {
+		RT.GIL.Lock(nil)
+		ProcessCoreData()
+		GLOBAL_ENV.ReferCoreToUser()
+	})
+	obj, _ := Read(NewReader(strings.NewReader(s), "<test>"))
+	return Parse(obj, &ParseContext{GlobalEnv: GLOBAL_ENV})
+}
+
+func evalString(s string) Object {
+	return Eval(parseString(s), nil)
+}
+
+func benchmarkFib(b *testing.B, compile bool) {
+	defer func(c bool) { COMPILE = c }(COMPILE)
+	COMPILE = compile
+	// A fresh fn, so that it gets compiled (or not) on its first call.
+	fib := evalString("(fn fib [n] (if (< n 2) n (+ (fib (- n 1)) (fib (- n 2)))))").(Callable)
+	args := []Object{MakeInt(15)}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fib.Call(args)
+	}
+}
+
+func BenchmarkFibCompiled(b *testing.B) {
+	benchmarkFib(b, true)
+}
+
+func BenchmarkFibEvaluated(b *testing.B) {
+	benchmarkFib(b, false)
+}
+
+func TestCompiledMatchesEvaluated(t *testing.T) {
+	defer func(c bool) { COMPILE = c }(COMPILE)
+	src := "(fn [n] (loop [i 0 acc []] (if (< i n) (recur (inc i) (conj acc (let [x (* i i)] (if (even? x) x (- x))))) acc)))"
+	var res [2]Object
+	for i, compile := range []bool{true, false} {
+		COMPILE = compile
+		res[i] = evalString(src).(Callable).Call([]Object{MakeInt(10)})
+	}
+	if !res[0].Equals(res[1]) {
+		t.Fatalf("compiled fn returned %s, evaluated one %s", res[0].ToString(true), res[1].ToString(true))
+	}
+}
+
+func TestCallStateRestoredAfterPanics(t *testing.T) {
+	defer func(c bool) { COMPILE = c }(COMPILE)
+	COMPILE = true
+	expr := RT.currentExpr
+	depth := len(RT.callstack.frames)
+	check := func(what string) {
+		if RT.currentExpr != expr || len(RT.callstack.frames) != depth {
+			t.Fatalf("%s: currentExpr is %v and the callstack %d frames deep, want %v and %d",
+				what, RT.currentExpr, len(RT.callstack.frames), expr, depth)
+		}
+	}
+	evalString(`((fn [] (let [x (try (subs "abc" 2 1) (catch Error e :caught))] x)))`)
+	check("caught in the fn")
+	if _, err := TryEval(parseString(`((fn [] (let [x (subs "abc" 2 1)] x)))`)); err == nil {
+		t.Fatal("expected an error")
+	}
+	check("caught by TryEval")
+}
@@ -0,0 +1,198 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CustomDialect is a dialect registered in the :dialects map of a .joker
+// file, e.g. for an in-house DSL or another Clojure runtime. It's linted
+// as its Base dialect plus the macros, symbols and namespace aliases it
+// declares.
+type CustomDialect struct {
+	Name         string
+	Base         Dialect
+	Extension    string
+	Features     []Keyword
+	KnownMacros  Map
+	KnownSymbols []Symbol
+	Aliases      map[*string]Symbol
+}
+
+var (
+	CUSTOM_DIALECTS = map[string]*CustomDialect{}
+	// Namespace aliases available in every namespace, set by the custom
+	// dialect being linted.
+	DIALECT_ALIASES map[*string]Symbol
+)
+
+var builtinDialects = map[string]Dialect{
+	"clj":   CLJ,
+	"cljs":  CLJS,
+	"joker": JOKER,
+	"edn":   EDN,
+}
+
+func dialectName(obj Object) (string, bool) {
+	switch obj := obj.(type) {
+	case Keyword:
+		return obj.Name(), true
+	case Symbol:
+		return obj.Name(), true
+	case String:
+		return obj.S, true
+	}
+	return "", false
+}
+
+func parseCustomDialect(name string, obj Object) (*CustomDialect, error) {
+	m, ok := obj.(Map)
+	if !ok {
+		return nil, fmt.Errorf("dialect %s must be a map, got %s", name, obj.GetType().ToString(false))
+	}
+	d := &CustomDialect{
+		Name:      name,
+		Base:      CLJ,
+		Extension: "." + name,
+		Aliases:   map[*string]Symbol{},
+	}
+	if ok, v := m.Get(MakeKeyword("base")); ok {
+		base, _ := dialectName(v)
+		if d.Base, ok = builtinDialects[base]; !ok {
+			return nil, fmt.Errorf(":base of dialect %s must be :clj, :cljs, :joker or :edn, got %s", name, v.ToString(true))
+		}
+	}
+	if ok, v := m.Get(MakeKeyword("extension")); ok {
+		s, ok := v.(String)
+		if !ok {
+			return nil, fmt.Errorf(":extension of dialect %s must be a string, got %s", name, v.GetType().ToString(false))
+		}
+		d.Extension = s.S
+		if !strings.HasPrefix(d.Extension, ".") {
+			d.Extension = "." + d.Extension
+		}
+	}
+	if ok, v := m.Get(MakeKeyword("features")); ok {
+		s, ok := v.(Seqable)
+		if !ok {
+			return nil, fmt.Errorf(":features of dialect %s must be a vector, got %s", name, v.GetType().ToString(false))
+		}
+		for iter := iterOf(s); iter.HasNext(); {
+			k, ok := iter.Next().(Keyword)
+			if !ok {
+				return nil, fmt.Errorf(":features of dialect %s must be keywords", name)
+			}
+			d.Features = append(d.Features, k)
+		}
+	}
+	if ok, v := m.Get(KEYWORDS.knownMacros); ok {
+		if _, ok := v.(Seqable); !ok {
+			return nil, fmt.Errorf(":known-macros of dialect %s must be a vector, got %s", name, v.GetType().ToString(false))
+		}
+		km, err := knownMacrosToMap(v)
+		if err != nil {
+			return nil, err
+		}
+		d.KnownMacros = km
+	}
+	if ok, v := m.Get(MakeKeyword("known-symbols")); ok {
+		s, ok := v.(Seqable)
+		if !ok {
+			return nil, fmt.Errorf(":known-symbols of dialect %s must be a vector, got %s", name, v.GetType().ToString(false))
+		}
+		for iter := iterOf(s); iter.HasNext(); {
+			sym, ok := iter.Next().(Symbol)
+			if !ok || sym.ns != nil {
+				return nil, fmt.Errorf(":known-symbols of dialect %s must be unqualified symbols", name)
+			}
+			d.KnownSymbols = append(d.KnownSymbols, sym)
+		}
+	}
+	if ok, v := m.Get(MakeKeyword("aliases")); ok {
+		am, ok := v.(Map)
+		if !ok {
+			return nil, fmt.Errorf(":aliases of dialect %s must be a map, got %s", name, v.GetType().ToString(false))
+		}
+		for iter := am.Iter(); iter.HasNext(); {
+			p := iter.Next()
+			alias, ok1 := p.Key.(Symbol)
+			target, ok2 := p.Value.(Symbol)
+			if !ok1 || !ok2 {
+				return nil, fmt.Errorf(":aliases of dialect %s must map symbols to symbols", name)
+			}
+			d.Aliases[alias.name] = target
+		}
+	}
+	return d, nil
+}
+
+func readDialects(configFileName string, dialects Object) bool {
+	m, ok := dialects.(Map)
+	if !ok {
+		printConfigError(configFileName, ":dialects value must be a map, got "+dialects.GetType().ToString(false))
+		return false
+	}
+	for iter := m.Iter(); iter.HasNext(); {
+		p := iter.Next()
+		name, ok := dialectName(p.Key)
+		if !ok {
+			printConfigError(configFileName, ":dialects keys must be keywords, got "+p.Key.GetType().ToString(false))
+			return false
+		}
+		if _, ok := builtinDialects[name]; ok {
+			printConfigError(configFileName, "can't redefine builtin dialect "+name)
+			return false
+		}
+		d, err := parseCustomDialect(name, p.Value)
+		if err != nil {
+			printConfigError(configFileName, err.Error())
+			return false
+		}
+		CUSTOM_DIALECTS[name] = d
+	}
+	return true
+}
+
+// CustomDialectForFile returns the custom dialect whose extension
+// filename has, if any.
+func CustomDialectForFile(filename string) *CustomDialect {
+	for _, d := range CUSTOM_DIALECTS {
+		if strings.HasSuffix(filename, d.Extension) {
+			return d
+		}
+	}
+	return nil
+}
+
+// Apply merges the dialect's known macros into the linter config and
+// sets its namespace aliases. It must be called after ReadConfig and
+// before the linter data is processed.
+func (d *CustomDialect) Apply() {
+	if d.KnownMacros != nil {
+		config := LINTER_CONFIG.Value.(Map)
+		km := d.KnownMacros
+		if ok, v := config.Get(KEYWORDS.knownMacros); ok {
+			km = v.(Map).Merge(km)
+		}
+		LINTER_CONFIG.Value = config.Assoc(KEYWORDS.knownMacros, km)
+	}
+	DIALECT_ALIASES = d.Aliases
+}
+
+// DeclareKnownSymbols interns the dialect's known symbols, and its
+// unqualified known macros, in joker.core so they resolve everywhere
+// (calls to the macros are then linted as calls to known macros). It
+// must be called after the linter data is processed.
+func (d *CustomDialect) DeclareKnownSymbols() {
+	for _, sym := range d.KnownSymbols {
+		GLOBAL_ENV.CoreNamespace.Intern(sym)
+	}
+	if d.KnownMacros == nil {
+		return
+	}
+	for iter := d.KnownMacros.Iter(); iter.HasNext(); {
+		if sym, ok := iter.Next().Key.(Symbol); ok && sym.ns == nil {
+			GLOBAL_ENV.CoreNamespace.Intern(sym)
+		}
+	}
+}
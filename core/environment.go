@@ -23,6 +23,10 @@ type (
 		stdin         *Var
 		stderr        *Var
 		printReadably *Var
+		printLength   *Var
+		printLevel    *Var
+		printMeta     *Var
+		printDup      *Var
 		file          *Var
 		MainFile      *Var
 		args          *Var
@@ -33,6 +37,8 @@ type (
 		version       *Var
 		libs          *Var
 		Features      Set
+		// Values *in*, *out* and *err* are bound to by InitEnv.
+		initialStdIO [3]Object
 	}
 )
 
@@ -82,6 +88,7 @@ func (env *Env) InitEnv(stdin io.Reader, stdout, stderr io.Writer, args []string
 	env.stdin.Value = MakeBufferedReader(stdin)
 	env.stdout.Value = MakeIOWriter(stdout)
 	env.stderr.Value = MakeIOWriter(stderr)
+	env.initialStdIO = [3]Object{env.stdin.Value, env.stdout.Value, env.stderr.Value}
 	env.SetEnvArgs(args)
 }
 
@@ -92,7 +99,30 @@ func (env *Env) SetStdIO(stdin, stdout, stderr Object) {
 }
 
 func (env *Env) StdIO() (stdin, stdout, stderr Object) {
-	return env.stdin.Value, env.stdout.Value, env.stderr.Value
+	return env.stdin.Resolve(), env.stdout.Resolve(), env.stderr.Resolve()
+}
+
+func writerOf(vr *Var, dflt io.Writer) io.Writer {
+	if w, ok := vr.Resolve().(io.Writer); ok {
+		return w
+	}
+	return dflt
+}
+
+// Out and Err return the writers *out* and *err* are currently bound
+// to, or Stdout and Stderr if they aren't (yet) bound to writers.
+func (env *Env) Out() io.Writer {
+	return writerOf(env.stdout, Stdout)
+}
+
+func (env *Env) Err() io.Writer {
+	return writerOf(env.stderr, Stderr)
+}
+
+// InitialStdIO returns the objects wrapping the process's standard streams,
+// regardless of the current bindings of *in*, *out* and *err*.
+func (env *Env) InitialStdIO() (stdin, stdout, stderr Object) {
+	return env.initialStdIO[0], env.initialStdIO[1], env.initialStdIO[2]
 }
 
 /* This runs after invariant initialization, which includes calling
@@ -106,31 +136,51 @@ func (env *Env) SetMainFilename(filename string) {
    NewEnv().  NOTE: Any changes to the list of run-time
    initializations must be reflected in gen_code/gen_code.go.  */
 func (env *Env) SetFilename(obj Object) {
-	env.file.Value = obj
+	env.file.setValue(obj)
 }
 
 func (env *Env) IsStdIn(obj Object) bool {
-	return env.stdin.Value == obj
+	return env.stdin.Resolve() == obj
 }
 
 func (env *Env) CurrentNamespace() *Namespace {
-	return EnsureObjectIsNamespace(env.ns.Value, "")
+	return EnsureObjectIsNamespace(env.ns.Resolve(), "")
 }
 
 func (env *Env) SetCurrentNamespace(ns *Namespace) {
-	env.ns.Value = ns
+	env.ns.setValue(ns)
 }
 
 func (env *Env) EnsureSymbolIsNamespace(sym Symbol) *Namespace {
 	if sym.ns != nil {
 		panic(RT.NewError("Namespace's name cannot be qualified: " + sym.ToString(false)))
 	}
+	nsLock.Lock()
+	defer nsLock.Unlock()
 	if env.Namespaces[sym.name] == nil {
 		env.Namespaces[sym.name] = NewNamespace(sym)
 	}
 	return env.Namespaces[sym.name]
 }
 
+func (env *Env) namespace(name *string) *Namespace {
+	nsLock.RLock()
+	defer nsLock.RUnlock()
+	return env.Namespaces[name]
+}
+
+// AllNamespaces returns a copy of env.Namespaces, which (unlike the
+// field itself) is safe to use while other goroutines evaluate code.
+func (env *Env) AllNamespaces() map[*string]*Namespace {
+	nsLock.RLock()
+	defer nsLock.RUnlock()
+	res := make(map[*string]*Namespace, len(env.Namespaces))
+	for k, v := range env.Namespaces {
+		res[k] = v
+	}
+	return res
+}
+
 func (env *Env) EnsureSymbolIsLib(sym Symbol) *Namespace {
 	ns := env.EnsureSymbolIsNamespace(sym)
 	env.libs.Value.(*MapSet).Add(sym)
@@ -142,9 +192,14 @@ func (env *Env) NamespaceFor(ns *Namespace, s Symbol) *Namespace {
 	if s.ns == nil {
 		res = ns
 	} else {
-		res = ns.aliases[s.ns]
+		res = ns.alias(s.ns)
 		if res == nil {
-			res = env.Namespaces[s.ns]
+			res = env.namespace(s.ns)
+		}
+		if res == nil {
+			if target, ok := DIALECT_ALIASES[s.ns]; ok {
+				res = env.namespace(target.name)
+			}
 		}
 	}
 	if res != nil {
@@ -158,7 +213,7 @@ func (env *Env) ResolveIn(n *Namespace, s Symbol) (*Var, bool) {
 	if ns == nil {
 		return nil, false
 	}
-	if v, ok := ns.mappings[s.name]; ok {
+	if v, ok := ns.mapping(s.name); ok {
 		return v, true
 	}
 	if s.Equals(env.IN_NS_VAR.name) {
@@ -178,7 +233,7 @@ func (env *Env) FindNamespace(s Symbol) *Namespace {
 	if s.ns != nil {
 		return nil
 	}
-	ns := env.Namespaces[s.name]
+	ns := env.namespace(s.name)
 	if ns != nil {
 		ns.MaybeLazy("FindNameSpace")
 	}
@@ -192,6 +247,8 @@ func (env *Env) RemoveNamespace(s Symbol) *Namespace {
 	if s.Equals(SYMBOLS.joker_core) {
 		panic(RT.NewError("Cannot remove core namespace"))
 	}
+	nsLock.Lock()
+	defer nsLock.Unlock()
 	ns := env.Namespaces[s.name]
 	delete(env.Namespaces, s.name)
 	return ns
@@ -221,7 +278,7 @@ func (env *Env) ResolveSymbol(s Symbol) Symbol {
 			ns:   ns.Name.name,
 		}
 	}
-	vr, ok := currentNs.mappings[s.name]
+	vr, ok := currentNs.mapping(s.name)
 	if !ok {
 		return Symbol{
 			name: s.name,
@@ -35,6 +35,14 @@ func NewEnv() *Env {
 	res.classPath.isPrivate = true
 	res.printReadably = res.CoreNamespace.Intern(MakeSymbol("*print-readably*"))
 	res.printReadably.Value = Boolean{B: true}
+	res.printLength = res.CoreNamespace.Intern(MakeSymbol("*print-length*"))
+	res.printLength.Value = NIL
+	res.printLevel = res.CoreNamespace.Intern(MakeSymbol("*print-level*"))
+	res.printLevel.Value = NIL
+	res.printMeta = res.CoreNamespace.Intern(MakeSymbol("*print-meta*"))
+	res.printMeta.Value = Boolean{B: false}
+	res.printDup = res.CoreNamespace.Intern(MakeSymbol("*print-dup*"))
+	res.printDup.Value = Boolean{B: false}
 	res.CoreNamespace.InternVar("*linter-mode*", Boolean{B: LINTER_MODE},
 		MakeMeta(nil, "true if Joker is running in linter mode", "1.0"))
 	res.CoreNamespace.InternVar("*linter-config*", EmptyArrayMap(),
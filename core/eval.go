@@ -3,8 +3,8 @@ package core
 import (
 	"bytes"
 	"fmt"
+	"runtime"
 	"strings"
-	"sync"
 	"unsafe"
 )
 
@@ -25,21 +25,54 @@ type (
 	Callstack struct {
 		frames []Frame
 	}
-	Runtime struct {
+	// CallState is how deep the callstack is and what expression is
+	// being evaluated. Eval and Fn.Call don't restore them with a
+	// deferred call, which would cost every call, so a panic leaves them
+	// as they were where it was raised; the code recovering the panic
+	// saves them beforehand and restores them (see Recovered).
+	CallState struct {
+		depth int
+		expr  Expr
+	}
+	// GoroutineState is the part of the runtime that belongs to the
+	// goroutine running Joker code (see InterpreterLock).
+	GoroutineState struct {
 		callstack   *Callstack
 		currentExpr Expr
-		GIL         sync.Mutex
+		bindings    *bindingFrame
+	}
+	Runtime struct {
+		GoroutineState
+		GIL InterpreterLock
 	}
 )
 
 var RT *Runtime = &Runtime{
-	callstack: &Callstack{frames: make([]Frame, 0, 50)},
+	GoroutineState: *newGoroutineState(),
+}
+
+func newGoroutineState() *GoroutineState {
+	return &GoroutineState{
+		callstack: &Callstack{frames: make([]Frame, 0, 50)},
+	}
+}
+
+// childState returns the state of a goroutine started by the current one
+// (with go or future), which gets its bindings. Its stacktraces start at
+// the expression starting it.
+func (rt *Runtime) childState() *GoroutineState {
+	s := newGoroutineState()
+	s.currentExpr = rt.currentExpr
+	s.bindings = rt.bindings
+	return s
 }
 
 func (rt *Runtime) clone() *Runtime {
 	return &Runtime{
-		callstack:   rt.callstack.clone(),
-		currentExpr: rt.currentExpr,
+		GoroutineState: GoroutineState{
+			callstack:   rt.callstack.clone(),
+			currentExpr: rt.currentExpr,
+		},
 	}
 }
 
@@ -103,11 +136,29 @@ func (rt *Runtime) popFrame() {
 	rt.callstack.popFrame()
 }
 
+func (rt *Runtime) SaveCallState() CallState {
+	return CallState{depth: len(rt.callstack.frames), expr: rt.currentExpr}
+}
+
+// Recovered returns r, recovered from a panic raised by Joker code, as a
+// Joker error if it's a Go runtime error (a nil dereference, an index
+// out of range, etc.), with the stacktrace of where it was raised. It
+// then unwinds the callstack and currentExpr back to s.
+func (rt *Runtime) Recovered(r interface{}, s CallState) interface{} {
+	if err, ok := r.(runtime.Error); ok {
+		r = rt.NewError(err.Error())
+	}
+	rt.callstack.frames = rt.callstack.frames[:s.depth]
+	rt.currentExpr = s.expr
+	return r
+}
+
 func Eval(expr Expr, env *LocalEnv) Object {
 	parentExpr := RT.currentExpr
 	RT.currentExpr = expr
-	defer (func() { RT.currentExpr = parentExpr })()
-	return expr.Eval(env)
+	res := expr.Eval(env)
+	RT.currentExpr = parentExpr
+	return res
 }
 
 func (s *Callstack) pushFrame(frame Frame) {
@@ -256,9 +307,15 @@ func (expr *DefExpr) Eval(env *LocalEnv) Object {
 	meta.Add(KEYWORDS.line, Int{I: expr.startLine})
 	meta.Add(KEYWORDS.column, Int{I: expr.startColumn})
 	meta.Add(KEYWORDS.file, String{S: *expr.filename})
+	if RETAIN_SOURCE {
+		if src, ok := sourceText(expr.Position); ok {
+			meta.Add(KEYWORDS.source, String{S: src})
+		}
+	}
 	meta.Add(KEYWORDS.ns, expr.vr.ns)
 	meta.Add(KEYWORDS.name, expr.vr.name)
 	expr.vr.meta = meta
+	expr.vr.packedMeta = nil
 	if expr.meta != nil {
 		expr.vr.meta = expr.vr.meta.Merge(Eval(expr.meta, env).(Map))
 	}
@@ -288,6 +345,11 @@ func (expr *CallExpr) Eval(env *LocalEnv) Object {
 	switch callable := callable.(type) {
 	case Callable:
 		args := evalSeq(expr.args, env)
+		if len(callHooks) > 0 {
+			if vref, ok := expr.callable.(*VarRefExpr); ok {
+				return callWithHooks(vref.vr, callable, args, expr.Pos())
+			}
+		}
 		return callable.Call(args)
 	default:
 		panic(RT.NewErrorWithPos(callable.ToString(false)+" is not a Fn", expr.callable.Pos()))
@@ -325,6 +387,7 @@ func (expr *ThrowExpr) Eval(env *LocalEnv) Object {
 }
 
 func (expr *TryExpr) Eval(env *LocalEnv) (obj Object) {
+	s := RT.SaveCallState()
 	defer func() {
 		defer func() {
 			if expr.finallyExpr != nil {
@@ -332,10 +395,10 @@ func (expr *TryExpr) Eval(env *LocalEnv) (obj Object) {
 			}
 		}()
 		if r := recover(); r != nil {
-			switch r := r.(type) {
+			switch r := RT.Recovered(r, s).(type) {
 			case Error:
 				for _, catchExpr := range expr.catches {
-					if IsInstance(catchExpr.excType, r) {
+					if catchExpr.catches(r) {
 						obj = evalBody(catchExpr.body, env.addFrame([]Object{r}))
 						return
 					}
@@ -349,6 +412,63 @@ func (expr *TryExpr) Eval(env *LocalEnv) (obj Object) {
 	return evalBody(expr.body, env)
 }
 
+// catches returns whether the catch clause handles err: whether err is an
+// instance of one of its types or, for a tag, an ExInfo whose data's :type
+// is the tag or derived from it (see derive).
+func (expr *CatchExpr) catches(err Error) bool {
+	for i, excType := range expr.excTypes {
+		if !IsInstance(excType, err) {
+			continue
+		}
+		tag := expr.excTags[i]
+		if tag == nil {
+			return true
+		}
+		_, data := err.(*ExInfo).Get(KEYWORDS.data)
+		ok, t := data.(Map).Get(KEYWORDS.type_)
+		if !ok {
+			continue
+		}
+		if isa(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// isa returns whether child is parent or derived from it in the global
+// hierarchy, as (isa? child parent) does. Calling isa? itself would need
+// a call expression to trace, which a catch clause doesn't have.
+func isa(child Object, parent Object) bool {
+	if child.Equals(parent) {
+		return true
+	}
+	if c, ok := child.(*Vector); ok {
+		if p, ok := parent.(*Vector); ok && c.count == p.count {
+			for i := 0; i < c.count; i++ {
+				if !isa(c.at(i), p.at(i)) {
+					return false
+				}
+			}
+			return true
+		}
+		return false
+	}
+	h, ok := GLOBAL_ENV.CoreNamespace.Resolve("global-hierarchy__").Resolve().(*Atom).value.(Map)
+	if !ok {
+		return false
+	}
+	if ok, ancestors := h.Get(MakeKeyword("ancestors")); ok {
+		if ok, as := ancestors.(Map).Get(child); ok {
+			if as, ok := as.(Set); ok {
+				ok, _ := as.Get(parent)
+				return ok
+			}
+		}
+	}
+	return false
+}
+
 func (expr *CatchExpr) Eval(env *LocalEnv) (obj Object) {
 	panic(RT.NewError("This should never happen!"))
 }
@@ -429,8 +549,10 @@ func (expr *MacroCallExpr) Name() string {
 }
 
 func TryEval(expr Expr) (obj Object, err error) {
+	s := RT.SaveCallState()
 	defer func() {
 		if r := recover(); r != nil {
+			r = RT.Recovered(r, s)
 			switch r.(type) {
 			case *EvalError:
 				err = r.(error)
@@ -444,6 +566,15 @@ func TryEval(expr Expr) (obj Object, err error) {
 	return Eval(expr, nil), nil
 }
 
+// TryEvalLocked is TryEval for goroutines that don't hold the GIL, such
+// as the ones of a program embedding Joker: it waits for the GIL, so that
+// the evaluation doesn't race with the goroutines running Joker code.
+func TryEvalLocked(expr Expr) (Object, error) {
+	RT.GIL.Lock(nil)
+	defer RT.GIL.Unlock()
+	return TryEval(expr)
+}
+
 func PanicOnErr(err error) {
 	if err != nil {
 		panic(RT.NewError(err.Error()))
@@ -0,0 +1,149 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// sourceFiles caches the lines of the files errors have been reported
+// in; nil means the file couldn't be read.
+var sourceFiles = map[string][]string{}
+
+func sourceLine(filename string, line int) (string, bool) {
+	lines, ok := sourceFiles[filename]
+	if !ok {
+		if data, err := ioutil.ReadFile(filename); err == nil {
+			lines = strings.Split(string(data), "\n")
+		}
+		sourceFiles[filename] = lines
+	}
+	if line < 1 || line > len(lines) {
+		return "", false
+	}
+	return strings.TrimRight(lines[line-1], "\r"), true
+}
+
+// forgetSourceFile drops the cached lines of a file about to be
+// (re)loaded, so that excerpts and retained source reflect its current
+// contents.
+func forgetSourceFile(filename *string) {
+	if filename != nil {
+		delete(sourceFiles, *filename)
+	}
+}
+
+// sourceText returns the text pos spans in its file.
+func sourceText(pos Position) (string, bool) {
+	if pos.filename == nil {
+		return "", false
+	}
+	var b strings.Builder
+	for line := pos.startLine; line <= pos.endLine; line++ {
+		text, ok := sourceLine(*pos.filename, line)
+		if !ok {
+			return "", false
+		}
+		runes := []rune(text)
+		from, to := 0, len(runes)
+		if line == pos.startLine {
+			from = pos.startColumn - 1
+		}
+		if line == pos.endLine && pos.endColumn < to {
+			to = pos.endColumn
+		}
+		if from < 0 || from > to {
+			return "", false
+		}
+		if line > pos.startLine {
+			b.WriteString("\n")
+		}
+		b.WriteString(string(runes[from:to]))
+	}
+	return b.String(), true
+}
+
+// writeExcerpt writes the source line pos starts on, with a caret under
+// the start column followed by tildes up to the end column (or the end
+// of the line if pos spans several lines).
+func writeExcerpt(w io.Writer, pos Position) {
+	if pos.filename == nil {
+		return
+	}
+	text, ok := sourceLine(*pos.filename, pos.startLine)
+	if !ok {
+		return
+	}
+	runes := []rune(text)
+	start := pos.startColumn
+	if start < 1 || start > len(runes)+1 {
+		return
+	}
+	end := pos.endColumn
+	if pos.endLine != pos.startLine || end > len(runes) {
+		end = len(runes)
+	}
+	gutter := fmt.Sprintf("%d", pos.startLine)
+	var marker strings.Builder
+	for _, r := range runes[:start-1] {
+		// Keep tabs so the caret lines up with the source.
+		if r == '\t' {
+			marker.WriteRune('\t')
+		} else {
+			marker.WriteRune(' ')
+		}
+	}
+	marker.WriteRune('^')
+	for i := start; i < end; i++ {
+		marker.WriteRune('~')
+	}
+	fmt.Fprintf(w, " %s | %s\n", gutter, text)
+	fmt.Fprintf(w, " %s | %s\n", strings.Repeat(" ", len(gutter)), marker.String())
+}
+
+func writeMacroNotes(w io.Writer, macros []Seq) {
+	for _, form := range macros {
+		pos := GetPosition(form)
+		fmt.Fprintf(w, "note: expanded from macro %s at %s:%d:%d\n",
+			form.First().ToString(false), pos.Filename(), pos.startLine, pos.startColumn)
+	}
+}
+
+// PrintError prints err to w followed, outside of linter mode, by an
+// excerpt of the source it was reported for and, for parse errors
+// inside macroexpansions, the macros involved.
+func PrintError(w io.Writer, err error) {
+	if LINTER_MODE {
+		captureProblem(errorProblem(err), err.Error())
+	}
+	if ProblemLimitExceeded() {
+		return
+	}
+	if LINT_FORMAT != "text" {
+		lintProblems = append(lintProblems, errorProblem(err))
+		return
+	}
+	if LINTER_MODE {
+		printLintText(w, err.Error())
+		return
+	}
+	fmt.Fprintln(w, err)
+	switch err := err.(type) {
+	case *EvalError:
+		writeExcerpt(w, err.pos)
+	case *ParseError:
+		if info := err.obj.GetInfo(); info != nil {
+			writeExcerpt(w, info.Position)
+		}
+		writeMacroNotes(w, err.macros)
+	case ReadError:
+		writeExcerpt(w, Position{
+			startLine:   err.line,
+			startColumn: err.column,
+			endLine:     err.line,
+			endColumn:   err.column,
+			filename:    err.filename,
+		})
+	}
+}
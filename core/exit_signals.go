@@ -0,0 +1,44 @@
+//go:build !plan9
+// +build !plan9
+
+package core
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+var exitSignalsHandled bool
+
+// handleExitSignals makes SIGINT and SIGTERM run the shutdown sequence
+// and exit with the conventional status 128 + signal number.
+// The exit hooks only run if the GIL can be acquired in a reasonable time,
+// as the signal may arrive while Joker code is running.
+func handleExitSignals() {
+	if exitSignalsHandled {
+		return
+	}
+	exitSignalsHandled = true
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		sig := <-ch
+		locked := make(chan struct{})
+		go func() {
+			RT.GIL.Lock(nil)
+			close(locked)
+		}()
+		select {
+		case <-locked:
+			Shutdown()
+		case <-time.After(2 * time.Second):
+		}
+		rc := 1
+		if s, ok := sig.(syscall.Signal); ok {
+			rc = 128 + int(s)
+		}
+		os.Exit(rc)
+	}()
+}
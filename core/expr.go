@@ -205,8 +205,16 @@ func typeOfLast(exprs []Expr) *Type {
 	return nil
 }
 
+func (m *typeMemo) typeOfLast(exprs []Expr) *Type {
+	if !m.inferred {
+		m.t = typeOfLast(exprs)
+		m.inferred = true
+	}
+	return m.t
+}
+
 func (expr *DoExpr) InferType() *Type {
-	return typeOfLast(expr.body)
+	return expr.typeMemo.typeOfLast(expr.body)
 }
 
 func (expr *DoExpr) Dump(pos bool) Map {
@@ -251,7 +259,7 @@ func (expr *FnExpr) Dump(pos bool) Map {
 }
 
 func (expr *LetExpr) InferType() *Type {
-	return typeOfLast(expr.body)
+	return expr.typeMemo.typeOfLast(expr.body)
 }
 
 func (expr *LetExpr) Dump(pos bool) Map {
@@ -266,7 +274,7 @@ func (expr *LetExpr) Dump(pos bool) Map {
 }
 
 func (expr *LoopExpr) InferType() *Type {
-	return typeOfLast(expr.body)
+	return expr.typeMemo.typeOfLast(expr.body)
 }
 
 func (expr *LoopExpr) Dump(pos bool) Map {
@@ -291,19 +299,34 @@ func (expr *ThrowExpr) Dump(pos bool) Map {
 }
 
 func (expr *CatchExpr) InferType() *Type {
-	return typeOfLast(expr.body)
+	return expr.typeMemo.typeOfLast(expr.body)
 }
 
 func (expr *CatchExpr) Dump(pos bool) Map {
 	res := exprArrayMap(expr, "catch", pos)
-	res.Add(MakeKeyword("error-type"), expr.excType)
+	if len(expr.excTypes) == 1 {
+		res.Add(MakeKeyword("error-type"), expr.excTypes[0])
+		if expr.excTags[0] != nil {
+			res.Add(MakeKeyword("error-tag"), expr.excTags[0])
+		}
+	} else {
+		types := EmptyVector()
+		for i, excType := range expr.excTypes {
+			if expr.excTags[i] != nil {
+				types = types.Conjoin(expr.excTags[i])
+			} else {
+				types = types.Conjoin(excType)
+			}
+		}
+		res.Add(MakeKeyword("error-types"), types)
+	}
 	res.Add(MakeKeyword("error-symbol"), expr.excSymbol)
 	addVector(res, expr.body, "body", pos)
 	return res
 }
 
 func (expr *TryExpr) InferType() *Type {
-	return typeOfLast(expr.body)
+	return expr.typeMemo.typeOfLast(expr.body)
 }
 
 func (expr *TryExpr) Dump(pos bool) Map {
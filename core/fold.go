@@ -0,0 +1,143 @@
+package core
+
+// Constant folding: vector, map and set literals of constants and calls
+// of pure core functions with constant arguments are evaluated when parsed
+// and replaced with their values, so that the evaluator doesn't redo
+// the work every time they are evaluated. Likewise, an if whose
+// condition is a literal is replaced with the branch it would take.
+
+// Core functions that always return the same value for the same
+// (constant) arguments and have no side effects.
+var pureCoreFns = map[string]bool{
+	"+":       true,
+	"-":       true,
+	"*":       true,
+	"inc":     true,
+	"dec":     true,
+	"quot":    true,
+	"rem":     true,
+	"mod":     true,
+	"max":     true,
+	"min":     true,
+	"=":       true,
+	"not=":    true,
+	"<":       true,
+	">":       true,
+	"<=":      true,
+	">=":      true,
+	"not":     true,
+	"str":     true,
+	"keyword": true,
+	"symbol":  true,
+}
+
+// isConstantValue returns whether obj can be the value of a folded
+// expression. Only data that is printed readably (as literals are when
+// packed) and has no metadata qualifies.
+func isConstantValue(obj Object) bool {
+	switch obj := obj.(type) {
+	case Nil, Boolean, Int, String, Char, Keyword:
+		return true
+	case Symbol:
+		return obj.GetMeta() == nil
+	case *Vector:
+		if obj.GetMeta() != nil {
+			return false
+		}
+		for i := 0; i < obj.count; i++ {
+			if !isConstantValue(obj.at(i)) {
+				return false
+			}
+		}
+		return true
+	case *ArrayMap, *HashMap, *MapSet:
+		if obj.(Meta).GetMeta() != nil {
+			return false
+		}
+		for s := obj.(Seqable).Seq(); !s.IsEmpty(); s = s.Rest() {
+			if !isConstantValue(s.First()) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func isConstantExpr(expr Expr) bool {
+	e, ok := expr.(*LiteralExpr)
+	return ok && !e.isSurrogate && isConstantValue(e.obj)
+}
+
+func areConstantExprs(exprs []Expr) bool {
+	for _, expr := range exprs {
+		if !isConstantExpr(expr) {
+			return false
+		}
+	}
+	return true
+}
+
+func isFoldable(expr Expr) bool {
+	switch expr := expr.(type) {
+	case *VectorExpr:
+		return areConstantExprs(expr.v)
+	case *MapExpr:
+		return areConstantExprs(expr.keys) && areConstantExprs(expr.values)
+	case *SetExpr:
+		return areConstantExprs(expr.elements)
+	case *CallExpr:
+		vr, ok := expr.callable.(*VarRefExpr)
+		return ok && vr.vr.ns == GLOBAL_ENV.CoreNamespace && !vr.vr.isDynamic &&
+			pureCoreFns[*vr.vr.name.name] && areConstantExprs(expr.args)
+	}
+	return false
+}
+
+// foldConstant returns expr replaced with a LiteralExpr of its value if
+// it can be computed at parse time, or expr itself otherwise (including
+// when evaluating it fails, so that the error is raised at run time).
+func foldConstant(expr Expr) Expr {
+	if LINTER_MODE || !isFoldable(expr) {
+		return expr
+	}
+	obj, ok := evalConstant(expr)
+	if !ok || !isConstantValue(obj) {
+		return expr
+	}
+	return &LiteralExpr{
+		obj:      obj,
+		Position: expr.Pos(),
+	}
+}
+
+func evalConstant(expr Expr) (obj Object, ok bool) {
+	s := RT.SaveCallState()
+	defer func() {
+		if r := recover(); r != nil {
+			if _, isError := RT.Recovered(r, s).(Error); !isError {
+				panic(r)
+			}
+			obj, ok = nil, false
+		}
+	}()
+	return Eval(expr, nil), true
+}
+
+// pruneDeadBranch returns the branch of expr that is always taken if
+// its condition is a literal (possibly a folded one), or expr itself
+// otherwise. Both branches have been parsed by then, so errors in the
+// dead one are still reported.
+func pruneDeadBranch(expr *IfExpr) Expr {
+	if LINTER_MODE {
+		return expr
+	}
+	cond, ok := expr.cond.(*LiteralExpr)
+	if !ok || cond.isSurrogate {
+		return expr
+	}
+	if ToBool(cond.obj) {
+		return expr.positive
+	}
+	return expr.negative
+}
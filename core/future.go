@@ -0,0 +1,252 @@
+package core
+
+import (
+	"time"
+	"unsafe"
+)
+
+type (
+	Future struct {
+		done        chan struct{}
+		result      FutureResult
+		isCancelled bool
+		hash        uint32
+	}
+	Promise struct {
+		done  chan struct{}
+		value Object
+		hash  uint32
+	}
+)
+
+// await waits for done to be closed, with the GIL released so that
+// other goroutines can run meanwhile. A negative timeout means no
+// timeout. Returns false if the timeout has elapsed first.
+func await(done chan struct{}, timeout time.Duration) bool {
+	select {
+	case <-done:
+		return true
+	default:
+	}
+	gs := RT.GIL.Unlock()
+	defer RT.GIL.Lock(gs)
+	if timeout < 0 {
+		<-done
+		return true
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+func isClosed(done chan struct{}) bool {
+	select {
+	case <-done:
+		return true
+	default:
+		return false
+	}
+}
+
+// MakeFuture calls f in a new goroutine, which (like the ones started
+// by go) only runs while holding the GIL, with the current bindings.
+func MakeFuture(f Callable) *Future {
+	res := &Future{done: make(chan struct{})}
+	res.hash = HashPtr(uintptr(unsafe.Pointer(res)))
+	gs := RT.childState()
+	go func() {
+		RT.GIL.Lock(gs)
+		s := RT.SaveCallState()
+		defer func() {
+			if r := recover(); r != nil {
+				switch r := RT.Recovered(r, s).(type) {
+				case Error:
+					res.complete(NIL, r)
+				default:
+					RT.GIL.Unlock()
+					panic(r)
+				}
+			}
+			RT.GIL.Unlock()
+		}()
+		res.complete(f.Call([]Object{}), nil)
+	}()
+	return res
+}
+
+// complete must be called with the GIL locked. It's a no-op if the
+// future has been cancelled.
+func (fut *Future) complete(value Object, err Error) {
+	if !isClosed(fut.done) {
+		fut.result = MakeFutureResult(value, err)
+		close(fut.done)
+	}
+}
+
+// Cancel makes the future done without a value. There's no way to stop
+// the goroutine computing it, so that keeps running, but its result is
+// discarded. Returns false if the future was already done.
+func (fut *Future) Cancel() bool {
+	if isClosed(fut.done) {
+		return false
+	}
+	fut.isCancelled = true
+	close(fut.done)
+	return true
+}
+
+func (fut *Future) value() Object {
+	if fut.isCancelled {
+		panic(RT.NewError("Future has been cancelled"))
+	}
+	if fut.result.err != nil {
+		panic(fut.result.err)
+	}
+	return fut.result.value
+}
+
+func (fut *Future) ToString(escape bool) string {
+	return "#object[Future]"
+}
+
+func (fut *Future) Equals(other interface{}) bool {
+	return fut == other
+}
+
+func (fut *Future) GetInfo() *ObjectInfo {
+	return nil
+}
+
+func (fut *Future) GetType() *Type {
+	return TYPE.Future
+}
+
+func (fut *Future) Hash() uint32 {
+	return fut.hash
+}
+
+func (fut *Future) WithInfo(info *ObjectInfo) Object {
+	return fut
+}
+
+func (fut *Future) Deref() Object {
+	await(fut.done, -1)
+	return fut.value()
+}
+
+func (fut *Future) DerefWithTimeout(timeout time.Duration, timeoutValue Object) Object {
+	if !await(fut.done, timeout) {
+		return timeoutValue
+	}
+	return fut.value()
+}
+
+func (fut *Future) IsRealized() bool {
+	return isClosed(fut.done)
+}
+
+func MakePromise() *Promise {
+	res := &Promise{done: make(chan struct{})}
+	res.hash = HashPtr(uintptr(unsafe.Pointer(res)))
+	return res
+}
+
+// Deliver sets the value of the promise, unless it has already been
+// delivered, and returns true if it has been set.
+func (p *Promise) Deliver(value Object) bool {
+	if isClosed(p.done) {
+		return false
+	}
+	p.value = value
+	close(p.done)
+	return true
+}
+
+func (p *Promise) ToString(escape bool) string {
+	return "#object[Promise]"
+}
+
+func (p *Promise) Equals(other interface{}) bool {
+	return p == other
+}
+
+func (p *Promise) GetInfo() *ObjectInfo {
+	return nil
+}
+
+func (p *Promise) GetType() *Type {
+	return TYPE.Promise
+}
+
+func (p *Promise) Hash() uint32 {
+	return p.hash
+}
+
+func (p *Promise) WithInfo(info *ObjectInfo) Object {
+	return p
+}
+
+func (p *Promise) Deref() Object {
+	await(p.done, -1)
+	return p.value
+}
+
+func (p *Promise) DerefWithTimeout(timeout time.Duration, timeoutValue Object) Object {
+	if !await(p.done, timeout) {
+		return timeoutValue
+	}
+	return p.value
+}
+
+func (p *Promise) IsRealized() bool {
+	return isClosed(p.done)
+}
+
+var procFuture = func(args []Object) Object {
+	CheckArity(args, 1, 1)
+	return MakeFuture(EnsureArgIsCallable(args, 0))
+}
+
+var procFutureCancel = func(args []Object) Object {
+	CheckArity(args, 1, 1)
+	return Boolean{B: EnsureArgIsFuture(args, 0).Cancel()}
+}
+
+var procIsFutureCancelled = func(args []Object) Object {
+	CheckArity(args, 1, 1)
+	return Boolean{B: EnsureArgIsFuture(args, 0).isCancelled}
+}
+
+var procPromise = func(args []Object) Object {
+	CheckArity(args, 0, 0)
+	return MakePromise()
+}
+
+var procDeliver = func(args []Object) Object {
+	CheckArity(args, 2, 2)
+	p := EnsureArgIsPromise(args, 0)
+	if p.Deliver(args[1]) {
+		return p
+	}
+	return NIL
+}
+
+var procDerefWithTimeout = func(args []Object) Object {
+	CheckArity(args, 3, 3)
+	timeout := time.Duration(EnsureArgIsInt(args, 1).I) * time.Millisecond
+	if timeout < 0 {
+		timeout = 0
+	}
+	switch ref := args[0].(type) {
+	case *Future:
+		return ref.DerefWithTimeout(timeout, args[2])
+	case *Promise:
+		return ref.DerefWithTimeout(timeout, args[2])
+	}
+	panic(FailArg(args[0], "Future or Promise", 0))
+}
@@ -5,8 +5,12 @@ package main
 
 // Imports of std libraries required by core libraries go here.
 import (
+	_ "github.com/candid82/joker/std/diff"
 	_ "github.com/candid82/joker/std/html"
+	_ "github.com/candid82/joker/std/os"
+	_ "github.com/candid82/joker/std/rand"
 	_ "github.com/candid82/joker/std/string"
+	_ "github.com/candid82/joker/std/term"
 )
 
 import (
@@ -55,6 +59,14 @@ var CoreSourceFiles []FileInfo = []FileInfo{
 		Name:     "<joker.test>",
 		Filename: "test.joke",
 	},
+	{
+		Name:     "<joker.gen>",
+		Filename: "gen.joke",
+	},
+	{
+		Name:     "<joker.check>",
+		Filename: "check.joke",
+	},
 	{
 		Name:     "<joker.set>",
 		Filename: "set.joke",
@@ -63,6 +75,38 @@ var CoreSourceFiles []FileInfo = []FileInfo{
 		Name:     "<joker.tools.cli>",
 		Filename: "tools_cli.joke",
 	},
+	{
+		Name:     "<joker.cli>",
+		Filename: "cli.joke",
+	},
+	{
+		Name:     "<joker.prompt>",
+		Filename: "prompt.joke",
+	},
+	{
+		Name:     "<joker.zip>",
+		Filename: "zip.joke",
+	},
+	{
+		Name:     "<joker.datafy>",
+		Filename: "datafy.joke",
+	},
+	{
+		Name:     "<joker.match>",
+		Filename: "match.joke",
+	},
+	{
+		Name:     "<joker.datalog>",
+		Filename: "datalog.joke",
+	},
+	{
+		Name:     "<joker.async>",
+		Filename: "async.joke",
+	},
+	{
+		Name:     "<joker.spec>",
+		Filename: "spec.joke",
+	},
 	{
 		Name:     "<joker.core>",
 		Filename: "linter_all.joke",
@@ -97,6 +141,19 @@ var CoreSourceFiles []FileInfo = []FileInfo{
 	},
 }
 
+/* Types registered by std libraries required by core libraries can't be
+   referenced from core; those libraries register them again at runtime. */
+func coreTypes() map[*string]*Type {
+	stdPrefix := path.Dir(reflect.TypeOf(Type{}).PkgPath()) + "/std/"
+	types := map[*string]*Type{}
+	for name, t := range TYPES {
+		if !strings.HasPrefix(t.ReflectType().PkgPath(), stdPrefix) {
+			types[name] = t
+		}
+	}
+	return types
+}
+
 func parseArgs(args []string) {
 	length := len(args)
 	stop := false
@@ -261,6 +318,10 @@ func init() {
 func main() {
 	parseArgs(os.Args)
 
+	// Fn bodies compiled while reading the core libraries can't be
+	// emitted as Go code, so use the tree-walking Eval.
+	COMPILE = false
+
 	coreSourceFilename := map[string]string{}
 	namespaceIndex := 0
 	var namespaces = map[string]int{}
@@ -413,7 +474,7 @@ func main() {
 	genGo.Var("SPECIAL_SYMBOLS", false, SPECIAL_SYMBOLS)
 	genGo.Var("KEYWORDS", false, KEYWORDS)
 	genGo.Var("TYPE", false, TYPE)
-	genGo.Var("TYPES", false, TYPES)
+	genGo.Var("TYPES", false, coreTypes())
 	genGo.Var("LINTER_TYPES", false, LINTER_TYPES)
 	genGo.Var("GLOBAL_ENV", true, GLOBAL_ENV) // init var at runtime to avoid cycles
 
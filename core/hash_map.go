@@ -18,6 +18,7 @@ type (
 		MetaHolder
 		count int
 		root  Node
+		hash  uint32
 	}
 	BitmapIndexedNode struct {
 		bitmap int
@@ -176,8 +177,8 @@ func (s *ArrayNodeSeq) ToString(escape bool) string {
 	return SeqToString(s, escape)
 }
 
-func (seq *ArrayNodeSeq) Pprint(w io.Writer, indent int) int {
-	return pprintSeq(seq, w, indent)
+func (seq *ArrayNodeSeq) Pprint(pp *PrettyPrinter, w io.Writer, indent int) int {
+	return pprintSeq(pp, seq, w, indent)
 }
 
 func (seq *ArrayNodeSeq) Format(w io.Writer, indent int) int {
@@ -269,8 +270,8 @@ func (s *NodeSeq) ToString(escape bool) string {
 	return SeqToString(s, escape)
 }
 
-func (seq *NodeSeq) Pprint(w io.Writer, indent int) int {
-	return pprintSeq(seq, w, indent)
+func (seq *NodeSeq) Pprint(pp *PrettyPrinter, w io.Writer, indent int) int {
+	return pprintSeq(pp, seq, w, indent)
 }
 
 func (seq *NodeSeq) Format(w io.Writer, indent int) int {
@@ -677,7 +678,7 @@ func (b *BitmapIndexedNode) find(shift uint, hash uint32, key Object) *Pair {
 	if keyOrNull == nil {
 		return valOrNode.(Node).find(shift+5, hash, key)
 	}
-	if key.Equals(keyOrNull) {
+	if keyEquals(key, keyOrNull) {
 		return &Pair{
 			Key:   keyOrNull.(Object),
 			Value: valOrNode.(Object),
@@ -709,7 +710,10 @@ func (m *HashMap) GetType() *Type {
 }
 
 func (m *HashMap) Hash() uint32 {
-	return hashUnordered(m.Seq(), 1)
+	if m.hash == 0 {
+		m.hash = hashUnordered(m.Seq(), 1)
+	}
+	return m.hash
 }
 
 func (m *HashMap) Seq() Seq {
@@ -853,6 +857,6 @@ func (m *HashMap) Empty() Collection {
 	return EmptyHashMap
 }
 
-func (m *HashMap) Pprint(w io.Writer, indent int) int {
-	return pprintMap(m, w, indent)
+func (m *HashMap) Pprint(pp *PrettyPrinter, w io.Writer, indent int) int {
+	return pprintMap(pp, m, w, indent)
 }
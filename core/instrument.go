@@ -0,0 +1,103 @@
+package core
+
+// CallHook holds functions called around the calls of vars made by
+// evaluated code; either may be nil. After is only called if the call
+// returns normally.
+type CallHook struct {
+	Before func(vr *Var, args []Object, pos Position)
+	After  func(vr *Var, args []Object, pos Position, result Object)
+}
+
+type keyedCallHook struct {
+	key  Object
+	hook *CallHook
+}
+
+var (
+	callHooks  []keyedCallHook
+	inCallHook bool
+)
+
+// AddCallHook registers hook under key, replacing the hook previously
+// registered under it, if any. Hooks run in the order they were added.
+func AddCallHook(key Object, hook *CallHook) {
+	for i := range callHooks {
+		if callHooks[i].key.Equals(key) {
+			callHooks[i].hook = hook
+			return
+		}
+	}
+	callHooks = append(callHooks, keyedCallHook{key: key, hook: hook})
+}
+
+// RemoveCallHook unregisters the hook registered under key, returning
+// whether there was one.
+func RemoveCallHook(key Object) bool {
+	for i := range callHooks {
+		if callHooks[i].key.Equals(key) {
+			callHooks = append(callHooks[:i], callHooks[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// callWithHooks calls callable, the value of vr, with args, running the
+// registered hooks around it. Calls made by the hooks themselves aren't
+// hooked.
+func callWithHooks(vr *Var, callable Callable, args []Object, pos Position) Object {
+	if inCallHook {
+		return callable.Call(args)
+	}
+	hooks := callHooks
+	runHooks := func(f func(h *CallHook)) {
+		inCallHook = true
+		defer func() { inCallHook = false }()
+		for _, h := range hooks {
+			f(h.hook)
+		}
+	}
+	runHooks(func(h *CallHook) {
+		if h.Before != nil {
+			h.Before(vr, args, pos)
+		}
+	})
+	res := callable.Call(args)
+	runHooks(func(h *CallHook) {
+		if h.After != nil {
+			h.After(vr, args, pos, res)
+		}
+	})
+	return res
+}
+
+func jokerCallHook(before, after Object) *CallHook {
+	hook := &CallHook{}
+	if !before.Equals(NIL) {
+		f := before.(Callable)
+		hook.Before = func(vr *Var, args []Object, pos Position) {
+			f.Call([]Object{vr, NewVectorFrom(args...), dumpPosition(pos)})
+		}
+	}
+	if !after.Equals(NIL) {
+		f := after.(Callable)
+		hook.After = func(vr *Var, args []Object, pos Position, result Object) {
+			f.Call([]Object{vr, NewVectorFrom(args...), dumpPosition(pos), result})
+		}
+	}
+	return hook
+}
+
+var procAddCallHook = func(args []Object) Object {
+	for i := 1; i <= 2; i++ {
+		if !args[i].Equals(NIL) {
+			EnsureArgIsCallable(args, i)
+		}
+	}
+	AddCallHook(args[0], jokerCallHook(args[1], args[2]))
+	return args[0]
+}
+
+var procRemoveCallHook = func(args []Object) Object {
+	return Boolean{B: RemoveCallHook(args[0])}
+}
@@ -1,14 +1,19 @@
 package core
 
 type (
+	// StringPool holds the strings interned while generating the core
+	// code, which are never dropped. At runtime it's only read from:
+	// strings interned by running programs are kept in a separate table
+	// that is safe for concurrent use (see intern_fast_init.go).
 	StringPool map[string]*string
 )
 
+// Intern returns the canonical pointer to a string equal to s, so that
+// interned strings can be compared by pointer.
 func (p StringPool) Intern(s string) *string {
 	ss, exists := p[s]
 	if exists {
 		return ss
 	}
-	p[s] = &s
-	return &s
+	return p.add(s)
 }
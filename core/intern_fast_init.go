@@ -0,0 +1,59 @@
+//go:build !gen_code
+// +build !gen_code
+
+package core
+
+import (
+	"sync"
+)
+
+const internShardCount = 64
+
+// EvictUnusedStrings controls whether strings interned at runtime (such as
+// names of symbols and keywords created from data read by a program) are
+// dropped once nothing refers to them anymore, so that long-running
+// programs don't accumulate them. Only has effect when Joker is built
+// with Go 1.24 or later; otherwise they are kept forever.
+var EvictUnusedStrings = true
+
+type internShard struct {
+	sync.Mutex
+	strings map[string]internRef
+}
+
+var internShards [internShardCount]internShard
+
+func internShardFor(s string) *internShard {
+	// FNV-1a
+	h := uint32(2166136261)
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= 16777619
+	}
+	return &internShards[h%internShardCount]
+}
+
+func (p StringPool) add(s string) *string {
+	shard := internShardFor(s)
+	shard.Lock()
+	defer shard.Unlock()
+	if ss := shard.strings[s].get(); ss != nil {
+		return ss
+	}
+	if shard.strings == nil {
+		shard.strings = map[string]internRef{}
+	}
+	ss := &s
+	shard.strings[s] = makeInternRef(shard, ss)
+	return ss
+}
+
+// remove drops the entry for s if it still refers to ref, which is no longer
+// referenced elsewhere.
+func (shard *internShard) remove(s string, ref internRef) {
+	shard.Lock()
+	defer shard.Unlock()
+	if shard.strings[s] == ref {
+		delete(shard.strings, s)
+	}
+}
@@ -4,3 +4,8 @@
 package core
 
 var STRINGS StringPool = StringPool{}
+
+func (p StringPool) add(s string) *string {
+	p[s] = &s
+	return &s
+}
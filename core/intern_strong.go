@@ -0,0 +1,16 @@
+//go:build !go1.24 && !gen_code
+// +build !go1.24,!gen_code
+
+package core
+
+type internRef struct {
+	strong *string
+}
+
+func makeInternRef(shard *internShard, ss *string) internRef {
+	return internRef{strong: ss}
+}
+
+func (ref internRef) get() *string {
+	return ref.strong
+}
@@ -0,0 +1,51 @@
+//go:build !gen_code
+// +build !gen_code
+
+package core
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestInternReturnsTheSamePointer(t *testing.T) {
+	p := StringPool{}
+	// Strings built at runtime, so that they don't share their bytes.
+	a := p.Intern("intern-" + strconv.Itoa(1))
+	b := p.Intern("intern-" + strconv.Itoa(1))
+	if a != b {
+		t.Fatalf("interning %q twice gave %p and %p", *a, a, b)
+	}
+	if c := p.Intern("intern-" + strconv.Itoa(2)); c == a {
+		t.Fatalf("interning %q and %q gave the same pointer", *a, *c)
+	}
+}
+
+func TestInternConcurrently(t *testing.T) {
+	const goroutines = 16
+	const names = 1000
+	p := StringPool{}
+	res := make([][]*string, goroutines)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			res[g] = make([]*string, names)
+			for i := 0; i < names; i++ {
+				// Each goroutine interns the names in a different order.
+				n := (i + g*names/goroutines) % names
+				res[g][n] = p.Intern("concurrent-" + strconv.Itoa(n))
+			}
+		}(g)
+	}
+	wg.Wait()
+	for g := 1; g < goroutines; g++ {
+		for n := 0; n < names; n++ {
+			if res[g][n] != res[0][n] {
+				t.Fatalf("goroutines 0 and %d got different pointers for %q", g, *res[0][n])
+			}
+		}
+	}
+}
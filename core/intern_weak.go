@@ -0,0 +1,32 @@
+//go:build go1.24 && !gen_code
+// +build go1.24,!gen_code
+
+package core
+
+import (
+	"runtime"
+	"weak"
+)
+
+type internRef struct {
+	strong *string
+	weak   weak.Pointer[string]
+}
+
+func makeInternRef(shard *internShard, ss *string) internRef {
+	if !EvictUnusedStrings {
+		return internRef{strong: ss}
+	}
+	ref := internRef{weak: weak.Make(ss)}
+	runtime.AddCleanup(ss, func(s string) {
+		shard.remove(s, ref)
+	}, *ss)
+	return ref
+}
+
+func (ref internRef) get() *string {
+	if ref.strong != nil {
+		return ref.strong
+	}
+	return ref.weak.Value()
+}
@@ -0,0 +1,63 @@
+//go:build go1.24 && !gen_code
+// +build go1.24,!gen_code
+
+package core
+
+import (
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func isInterned(s string) bool {
+	shard := internShardFor(s)
+	shard.Lock()
+	defer shard.Unlock()
+	_, ok := shard.strings[s]
+	return ok
+}
+
+// collected returns whether s gets dropped from the intern table within
+// a second, running the GC (and so the cleanups) meanwhile.
+func collected(s string) bool {
+	for i := 0; i < 100; i++ {
+		runtime.GC()
+		if !isInterned(s) {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
+
+func TestInternDropsUnusedStrings(t *testing.T) {
+	s := "dropped-" + strconv.Itoa(1)
+	StringPool{}.Intern(s)
+	if !collected(s) {
+		t.Fatalf("%q is still interned, although nothing refers to it", s)
+	}
+}
+
+func TestInternKeepsUsedStrings(t *testing.T) {
+	p := StringPool{}
+	s := "kept-" + strconv.Itoa(1)
+	ss := p.Intern(s)
+	if collected(s) {
+		t.Fatalf("%q has been dropped, although it's still referred to", s)
+	}
+	if p.Intern(s) != ss {
+		t.Fatalf("interning %q again gave a different pointer", s)
+	}
+	runtime.KeepAlive(ss)
+}
+
+func TestInternKeepsStringsWhenNotEvicting(t *testing.T) {
+	EvictUnusedStrings = false
+	defer func() { EvictUnusedStrings = true }()
+	s := "not-evicted-" + strconv.Itoa(1)
+	StringPool{}.Intern(s)
+	if collected(s) {
+		t.Fatalf("%q has been dropped, although EvictUnusedStrings is false", s)
+	}
+}
@@ -0,0 +1,490 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// The lint cache: when LINT_CACHE_DIR is set (by --lint-cache), what
+// the linter finds in a file is saved to a file of that directory named
+// after the hash of its source, its name, the Joker version and the
+// linter's configuration: the problems, the namespaces and vars the file
+// defines, and the ones it uses. Linting the file again replays these
+// instead of reading and parsing it, unless the vars of the namespaces
+// it uses (defined by other files) have changed since.
+//
+// Cached vars keep a summary of their values (such as the arities of
+// fns, with their args' type hints) that is enough to check calls to
+// them, but not to infer the types of all of them.
+
+var LINT_CACHE_DIR string
+
+type (
+	lintCacheEntry struct {
+		Problems   []cachedProblem   `json:"problems"`
+		Namespaces []cachedNamespace `json:"namespaces"`
+		Vars       []cachedVar       `json:"vars"`
+		Uses       []cachedUse       `json:"uses"`
+		Deps       map[string]string `json:"deps"` // namespace name -> nsFingerprint
+		Failed     bool              `json:"failed,omitempty"`
+	}
+	cachedPosition struct {
+		Line      int `json:"line,omitempty"`
+		Column    int `json:"column,omitempty"`
+		EndLine   int `json:"endLine,omitempty"`
+		EndColumn int `json:"endColumn,omitempty"`
+	}
+	cachedProblem struct {
+		cachedPosition
+		File    string `json:"file"`
+		Kind    string `json:"kind"`
+		Rule    string `json:"rule,omitempty"`
+		Message string `json:"message"`
+		Text    string `json:"text,omitempty"` // set for errors, as printed by PrintError
+	}
+	cachedNamespace struct {
+		cachedPosition
+		Name string `json:"name"`
+	}
+	cachedVar struct {
+		cachedPosition
+		Ns       string        `json:"ns"`
+		Name     string        `json:"name"`
+		Private  bool          `json:"private,omitempty"`
+		Macro    bool          `json:"macro,omitempty"`
+		Dynamic  bool          `json:"dynamic,omitempty"`
+		Fake     bool          `json:"fake,omitempty"`
+		Kind     string        `json:"kind,omitempty"` // "fn", "map", "set", "vector", "literal" or "other"
+		Arities  [][]cachedArg `json:"arities,omitempty"`
+		Variadic []cachedArg   `json:"variadic,omitempty"`
+		Literal  string        `json:"literal,omitempty"`
+	}
+	cachedArg struct {
+		Name   string `json:"name"`
+		Tag    string `json:"tag,omitempty"`
+		Kwargs bool   `json:"kwargs,omitempty"`
+	}
+	cachedUse struct {
+		Ns   string `json:"ns"`
+		Name string `json:"name,omitempty"` // empty if the namespace itself is used
+	}
+)
+
+var (
+	// lintCapture collects the problems found in the file being linted
+	// for the cache.
+	lintCapture *[]cachedProblem
+
+	// lintConfigFiles are the files configuring the linter (.joker and
+	// the linter files of .jokerd), whose contents are part of the key
+	// of every cached file.
+	lintConfigFiles []string
+	lintCacheSalt   string
+
+	errCachedFailure = errors.New("linting failed when cached")
+)
+
+// DefaultLintCacheDir returns ~/.joker-cache/lint.
+func DefaultLintCacheDir() (string, error) {
+	dir, err := DefaultNSCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "lint"), nil
+}
+
+func lintCacheFilename(filename string, src []byte) string {
+	if lintCacheSalt == "" {
+		h := sha256.New()
+		fmt.Fprintf(h, "%s\n%d\n%s\n", VERSION, DIALECT, GLOBAL_ENV.Features.ToString(false))
+		for _, f := range lintConfigFiles {
+			io.WriteString(h, f+"\n")
+			if p, err := ioutil.ReadFile(f); err == nil {
+				h.Write(p)
+			}
+		}
+		lintCacheSalt = hex.EncodeToString(h.Sum(nil))
+	}
+	h := sha256.New()
+	io.WriteString(h, lintCacheSalt+"\n"+filename+"\n")
+	h.Write(src)
+	return filepath.Join(LINT_CACHE_DIR, hex.EncodeToString(h.Sum(nil))+".json")
+}
+
+// CachedLint lints filename by calling lint, unless the lint cache
+// has what linting its current source finds, which it replays instead.
+func CachedLint(filename string, lint func() error) error {
+	if LINT_CACHE_DIR == "" || filename == "-" {
+		return lint()
+	}
+	src, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return lint()
+	}
+	cacheFilename := lintCacheFilename(filename, src)
+	if p, err := ioutil.ReadFile(cacheFilename); err == nil {
+		var entry lintCacheEntry
+		if json.Unmarshal(p, &entry) == nil && entry.isCurrent() {
+			return entry.replay(filename)
+		}
+	}
+	usedBefore := takeGlobalUsage()
+	problems := []cachedProblem{}
+	lintCapture = &problems
+	err = lint()
+	lintCapture = nil
+	used := takeGlobalUsage()
+	usedBefore.restore()
+	used.restore()
+	if ProblemLimitExceeded() {
+		// The problems of filename may have been cut short.
+		return err
+	}
+	entry := newLintCacheEntry(filename, problems, used)
+	entry.Failed = err != nil
+	// Caching is best effort: the file is linted either way.
+	if p, err := json.Marshal(entry); err == nil && os.MkdirAll(LINT_CACHE_DIR, 0777) == nil {
+		ioutil.WriteFile(cacheFilename, p, 0666)
+	}
+	return err
+}
+
+func captureProblem(p lintProblem, text string) {
+	if lintCapture == nil {
+		return
+	}
+	*lintCapture = append(*lintCapture, cachedProblem{
+		cachedPosition: makeCachedPosition(p.pos),
+		File:           p.pos.Filename(),
+		Kind:           p.kind,
+		Rule:           p.rule,
+		Message:        p.msg,
+		Text:           text,
+	})
+}
+
+func makeCachedPosition(pos Position) cachedPosition {
+	return cachedPosition{Line: pos.startLine, Column: pos.startColumn, EndLine: pos.endLine, EndColumn: pos.endColumn}
+}
+
+func (p cachedPosition) position(filename string) Position {
+	return Position{
+		filename:    STRINGS.Intern(filename),
+		startLine:   p.Line,
+		startColumn: p.Column,
+		endLine:     p.EndLine,
+		endColumn:   p.EndColumn,
+	}
+}
+
+// globalUsage holds the namespaces, and the vars outside of joker.core,
+// that are globally used.
+type globalUsage struct {
+	namespaces []*Namespace
+	vars       []*Var
+}
+
+// takeGlobalUsage returns the global usage so far, clearing it so that
+// what the next file uses can be told apart.
+func takeGlobalUsage() globalUsage {
+	var res globalUsage
+	for _, ns := range GLOBAL_ENV.AllNamespaces() {
+		if ns.isGloballyUsed {
+			res.namespaces = append(res.namespaces, ns)
+			ns.isGloballyUsed = false
+		}
+		if ns == GLOBAL_ENV.CoreNamespace {
+			continue
+		}
+		for _, vr := range ns.Mappings() {
+			if vr.ns == ns && vr.isGloballyUsed {
+				res.vars = append(res.vars, vr)
+				vr.isGloballyUsed = false
+			}
+		}
+	}
+	return res
+}
+
+func (u globalUsage) restore() {
+	for _, ns := range u.namespaces {
+		ns.isGloballyUsed = true
+	}
+	for _, vr := range u.vars {
+		vr.isGloballyUsed = true
+	}
+}
+
+func newLintCacheEntry(filename string, problems []cachedProblem, used globalUsage) *lintCacheEntry {
+	entry := &lintCacheEntry{Problems: problems, Deps: map[string]string{}}
+	defined := map[string]bool{}
+	for _, ns := range GLOBAL_ENV.AllNamespaces() {
+		if info := ns.Name.GetInfo(); info != nil && info.Filename() == filename {
+			entry.Namespaces = append(entry.Namespaces, cachedNamespace{
+				cachedPosition: makeCachedPosition(info.Position),
+				Name:           ns.Name.Name(),
+			})
+			defined[ns.Name.Name()] = true
+		}
+		if ns == GLOBAL_ENV.CoreNamespace {
+			continue
+		}
+		for _, vr := range ns.Mappings() {
+			if info := vr.GetInfo(); vr.ns == ns && info != nil && info.Filename() == filename {
+				entry.Vars = append(entry.Vars, makeCachedVar(vr))
+				defined[ns.Name.Name()] = true
+			}
+		}
+	}
+	for _, ns := range used.namespaces {
+		entry.Uses = append(entry.Uses, cachedUse{Ns: ns.Name.Name()})
+	}
+	for _, vr := range used.vars {
+		entry.Uses = append(entry.Uses, cachedUse{Ns: vr.ns.Name.Name(), Name: *vr.name.name})
+	}
+	for _, use := range entry.Uses {
+		if !defined[use.Ns] {
+			if ns := GLOBAL_ENV.FindNamespace(MakeSymbol(use.Ns)); ns != nil && ns != GLOBAL_ENV.CoreNamespace {
+				entry.Deps[use.Ns] = nsFingerprint(ns)
+			}
+		}
+	}
+	sort.Slice(entry.Uses, func(i, j int) bool {
+		if entry.Uses[i].Ns != entry.Uses[j].Ns {
+			return entry.Uses[i].Ns < entry.Uses[j].Ns
+		}
+		return entry.Uses[i].Name < entry.Uses[j].Name
+	})
+	return entry
+}
+
+func makeCachedVar(vr *Var) cachedVar {
+	v := cachedVar{
+		Ns:      vr.ns.Name.Name(),
+		Name:    *vr.name.name,
+		Private: vr.isPrivate,
+		Macro:   vr.isMacro,
+		Dynamic: vr.isDynamic,
+		Fake:    vr.isFake,
+	}
+	if info := vr.GetInfo(); info != nil {
+		v.cachedPosition = makeCachedPosition(info.Position)
+	}
+	switch expr := vr.expr.(type) {
+	case nil:
+	case *FnExpr:
+		v.Kind = "fn"
+		for _, arity := range expr.arities {
+			v.Arities = append(v.Arities, makeCachedArgs(arity.args))
+		}
+		if expr.variadic != nil {
+			v.Variadic = makeCachedArgs(expr.variadic.args)
+		}
+	case *MapExpr:
+		v.Kind = "map"
+	case *SetExpr:
+		v.Kind = "set"
+	case *VectorExpr:
+		v.Kind = "vector"
+	case *LiteralExpr:
+		v.Kind = "other"
+		if !expr.isSurrogate {
+			switch expr.obj.(type) {
+			case Keyword, String, Int, Double, Boolean, Char, Nil:
+				v.Kind = "literal"
+				v.Literal = expr.obj.ToString(true)
+			}
+		}
+	default:
+		v.Kind = "other"
+	}
+	return v
+}
+
+func makeCachedArgs(args []Symbol) []cachedArg {
+	res := []cachedArg{}
+	for _, arg := range args {
+		a := cachedArg{Name: *arg.name}
+		if m := arg.GetMeta(); m != nil {
+			if ok, tag := m.Get(KEYWORDS.tag); ok {
+				switch tag := tag.(type) {
+				case Symbol:
+					a.Tag = tag.ToString(false)
+				case String:
+					a.Tag = tag.S
+				}
+			}
+			if ok, kwargs := m.Get(KEYWORDS.kwargs); ok {
+				a.Kwargs = ToBool(kwargs)
+			}
+		}
+		res = append(res, a)
+	}
+	return res
+}
+
+// nsFingerprint hashes what the files using ns can see of the vars
+// defined in it, leaving out where they are defined. ns may be nil, as
+// if it had no vars.
+func nsFingerprint(ns *Namespace) string {
+	var vars []string
+	if ns != nil {
+		for _, vr := range ns.Mappings() {
+			if vr.ns == ns && vr.GetInfo() != nil {
+				v := makeCachedVar(vr)
+				v.cachedPosition = cachedPosition{}
+				p, _ := json.Marshal(v)
+				vars = append(vars, string(p))
+			}
+		}
+	}
+	sort.Strings(vars)
+	h := sha256.New()
+	for _, v := range vars {
+		io.WriteString(h, v+"\n")
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// isCurrent returns whether the namespaces entry's file uses are as
+// they were when it was cached.
+func (entry *lintCacheEntry) isCurrent() bool {
+	for name, fingerprint := range entry.Deps {
+		if nsFingerprint(GLOBAL_ENV.FindNamespace(MakeSymbol(name))) != fingerprint {
+			return false
+		}
+	}
+	return true
+}
+
+func (entry *lintCacheEntry) replay(filename string) error {
+	for _, n := range entry.Namespaces {
+		sym := MakeSymbol(n.Name).WithInfo(&ObjectInfo{Position: n.position(filename)}).(Symbol)
+		GLOBAL_ENV.EnsureSymbolIsNamespace(sym).isUsed = true
+	}
+	for _, v := range entry.Vars {
+		v.restore(filename)
+	}
+	for _, use := range entry.Uses {
+		ns := GLOBAL_ENV.EnsureSymbolIsNamespace(MakeSymbol(use.Ns))
+		if use.Name == "" {
+			ns.isGloballyUsed = true
+		} else {
+			internCachedVar(ns, use.Name, true).isGloballyUsed = true
+		}
+	}
+	for _, p := range entry.Problems {
+		p.replay()
+	}
+	if entry.Failed {
+		return errCachedFailure
+	}
+	return nil
+}
+
+// internCachedVar returns the var named name that ns defines, creating
+// it if needed.
+func internCachedVar(ns *Namespace, name string, fake bool) *Var {
+	sym := Symbol{name: STRINGS.Intern(name)}
+	nsLock.Lock()
+	defer nsLock.Unlock()
+	vr, ok := ns.mappings[sym.name]
+	if !ok || vr.ns != ns {
+		vr = &Var{ns: ns, name: sym, isFake: fake}
+		ns.mappings[sym.name] = vr
+	}
+	return vr
+}
+
+func (v cachedVar) restore(filename string) {
+	ns := GLOBAL_ENV.EnsureSymbolIsNamespace(MakeSymbol(v.Ns))
+	vr := internCachedVar(ns, v.Name, v.Fake)
+	vr.WithInfo(&ObjectInfo{Position: v.position(filename)})
+	vr.isPrivate = v.Private
+	vr.isMacro = v.Macro
+	vr.isDynamic = v.Dynamic
+	vr.isFake = v.Fake
+	vr.isUsed = true
+	switch v.Kind {
+	case "fn":
+		expr := &FnExpr{}
+		for _, args := range v.Arities {
+			expr.arities = append(expr.arities, FnArityExpr{args: restoreCachedArgs(args)})
+		}
+		if v.Variadic != nil {
+			expr.variadic = &FnArityExpr{args: restoreCachedArgs(v.Variadic)}
+		}
+		vr.expr = expr
+	case "map":
+		vr.expr = &MapExpr{}
+	case "set":
+		vr.expr = &SetExpr{}
+	case "vector":
+		vr.expr = &VectorExpr{}
+	case "literal":
+		if obj, ok := readCachedLiteral(v.Literal); ok {
+			vr.expr = &LiteralExpr{obj: obj}
+		} else {
+			vr.expr = &LiteralExpr{obj: NIL, isSurrogate: true}
+		}
+	case "other":
+		vr.expr = &LiteralExpr{obj: NIL, isSurrogate: true}
+	}
+}
+
+func restoreCachedArgs(args []cachedArg) []Symbol {
+	var res []Symbol
+	for _, a := range args {
+		sym := Symbol{name: STRINGS.Intern(a.Name)}
+		if a.Tag != "" || a.Kwargs {
+			m := EmptyArrayMap()
+			if a.Tag != "" {
+				m.Add(KEYWORDS.tag, MakeString(a.Tag))
+			}
+			if a.Kwargs {
+				m.Add(KEYWORDS.kwargs, Boolean{B: true})
+			}
+			sym = sym.WithMeta(m).(Symbol)
+		}
+		res = append(res, sym)
+	}
+	return res
+}
+
+func readCachedLiteral(s string) (obj Object, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			ok = false
+		}
+	}()
+	obj, multi := Read(NewReader(strings.NewReader(s), "<lint-cache>"))
+	return obj, !multi
+}
+
+// replay reports p again, as printError or PrintError did when it was
+// cached.
+func (p cachedProblem) replay() {
+	problem := lintProblem{pos: p.position(p.File), kind: p.Kind, rule: p.Rule, msg: p.Message}
+	if p.Text == "" {
+		reportProblem(problem)
+		return
+	}
+	countError()
+	if ProblemLimitExceeded() {
+		return
+	}
+	if LINT_FORMAT != "text" {
+		lintProblems = append(lintProblems, problem)
+		return
+	}
+	printLintText(Stderr, p.Text)
+}
@@ -0,0 +1,260 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Machine-readable output of lint problems (--format=sarif, json or
+// edn), for CI and editor integrations. Each problem has a rule id that,
+// unlike its message, isn't going to change.
+
+type (
+	lintProblem struct {
+		pos  Position
+		kind string // "Parse warning", "Read error" etc.
+		rule string // e.g. "unused-binding"; empty if kind says it all
+		msg  string
+	}
+	jsonLintProblem struct {
+		File      string `json:"file"`
+		Line      int    `json:"line"`
+		Column    int    `json:"column"`
+		EndLine   int    `json:"endLine,omitempty"`
+		EndColumn int    `json:"endColumn,omitempty"`
+		Rule      string `json:"rule"`
+		Severity  string `json:"severity"`
+		Message   string `json:"message"`
+	}
+
+	// SARIF (Static Analysis Results Interchange Format) 2.1.0, as
+	// consumed by e.g. GitHub Code Scanning. See
+	// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		Version        string      `json:"version"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID               string       `json:"id"`
+		ShortDescription sarifMessage `json:"shortDescription"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		RuleIndex int             `json:"ruleIndex"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	}
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Region           *sarifRegion          `json:"region,omitempty"`
+	}
+	sarifArtifactLocation struct {
+		URI string `json:"uri"`
+	}
+	sarifRegion struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn,omitempty"`
+		EndLine     int `json:"endLine,omitempty"`
+		EndColumn   int `json:"endColumn,omitempty"`
+	}
+)
+
+var (
+	// LINT_FORMAT is the format lint problems are output in: "text"
+	// prints each of them to stderr as soon as it's found, while
+	// "sarif", "json" and "edn" collect them for WriteLintProblems.
+	LINT_FORMAT  = "text"
+	lintProblems []lintProblem
+)
+
+// printLintText prints text, a problem in text format, to w, unless a
+// LintWatcher is collecting the problems of its pass.
+func printLintText(w io.Writer, text string) {
+	if lintText != nil {
+		*lintText = append(*lintText, text)
+		return
+	}
+	fmt.Fprintln(w, text)
+}
+
+// errorProblem returns the problem PrintError prints err as.
+func errorProblem(err error) lintProblem {
+	switch err := err.(type) {
+	case *EvalError:
+		pos := err.pos
+		if len(err.rt.callstack.frames) > 0 {
+			pos = err.rt.callstack.frames[0].traceable.Pos()
+		}
+		return lintProblem{pos: pos, kind: "Eval error", msg: err.msg}
+	case *ParseError:
+		var pos Position
+		if info := err.obj.GetInfo(); info != nil {
+			pos = info.Position
+		}
+		return lintProblem{pos: pos, kind: "Parse error", msg: err.msg}
+	case ReadError:
+		pos := Position{filename: err.filename, startLine: err.line, startColumn: err.column}
+		return lintProblem{pos: pos, kind: "Read error", msg: err.msg}
+	case *ExInfo:
+		pos, kind, rule, msg := err.problem()
+		return lintProblem{pos: pos, kind: kind, rule: rule, msg: msg}
+	}
+	return lintProblem{kind: "Exception", msg: err.Error()}
+}
+
+// ruleID returns the rule of p or, failing that, its kind as an id,
+// e.g. "parse-error".
+func (p lintProblem) ruleID() string {
+	if p.rule != "" {
+		return p.rule
+	}
+	return strings.ToLower(strings.ReplaceAll(p.kind, " ", "-"))
+}
+
+func (p lintProblem) severity() string {
+	return kindSeverity(p.kind)
+}
+
+// sarifLevel returns the SARIF level of p, which calls infos notes.
+func (p lintProblem) sarifLevel() string {
+	if s := p.severity(); s != "info" {
+		return s
+	}
+	return "note"
+}
+
+// WriteLintProblems writes the problems the linter collected to w,
+// in LINT_FORMAT.
+func WriteLintProblems(w io.Writer) error {
+	switch LINT_FORMAT {
+	case "sarif":
+		return writeSARIF(w)
+	case "json":
+		return writeJSON(w)
+	case "edn":
+		return writeEDN(w)
+	}
+	return fmt.Errorf("unknown lint output format: %s", LINT_FORMAT)
+}
+
+func writeJSON(w io.Writer) error {
+	res := []jsonLintProblem{}
+	for _, p := range lintProblems {
+		res = append(res, jsonLintProblem{
+			File:      p.pos.Filename(),
+			Line:      p.pos.startLine,
+			Column:    p.pos.startColumn,
+			EndLine:   p.pos.endLine,
+			EndColumn: p.pos.endColumn,
+			Rule:      p.ruleID(),
+			Severity:  p.severity(),
+			Message:   p.msg,
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(res)
+}
+
+// writeEDN writes a vector of maps, one per line.
+func writeEDN(w io.Writer) error {
+	var b strings.Builder
+	b.WriteRune('[')
+	for i, p := range lintProblems {
+		if i > 0 {
+			b.WriteString("\n ")
+		}
+		m := EmptyArrayMap()
+		m.Add(MakeKeyword("file"), MakeString(p.pos.Filename()))
+		m.Add(MakeKeyword("line"), Int{I: p.pos.startLine})
+		m.Add(MakeKeyword("column"), Int{I: p.pos.startColumn})
+		if p.pos.endLine > 0 {
+			m.Add(MakeKeyword("end-line"), Int{I: p.pos.endLine})
+			m.Add(MakeKeyword("end-column"), Int{I: p.pos.endColumn})
+		}
+		m.Add(MakeKeyword("rule"), MakeKeyword(p.ruleID()))
+		m.Add(MakeKeyword("severity"), MakeKeyword(p.severity()))
+		m.Add(MakeKeyword("message"), MakeString(p.msg))
+		b.WriteString(m.ToString(true))
+	}
+	b.WriteString("]\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func sarifLocationOf(pos Position) sarifLocation {
+	uri := filepath.ToSlash(pos.Filename())
+	if filepath.IsAbs(pos.Filename()) {
+		uri = "file://" + uri
+	}
+	loc := sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: uri}}}
+	if pos.startLine > 0 {
+		// SARIF's endColumn is the column after the last character.
+		loc.PhysicalLocation.Region = &sarifRegion{
+			StartLine:   pos.startLine,
+			StartColumn: pos.startColumn,
+			EndLine:     pos.endLine,
+			EndColumn:   pos.endColumn + 1,
+		}
+	}
+	return loc
+}
+
+func writeSARIF(w io.Writer) error {
+	driver := sarifDriver{
+		Name:           "joker",
+		Version:        VERSION,
+		InformationURI: "https://github.com/candid82/joker",
+		Rules:          []sarifRule{},
+	}
+	results := []sarifResult{}
+	ruleIndexes := map[string]int{}
+	for _, p := range lintProblems {
+		id := p.ruleID()
+		index, ok := ruleIndexes[id]
+		if !ok {
+			index = len(driver.Rules)
+			ruleIndexes[id] = index
+			driver.Rules = append(driver.Rules, sarifRule{ID: id, ShortDescription: sarifMessage{Text: p.kind}})
+		}
+		results = append(results, sarifResult{
+			RuleID:    id,
+			RuleIndex: index,
+			Level:     p.sarifLevel(),
+			Message:   sarifMessage{Text: p.msg},
+			Locations: []sarifLocation{sarifLocationOf(p.pos)},
+		})
+	}
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
@@ -0,0 +1,96 @@
+package core
+
+// Linting the same files over and over (for --watch) without restarting
+// Joker: what linting them changes in the environment (the namespaces
+// and vars they define, the aliases and refers of their ns forms, and
+// whether namespaces and vars are used) is saved once the linter has
+// been configured, and restored after each pass so that the next one
+// doesn't find the files' own defs already there.
+
+type (
+	// LintWatcher runs lint passes over the same files, each as if it
+	// were the first, collecting the problems they find as text.
+	LintWatcher struct {
+		namespaces map[*string]*Namespace
+		nsStates   map[*Namespace]nsState
+		vars       map[*Var]Var
+		loadedLibs *MapSet
+	}
+	nsState struct {
+		mappings       map[*string]*Var
+		aliases        map[*string]*Namespace
+		isUsed         bool
+		isGloballyUsed bool
+	}
+)
+
+// lintText collects the problems that would be printed in text format
+// during a LintWatcher's pass.
+var lintText *[]string
+
+// NewLintWatcher saves the environment for the lint passes to start
+// from; call it after configuring the linter, before linting.
+func NewLintWatcher() *LintWatcher {
+	w := &LintWatcher{
+		namespaces: GLOBAL_ENV.AllNamespaces(),
+		nsStates:   map[*Namespace]nsState{},
+		vars:       map[*Var]Var{},
+		loadedLibs: NewSetFromSeq(GLOBAL_ENV.libs.Value.(*MapSet).Seq()),
+	}
+	for _, ns := range w.namespaces {
+		state := nsState{
+			mappings:       ns.Mappings(),
+			aliases:        ns.Aliases(),
+			isUsed:         ns.isUsed,
+			isGloballyUsed: ns.isGloballyUsed,
+		}
+		w.nsStates[ns] = state
+		for _, vr := range state.mappings {
+			w.vars[vr] = *vr
+		}
+	}
+	return w
+}
+
+// Pass calls lint, returning the problems found as the lines they would
+// be printed as, and then restores the environment NewLintWatcher saved.
+func (w *LintWatcher) Pass(lint func()) []string {
+	problems := []string{}
+	lintText = &problems
+	defer func() {
+		lintText = nil
+		w.restore()
+	}()
+	lint()
+	return problems
+}
+
+func (w *LintWatcher) restore() {
+	namespaces := make(map[*string]*Namespace, len(w.namespaces))
+	for k, ns := range w.namespaces {
+		namespaces[k] = ns
+	}
+	nsLock.Lock()
+	GLOBAL_ENV.Namespaces = namespaces
+	for ns, state := range w.nsStates {
+		ns.mappings = make(map[*string]*Var, len(state.mappings))
+		for k, vr := range state.mappings {
+			ns.mappings[k] = vr
+		}
+		ns.aliases = make(map[*string]*Namespace, len(state.aliases))
+		for k, alias := range state.aliases {
+			ns.aliases[k] = alias
+		}
+		ns.isUsed = state.isUsed
+		ns.isGloballyUsed = state.isGloballyUsed
+	}
+	nsLock.Unlock()
+	for vr, saved := range w.vars {
+		*vr = saved
+	}
+	// *loaded-libs* is added to in place.
+	GLOBAL_ENV.libs.Value = NewSetFromSeq(w.loadedLibs.Seq())
+	ignoreComments = map[*string]map[int][]string{}
+	PROBLEM_COUNT, ERROR_COUNT, INFO_COUNT = 0, 0, 0
+	lintProblems = nil
+}
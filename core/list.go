@@ -2,13 +2,19 @@ package core
 
 import "io"
 
-type List struct {
-	InfoHolder
-	MetaHolder
-	first Object
-	rest  *List
-	count int
-}
+type (
+	List struct {
+		InfoHolder
+		MetaHolder
+		first Object
+		rest  *List
+		count int
+		hash  uint32
+	}
+	ListIterator struct {
+		list *List
+	}
+)
 
 func NewList(first Object, rest *List) *List {
 	result := List{
@@ -47,8 +53,8 @@ func (list *List) ToString(escape bool) string {
 	return SeqToString(list, escape)
 }
 
-func (seq *List) Pprint(w io.Writer, indent int) int {
-	return pprintSeq(seq, w, indent)
+func (seq *List) Pprint(pp *PrettyPrinter, w io.Writer, indent int) int {
+	return pprintSeq(pp, seq, w, indent)
 }
 
 func (seq *List) Format(w io.Writer, indent int) int {
@@ -64,7 +70,10 @@ func (list *List) GetType() *Type {
 }
 
 func (list *List) Hash() uint32 {
-	return hashOrdered(list)
+	if list.hash == 0 {
+		list.hash = hashOrdered(list)
+	}
+	return list.hash
 }
 
 func (list *List) First() Object {
@@ -87,6 +96,20 @@ func (list *List) Seq() Seq {
 	return list
 }
 
+func (list *List) Iter() Iterator {
+	return &ListIterator{list: list}
+}
+
+func (iter *ListIterator) HasNext() bool {
+	return iter.list.count > 0
+}
+
+func (iter *ListIterator) Next() Object {
+	res := iter.list.first
+	iter.list = iter.list.rest
+	return res
+}
+
 func (list *List) Second() Object {
 	return list.rest.first
 }
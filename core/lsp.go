@@ -0,0 +1,867 @@
+package core
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// A Language Server Protocol (LSP) server for editors, speaking JSON-RPC
+// over stdio (see joker --lsp). Diagnostics are the problems the linter
+// finds in a document, as reported by joker --lint --format=json run on
+// its text, since linting in this process would leave the vars it
+// defines behind. That runs once changes to the document settle, and is
+// cancelled by the next change. Hover, go-to-definition and completion
+// come from the defs of the open documents (read, but not parsed or
+// evaluated) and the vars of the namespaces Joker comes with. Positions
+// count UTF-16 code units, as the protocol has it, unless the client
+// accepts utf-32, which is how Joker counts columns. See
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/
+
+type (
+	lspServer struct {
+		in        *bufio.Reader
+		out       io.Writer
+		documents map[string]*lspDocument // by URI
+		lints     map[string]*lspLint     // pending or running, by URI
+		running   sync.WaitGroup
+		utf16     bool
+		shutdown  bool
+		// sendLock serializes the messages sent, as diagnostics are sent
+		// by the goroutines linting.
+		sendLock sync.Mutex
+	}
+	lspLint struct {
+		timer  *time.Timer
+		cancel context.CancelFunc
+	}
+	lspDocument struct {
+		uri     string
+		path    string
+		text    string
+		ns      string
+		aliases map[string]string // alias -> namespace
+		refers  map[string]string // referred name -> namespace
+		defs    map[string]*lspDef
+	}
+	lspDef struct {
+		name     string
+		pos      Position
+		doc      string
+		arglists []string
+		private  bool
+	}
+
+	lspMessage struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	lspPosition struct {
+		Line      int `json:"line"`
+		Character int `json:"character"`
+	}
+	lspRange struct {
+		Start lspPosition `json:"start"`
+		End   lspPosition `json:"end"`
+	}
+	lspLocation struct {
+		URI   string   `json:"uri"`
+		Range lspRange `json:"range"`
+	}
+	lspInitializeParams struct {
+		Capabilities struct {
+			General struct {
+				PositionEncodings []string `json:"positionEncodings"`
+			} `json:"general"`
+		} `json:"capabilities"`
+	}
+	lspTextDocument struct {
+		URI  string `json:"uri"`
+		Text string `json:"text"`
+	}
+	lspDocumentParams struct {
+		TextDocument   lspTextDocument `json:"textDocument"`
+		Position       lspPosition     `json:"position"`
+		ContentChanges []struct {
+			Text string `json:"text"`
+		} `json:"contentChanges"`
+	}
+	lspDiagnostic struct {
+		Range    lspRange `json:"range"`
+		Severity int      `json:"severity"`
+		Code     string   `json:"code,omitempty"`
+		Source   string   `json:"source"`
+		Message  string   `json:"message"`
+	}
+	lspMarkupContent struct {
+		Kind  string `json:"kind"`
+		Value string `json:"value"`
+	}
+	lspCompletionItem struct {
+		Label         string            `json:"label"`
+		Kind          int               `json:"kind"`
+		Detail        string            `json:"detail,omitempty"`
+		Documentation *lspMarkupContent `json:"documentation,omitempty"`
+		TextEdit      lspTextEdit       `json:"textEdit"`
+	}
+	lspTextEdit struct {
+		Range   lspRange `json:"range"`
+		NewText string   `json:"newText"`
+	}
+)
+
+// LSP error codes and completion item kinds.
+const (
+	lspMethodNotFound = -32601
+	lspInvalidParams  = -32602
+
+	lspFunctionItem = 3
+	lspVariableItem = 6
+	lspModuleItem   = 9
+)
+
+// lspLintDelay is how long the server waits after a document changes for
+// more changes before linting it.
+const lspLintDelay = 200 * time.Millisecond
+
+var errLSPExitWithoutShutdown = errors.New("exit notification received before shutdown")
+
+// LSP serves LSP requests read from in, writing responses and
+// notifications to out, until the client sends the exit notification
+// (or closes in).
+func LSP(in io.Reader, out io.Writer) error {
+	s := &lspServer{
+		in:        bufio.NewReader(in),
+		out:       out,
+		documents: map[string]*lspDocument{},
+		lints:     map[string]*lspLint{},
+		utf16:     true,
+	}
+	// Lets the lints pending or running publish their diagnostics.
+	defer s.running.Wait()
+	for {
+		p, err := s.read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var msg lspMessage
+		if err := json.Unmarshal(p, &msg); err != nil {
+			return err
+		}
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return errLSPExitWithoutShutdown
+			}
+			return nil
+		}
+		s.handle(&msg)
+	}
+}
+
+// read returns the content of the next message, which follows headers
+// giving its Content-Length.
+func (s *lspServer) read() ([]byte, error) {
+	length := -1
+	for {
+		line, err := s.in.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		if i := strings.IndexByte(line, ':'); i > 0 && strings.EqualFold(line[:i], "Content-Length") {
+			if length, err = strconv.Atoi(strings.TrimSpace(line[i+1:])); err != nil {
+				return nil, fmt.Errorf("invalid Content-Length: %s", line[i+1:])
+			}
+		}
+	}
+	if length < 0 {
+		return nil, errors.New("message without Content-Length")
+	}
+	p := make([]byte, length)
+	_, err := io.ReadFull(s.in, p)
+	return p, err
+}
+
+func (s *lspServer) send(msg map[string]interface{}) {
+	s.sendUnlessCancelled(context.Background(), msg)
+}
+
+func (s *lspServer) sendUnlessCancelled(ctx context.Context, msg map[string]interface{}) {
+	msg["jsonrpc"] = "2.0"
+	p, err := json.Marshal(msg)
+	PanicOnErr(err)
+	s.sendLock.Lock()
+	defer s.sendLock.Unlock()
+	if ctx.Err() == nil {
+		fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n%s", len(p), p)
+	}
+}
+
+func (s *lspServer) respond(id json.RawMessage, result interface{}) {
+	s.send(map[string]interface{}{"id": id, "result": result})
+}
+
+func (s *lspServer) respondError(id json.RawMessage, code int, msg string) {
+	s.send(map[string]interface{}{"id": id, "error": map[string]interface{}{"code": code, "message": msg}})
+}
+
+func (s *lspServer) notify(method string, params interface{}) {
+	s.send(map[string]interface{}{"method": method, "params": params})
+}
+
+func (s *lspServer) handle(msg *lspMessage) {
+	isRequest := len(msg.ID) > 0
+	var params lspDocumentParams
+	if len(msg.Params) > 0 {
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			if isRequest {
+				s.respondError(msg.ID, lspInvalidParams, err.Error())
+			}
+			return
+		}
+	}
+	switch msg.Method {
+	case "initialize":
+		var init lspInitializeParams
+		json.Unmarshal(msg.Params, &init)
+		encoding := "utf-16"
+		for _, e := range init.Capabilities.General.PositionEncodings {
+			if e == "utf-32" {
+				encoding = e
+				s.utf16 = false
+			}
+		}
+		s.respond(msg.ID, map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"positionEncoding":   encoding,
+				"textDocumentSync":   1, // full
+				"hoverProvider":      true,
+				"definitionProvider": true,
+				"completionProvider": map[string]interface{}{"triggerCharacters": []string{"/"}},
+			},
+			"serverInfo": map[string]interface{}{"name": "joker", "version": VERSION},
+		})
+	case "shutdown":
+		s.shutdown = true
+		s.respond(msg.ID, nil)
+	case "textDocument/didOpen":
+		s.update(params.TextDocument.URI, params.TextDocument.Text)
+	case "textDocument/didChange":
+		if n := len(params.ContentChanges); n > 0 {
+			s.update(params.TextDocument.URI, params.ContentChanges[n-1].Text)
+		}
+	case "textDocument/didSave":
+		if doc := s.documents[params.TextDocument.URI]; doc != nil {
+			s.scheduleLint(doc)
+		}
+	case "textDocument/didClose":
+		s.cancelLint(params.TextDocument.URI)
+		delete(s.documents, params.TextDocument.URI)
+		s.notify("textDocument/publishDiagnostics", map[string]interface{}{"uri": params.TextDocument.URI, "diagnostics": []lspDiagnostic{}})
+	case "textDocument/hover":
+		s.respond(msg.ID, s.hover(&params))
+	case "textDocument/definition":
+		s.respond(msg.ID, s.definition(&params))
+	case "textDocument/completion":
+		s.respond(msg.ID, s.completion(&params))
+	default:
+		if isRequest {
+			s.respondError(msg.ID, lspMethodNotFound, "method not found: "+msg.Method)
+		}
+	}
+}
+
+func (s *lspServer) update(uri string, text string) {
+	doc := &lspDocument{uri: uri, path: uriPath(uri), text: text}
+	doc.analyze()
+	s.documents[uri] = doc
+	s.scheduleLint(doc)
+}
+
+// scheduleLint lints doc and publishes its diagnostics after lspLintDelay,
+// unless it changes (or is closed) first. A change also cancels the lint
+// if it's already running.
+func (s *lspServer) scheduleLint(doc *lspDocument) {
+	s.cancelLint(doc.uri)
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &lspLint{cancel: cancel}
+	s.running.Add(1)
+	l.timer = time.AfterFunc(lspLintDelay, func() {
+		defer s.running.Done()
+		s.publishDiagnostics(ctx, doc, doc.lint(ctx))
+	})
+	s.lints[doc.uri] = l
+}
+
+func (s *lspServer) cancelLint(uri string) {
+	l := s.lints[uri]
+	if l == nil {
+		return
+	}
+	delete(s.lints, uri)
+	if l.timer.Stop() {
+		s.running.Done()
+	}
+	// Holding sendLock, so that once cancelled the lint can't publish
+	// its diagnostics anymore (see sendUnlessCancelled).
+	s.sendLock.Lock()
+	l.cancel()
+	s.sendLock.Unlock()
+}
+
+func uriPath(uri string) string {
+	if u, err := url.Parse(uri); err == nil && u.Scheme == "file" {
+		return filepath.FromSlash(u.Path)
+	}
+	return uri
+}
+
+func pathURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+// lint returns the problems the linter finds in the text of doc, using
+// the .joker config of doc's directory. It kills the linter when ctx is
+// cancelled.
+func (doc *lspDocument) lint(ctx context.Context) []jsonLintProblem {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil
+	}
+	dialect := "clj"
+	switch filepath.Ext(doc.path) {
+	case ".cljs":
+		dialect = "cljs"
+	case ".joke":
+		dialect = "joker"
+	case ".edn":
+		dialect = "edn"
+	}
+	cmd := exec.CommandContext(ctx, exe, "--lint", "--format=json", "--dialect", dialect, "--working-dir", filepath.Dir(doc.path), "-")
+	cmd.Stdin = strings.NewReader(doc.text)
+	// The linter exits non-zero when it finds problems.
+	out, _ := cmd.Output()
+	var problems []jsonLintProblem
+	json.Unmarshal(out, &problems)
+	return problems
+}
+
+// publishDiagnostics publishes problems as the diagnostics of doc, unless
+// ctx is cancelled.
+func (s *lspServer) publishDiagnostics(ctx context.Context, doc *lspDocument, problems []jsonLintProblem) {
+	lines := strings.Split(doc.text, "\n")
+	diagnostics := []lspDiagnostic{}
+	for _, p := range problems {
+		d := lspDiagnostic{
+			Range:   lspRange{Start: lspPosition{p.Line - 1, p.Column - 1}, End: lspPosition{p.Line - 1, p.Column}},
+			Code:    p.Rule,
+			Source:  "joker",
+			Message: p.Message,
+		}
+		if p.EndLine > 0 {
+			d.Range.End = lspPosition{p.EndLine - 1, p.EndColumn}
+		}
+		d.Range = s.encodeRange(lines, d.Range)
+		switch p.Severity {
+		case "error":
+			d.Severity = 1
+		case "warning":
+			d.Severity = 2
+		default:
+			d.Severity = 3
+		}
+		diagnostics = append(diagnostics, d)
+	}
+	s.sendUnlessCancelled(ctx, map[string]interface{}{
+		"method": "textDocument/publishDiagnostics",
+		"params": map[string]interface{}{"uri": doc.uri, "diagnostics": diagnostics},
+	})
+}
+
+// encode returns the LSP position of pos, whose character is a rune
+// index into its line of lines.
+func (s *lspServer) encode(lines []string, pos lspPosition) lspPosition {
+	if !s.utf16 || pos.Line < 0 || pos.Line >= len(lines) {
+		return pos
+	}
+	runes, n := pos.Character, 0
+	pos.Character = 0
+	for _, r := range lines[pos.Line] {
+		if n == runes {
+			break
+		}
+		n++
+		pos.Character += utf16Len(r)
+	}
+	pos.Character += runes - n
+	return pos
+}
+
+// decode is the inverse of encode.
+func (s *lspServer) decode(lines []string, pos lspPosition) lspPosition {
+	if !s.utf16 || pos.Line < 0 || pos.Line >= len(lines) {
+		return pos
+	}
+	units, n := pos.Character, 0
+	pos.Character = 0
+	for _, r := range lines[pos.Line] {
+		if n >= units {
+			break
+		}
+		n += utf16Len(r)
+		pos.Character++
+	}
+	if n < units {
+		pos.Character += units - n
+	}
+	return pos
+}
+
+// utf16Len returns the number of UTF-16 code units encoding r.
+func utf16Len(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
+
+func (s *lspServer) encodeRange(lines []string, r lspRange) lspRange {
+	return lspRange{Start: s.encode(lines, r.Start), End: s.encode(lines, r.End)}
+}
+
+// analyze reads the forms of doc for its namespace, the aliases and
+// refers of its ns form, and its defs. It stops at the first form that
+// can't be read.
+func (doc *lspDocument) analyze() {
+	doc.aliases = map[string]string{}
+	doc.refers = map[string]string{}
+	doc.defs = map[string]*lspDef{}
+	defer func() {
+		recover()
+	}()
+	reader := NewReader(strings.NewReader(doc.text), doc.path)
+	for {
+		obj, err := TryRead(reader)
+		if err != nil {
+			return
+		}
+		seq, ok := obj.(Seq)
+		if !ok || seq.IsEmpty() {
+			continue
+		}
+		head, ok := seq.First().(Symbol)
+		if !ok {
+			continue
+		}
+		switch head.Name() {
+		case "ns":
+			doc.analyzeNs(ToSlice(seq.Rest()))
+		default:
+			if strings.HasPrefix(head.Name(), "def") {
+				doc.analyzeDef(head.Name(), ToSlice(seq.Rest()))
+			}
+		}
+	}
+}
+
+func (doc *lspDocument) analyzeNs(args []Object) {
+	if len(args) == 0 {
+		return
+	}
+	if name, ok := args[0].(Symbol); ok {
+		doc.ns = name.Name()
+	}
+	for _, clause := range args[1:] {
+		seq, ok := clause.(Seq)
+		if !ok || seq.IsEmpty() {
+			continue
+		}
+		if k, ok := seq.First().(Keyword); !ok || (k.Name() != "require" && k.Name() != "use") {
+			continue
+		}
+		for _, spec := range ToSlice(seq.Rest()) {
+			v, ok := spec.(*Vector)
+			if !ok || v.Count() == 0 {
+				continue
+			}
+			lib, ok := v.Nth(0).(Symbol)
+			if !ok {
+				continue
+			}
+			for i := 1; i+1 < v.Count(); i += 2 {
+				k, ok := v.Nth(i).(Keyword)
+				if !ok {
+					continue
+				}
+				switch opt := v.Nth(i + 1); k.Name() {
+				case "as":
+					if alias, ok := opt.(Symbol); ok {
+						doc.aliases[alias.Name()] = lib.Name()
+					}
+				case "refer":
+					if refers, ok := opt.(*Vector); ok {
+						for j := 0; j < refers.Count(); j++ {
+							if sym, ok := refers.Nth(j).(Symbol); ok {
+								doc.refers[sym.Name()] = lib.Name()
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func (doc *lspDocument) analyzeDef(kind string, args []Object) {
+	if len(args) == 0 {
+		return
+	}
+	name, ok := args[0].(Symbol)
+	if !ok || name.GetInfo() == nil {
+		return
+	}
+	def := &lspDef{name: name.Name(), pos: name.GetInfo().Position, private: kind == "defn-"}
+	if m := name.GetMeta(); m != nil {
+		if ok, p := m.Get(KEYWORDS.private); ok {
+			def.private = ToBool(p)
+		}
+	}
+	args = args[1:]
+	isFn := kind == "defn" || kind == "defn-" || kind == "defmacro"
+	if s, ok := firstString(args); ok && (isFn || len(args) > 1) {
+		def.doc = s
+		args = args[1:]
+	}
+	if isFn {
+		if len(args) > 0 {
+			if _, ok := args[0].(Map); ok {
+				args = args[1:]
+			}
+		}
+		if len(args) > 0 {
+			if v, ok := args[0].(*Vector); ok {
+				def.arglists = append(def.arglists, v.ToString(false))
+				args = nil
+			}
+		}
+		for _, arity := range args {
+			if seq, ok := arity.(Seq); ok && !seq.IsEmpty() {
+				if v, ok := seq.First().(*Vector); ok {
+					def.arglists = append(def.arglists, v.ToString(false))
+				}
+			}
+		}
+	}
+	doc.defs[def.name] = def
+}
+
+func firstString(objs []Object) (string, bool) {
+	if len(objs) > 0 {
+		if s, ok := objs[0].(String); ok {
+			return s.S, true
+		}
+	}
+	return "", false
+}
+
+func isLSPSymbolRune(r rune) bool {
+	return !unicode.IsSpace(r) && !strings.ContainsRune("()[]{}\",;`~^@\\", r)
+}
+
+// symbolAt returns the symbol at pos in doc (or, if prefix, the part of
+// it before pos) and the range it spans, their characters counting runes
+// (see decode).
+func (doc *lspDocument) symbolAt(pos lspPosition, prefix bool) (string, lspRange) {
+	lines := strings.Split(doc.text, "\n")
+	if pos.Line < 0 || pos.Line >= len(lines) {
+		return "", lspRange{}
+	}
+	line := []rune(lines[pos.Line])
+	start := pos.Character
+	if start > len(line) {
+		start = len(line)
+	}
+	end := start
+	for start > 0 && isLSPSymbolRune(line[start-1]) {
+		start--
+	}
+	for !prefix && end < len(line) && isLSPSymbolRune(line[end]) {
+		end++
+	}
+	for start < end && (line[start] == '\'' || line[start] == '#') {
+		start++
+	}
+	return string(line[start:end]), lspRange{Start: lspPosition{pos.Line, start}, End: lspPosition{pos.Line, end}}
+}
+
+// resolve returns the namespace (empty if unqualified and not referred)
+// and name that sym refers to in doc.
+func (doc *lspDocument) resolve(sym string) (string, string) {
+	if i := strings.IndexByte(sym, '/'); i > 0 && i < len(sym)-1 {
+		ns := sym[:i]
+		if target, ok := doc.aliases[ns]; ok {
+			ns = target
+		}
+		return ns, sym[i+1:]
+	}
+	return doc.refers[sym], sym
+}
+
+// findNamespace returns the namespace of Joker named name or, failing
+// that, the joker.* namespace standing in for a clojure.* one.
+func findNamespace(name string) *Namespace {
+	if ns := GLOBAL_ENV.FindNamespace(MakeSymbol(name)); ns != nil {
+		return ns
+	}
+	if strings.HasPrefix(name, "clojure.") {
+		return GLOBAL_ENV.FindNamespace(MakeSymbol("joker." + strings.TrimPrefix(name, "clojure.")))
+	}
+	return nil
+}
+
+// lookup returns the def or the var that sym refers to in doc.
+func (s *lspServer) lookup(doc *lspDocument, sym string) (*lspDocument, *lspDef, *Var) {
+	ns, name := doc.resolve(sym)
+	if ns == "" || ns == doc.ns {
+		if def := doc.defs[name]; def != nil {
+			return doc, def, nil
+		}
+	}
+	if ns == "" {
+		return nil, nil, GLOBAL_ENV.CoreNamespace.Resolve(name)
+	}
+	for _, other := range s.documents {
+		if other.ns == ns && other.defs[name] != nil {
+			return other, other.defs[name], nil
+		}
+	}
+	if n := findNamespace(ns); n != nil {
+		return nil, nil, n.Resolve(name)
+	}
+	return nil, nil, nil
+}
+
+func (s *lspServer) hover(params *lspDocumentParams) interface{} {
+	doc := s.documents[params.TextDocument.URI]
+	if doc == nil {
+		return nil
+	}
+	lines := strings.Split(doc.text, "\n")
+	sym, r := doc.symbolAt(s.decode(lines, params.Position), false)
+	if sym == "" {
+		return nil
+	}
+	var name, docstring string
+	var arglists []string
+	if defDoc, def, vr := s.lookup(doc, sym); def != nil {
+		name, docstring, arglists = def.name, def.doc, def.arglists
+		if defDoc.ns != "" {
+			name = defDoc.ns + "/" + name
+		}
+	} else if vr != nil {
+		name, docstring, arglists = vr.Name(), varDoc(vr), varArglists(vr)
+	} else {
+		return nil
+	}
+	return map[string]interface{}{
+		"contents": lspMarkupContent{Kind: "markdown", Value: hoverMarkdown(name, arglists, docstring)},
+		"range":    s.encodeRange(lines, r),
+	}
+}
+
+func hoverMarkdown(name string, arglists []string, doc string) string {
+	var b strings.Builder
+	b.WriteString("```clojure\n")
+	if len(arglists) == 0 {
+		b.WriteString(name + "\n")
+	}
+	for _, arglist := range arglists {
+		b.WriteString("(" + name + " " + arglist + ")\n")
+	}
+	b.WriteString("```")
+	if doc != "" {
+		b.WriteString("\n\n" + doc)
+	}
+	return b.String()
+}
+
+func varDoc(vr *Var) string {
+	if m := vr.GetMeta(); m != nil {
+		if ok, doc := m.Get(KEYWORDS.doc); ok {
+			if s, ok := doc.(String); ok {
+				return s.S
+			}
+		}
+	}
+	return ""
+}
+
+func varArglists(vr *Var) []string {
+	var res []string
+	if m := vr.GetMeta(); m != nil {
+		if ok, arglists := m.Get(KEYWORDS.arglist); ok {
+			if seq, ok := arglists.(Seqable); ok {
+				for _, arglist := range ToSlice(seq.Seq()) {
+					res = append(res, arglist.ToString(false))
+				}
+			}
+		}
+	}
+	return res
+}
+
+func (s *lspServer) definition(params *lspDocumentParams) interface{} {
+	doc := s.documents[params.TextDocument.URI]
+	if doc == nil {
+		return nil
+	}
+	sym, _ := doc.symbolAt(s.decode(strings.Split(doc.text, "\n"), params.Position), false)
+	if sym == "" {
+		return nil
+	}
+	defDoc, def, vr := s.lookup(doc, sym)
+	if def != nil {
+		return lspLocation{URI: defDoc.uri, Range: s.encodeRange(strings.Split(defDoc.text, "\n"), positionRange(def.pos))}
+	}
+	if vr == nil || vr.GetMeta() == nil {
+		return nil
+	}
+	// Vars of the namespaces Joker comes with only have a file if it
+	// was loaded from disk.
+	m := vr.GetMeta()
+	ok, file := m.Get(KEYWORDS.file)
+	if !ok {
+		return nil
+	}
+	path, ok := file.(String)
+	if !ok || !filepath.IsAbs(path.S) {
+		return nil
+	}
+	text, err := os.ReadFile(path.S)
+	if err != nil {
+		return nil
+	}
+	var pos Position
+	if ok, line := m.Get(KEYWORDS.line); ok {
+		if line, ok := line.(Int); ok {
+			pos.startLine = line.I
+		}
+	}
+	if ok, column := m.Get(KEYWORDS.column); ok {
+		if column, ok := column.(Int); ok {
+			pos.startColumn = column.I
+		}
+	}
+	return lspLocation{URI: pathURI(path.S), Range: s.encodeRange(strings.Split(string(text), "\n"), positionRange(pos))}
+}
+
+func positionRange(pos Position) lspRange {
+	start := lspPosition{pos.startLine - 1, pos.startColumn - 1}
+	if start.Line < 0 {
+		start.Line = 0
+	}
+	if start.Character < 0 {
+		start.Character = 0
+	}
+	end := start
+	if pos.endLine > 0 {
+		end = lspPosition{pos.endLine - 1, pos.endColumn}
+	}
+	return lspRange{Start: start, End: end}
+}
+
+func (s *lspServer) completion(params *lspDocumentParams) interface{} {
+	items := []lspCompletionItem{}
+	doc := s.documents[params.TextDocument.URI]
+	if doc == nil {
+		return items
+	}
+	lines := strings.Split(doc.text, "\n")
+	prefix, r := doc.symbolAt(s.decode(lines, params.Position), true)
+	r = s.encodeRange(lines, r)
+	seen := map[string]bool{}
+	add := func(label string, kind int, arglists []string, docstring string) {
+		if seen[label] {
+			return
+		}
+		seen[label] = true
+		if kind == lspVariableItem && len(arglists) > 0 {
+			kind = lspFunctionItem
+		}
+		item := lspCompletionItem{
+			Label:    label,
+			Kind:     kind,
+			Detail:   strings.Join(arglists, " "),
+			TextEdit: lspTextEdit{Range: r, NewText: label},
+		}
+		if docstring != "" {
+			item.Documentation = &lspMarkupContent{Kind: "markdown", Value: docstring}
+		}
+		items = append(items, item)
+	}
+	addDefs := func(qualifier string, d *lspDocument) {
+		for _, def := range d.defs {
+			if label := qualifier + def.name; strings.HasPrefix(label, prefix) && (d == doc || !def.private) {
+				add(label, lspVariableItem, def.arglists, def.doc)
+			}
+		}
+	}
+	addVars := func(qualifier string, ns *Namespace) {
+		for name, vr := range ns.Mappings() {
+			if label := qualifier + *name; strings.HasPrefix(label, prefix) && vr.ns == ns && !vr.isPrivate {
+				add(label, lspVariableItem, varArglists(vr), varDoc(vr))
+			}
+		}
+	}
+	if i := strings.IndexByte(prefix, '/'); i > 0 {
+		ns := prefix[:i]
+		if target, ok := doc.aliases[ns]; ok {
+			ns = target
+		}
+		for _, d := range s.documents {
+			if d.ns == ns {
+				addDefs(prefix[:i+1], d)
+			}
+		}
+		if n := findNamespace(ns); n != nil {
+			addVars(prefix[:i+1], n)
+		}
+	} else {
+		addDefs("", doc)
+		for name := range doc.refers {
+			if strings.HasPrefix(name, prefix) {
+				add(name, lspVariableItem, nil, "")
+			}
+		}
+		for alias := range doc.aliases {
+			if strings.HasPrefix(alias, prefix) {
+				add(alias, lspModuleItem, nil, "")
+			}
+		}
+		addVars("", GLOBAL_ENV.CoreNamespace)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Label < items[j].Label
+	})
+	return items
+}
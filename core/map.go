@@ -23,6 +23,11 @@ type (
 	}
 	EmptyMapIterator struct {
 	}
+	// MapEntryIterator walks a map's entries as [key value] vectors,
+	// the way the map's seq does.
+	MapEntryIterator struct {
+		iter MapIterator
+	}
 	Pair struct {
 		Key   Object
 		Value Object
@@ -41,6 +46,15 @@ func (iter *EmptyMapIterator) Next() *Pair {
 	panic(newIteratorError())
 }
 
+func (iter *MapEntryIterator) HasNext() bool {
+	return iter.iter.HasNext()
+}
+
+func (iter *MapEntryIterator) Next() Object {
+	p := iter.iter.Next()
+	return NewVectorFrom(p.Key, p.Value)
+}
+
 func mapConj(m Map, obj Object) Conjable {
 	switch obj := obj.(type) {
 	case *Vector:
@@ -110,15 +124,15 @@ func callMap(m Map, args []Object) Object {
 	return NIL
 }
 
-func pprintMap(m Map, w io.Writer, indent int) int {
+func pprintMap(pp *PrettyPrinter, m Map, w io.Writer, indent int) int {
 	i := indent + 1
 	fmt.Fprint(w, "{")
 	if m.Count() > 0 {
 		for iter := m.Iter(); ; {
 			p := iter.Next()
-			i = pprintObject(p.Key, indent+1, w)
+			i = pprintObject(pp, p.Key, indent+1, w)
 			fmt.Fprint(w, " ")
-			i = pprintObject(p.Value, i+1, w)
+			i = pprintObject(pp, p.Value, i+1, w)
 			if iter.HasNext() {
 				fmt.Fprint(w, "\n")
 				writeIndent(w, indent+1)
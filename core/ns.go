@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 )
 
 type (
@@ -75,6 +76,12 @@ func (ns *Namespace) MaybeLazy(doc string) {
 
 const nsHashMask uint32 = 0x90569f6f
 
+// nsLock guards GLOBAL_ENV.Namespaces and the mappings and aliases of
+// namespaces, so that they can be looked up (e.g. by a program embedding
+// Joker) while other goroutines intern vars or create namespaces.
+// It's never held while running Joker code or loading a namespace.
+var nsLock sync.RWMutex
+
 func NewNamespace(sym Symbol) *Namespace {
 	return &Namespace{
 		Name:     sym,
@@ -88,11 +95,15 @@ func (ns *Namespace) Refer(sym Symbol, vr *Var) *Var {
 	if sym.ns != nil {
 		panic(RT.NewError("Can't intern namespace-qualified symbol " + sym.ToString(false)))
 	}
+	nsLock.Lock()
 	ns.mappings[sym.name] = vr
+	nsLock.Unlock()
 	return vr
 }
 
 func (ns *Namespace) ReferAll(other *Namespace) {
+	nsLock.Lock()
+	defer nsLock.Unlock()
 	for name, vr := range other.mappings {
 		if !vr.isPrivate {
 			ns.mappings[name] = vr
@@ -114,10 +125,12 @@ func (ns *Namespace) Intern(sym Symbol) *Var {
 		if LINTER_TYPES[sym.name] {
 			msg := fmt.Sprintf("Expecting var, but %s is a type", *sym.name)
 			pos := sym.GetInfo().Pos()
-			printParseWarning(pos, msg)
+			printParseWarning(pos, "type-as-var", msg)
 		}
 	}
 	sym.meta = nil
+	nsLock.Lock()
+	defer nsLock.Unlock()
 	existingVar, ok := ns.mappings[sym.name]
 	if !ok {
 		newVar := &Var{
@@ -135,7 +148,7 @@ func (ns *Namespace) Intern(sym Symbol) *Var {
 			}
 			ns.mappings[sym.name] = newVar
 			if !strings.HasPrefix(ns.Name.Name(), "joker.") {
-				printParseWarning(GetPosition(sym), fmt.Sprintf("WARNING: %s already refers to: %s in namespace %s, being replaced by: %s\n",
+				printParseWarning(GetPosition(sym), "refer-replaced", fmt.Sprintf("WARNING: %s already refers to: %s in namespace %s, being replaced by: %s\n",
 					sym.ToString(false), existingVar.ToString(false), ns.Name.ToString(false), newVar.ToString(false)))
 			}
 			return newVar
@@ -146,9 +159,9 @@ func (ns *Namespace) Intern(sym Symbol) *Var {
 	if LINTER_MODE && existingVar.expr != nil && !existingVar.ns.Name.Equals(SYMBOLS.joker_core) {
 		if !isDeclaredInConfig(existingVar) {
 			if sym.GetInfo() == nil {
-				printParseWarning(existingVar.GetInfo().Pos(), "Subsequent duplicate def of "+existingVar.ToString(false))
+				printParseWarning(existingVar.GetInfo().Pos(), "duplicate-def", "Subsequent duplicate def of "+existingVar.ToString(false))
 			} else {
-				printParseWarning(sym.GetInfo().Pos(), "Duplicate def of "+existingVar.ToString(false))
+				printParseWarning(sym.GetInfo().Pos(), "duplicate-def", "Duplicate def of "+existingVar.ToString(false))
 			}
 		}
 	}
@@ -156,7 +169,7 @@ func (ns *Namespace) Intern(sym Symbol) *Var {
 }
 
 func isDeclaredInConfig(vr *Var) bool {
-	m := vr.GetMeta()
+	m := vr.meta
 	if m == nil {
 		return false
 	}
@@ -177,6 +190,7 @@ func (ns *Namespace) InternVar(name string, val Object, meta *ArrayMap) *Var {
 	meta.Add(KEYWORDS.ns, ns)
 	meta.Add(KEYWORDS.name, vr.name)
 	vr.meta = meta
+	vr.packedMeta = nil
 	return vr
 }
 
@@ -184,11 +198,13 @@ func (ns *Namespace) AddAlias(alias Symbol, namespace *Namespace) {
 	if alias.ns != nil {
 		panic(RT.NewError("Alias can't be namespace-qualified"))
 	}
+	nsLock.Lock()
+	defer nsLock.Unlock()
 	existing := ns.aliases[alias.name]
 	if existing != nil && existing != namespace {
 		msg := "Alias " + alias.ToString(false) + " already exists in namespace " + ns.Name.ToString(false) + ", aliasing " + existing.Name.ToString(false)
 		if LINTER_MODE {
-			printParseError(GetPosition(alias), msg)
+			printParseError(GetPosition(alias), "duplicate-alias", msg)
 			return
 		}
 		panic(RT.NewError(msg))
@@ -197,13 +213,53 @@ func (ns *Namespace) AddAlias(alias Symbol, namespace *Namespace) {
 }
 
 func (ns *Namespace) Resolve(name string) *Var {
-	return ns.mappings[STRINGS.Intern(name)]
+	vr, _ := ns.mapping(STRINGS.Intern(name))
+	return vr
+}
+
+func (ns *Namespace) mapping(name *string) (*Var, bool) {
+	nsLock.RLock()
+	defer nsLock.RUnlock()
+	vr, ok := ns.mappings[name]
+	return vr, ok
+}
+
+func (ns *Namespace) unmap(name *string) {
+	nsLock.Lock()
+	delete(ns.mappings, name)
+	nsLock.Unlock()
 }
 
+func (ns *Namespace) alias(name *string) *Namespace {
+	nsLock.RLock()
+	defer nsLock.RUnlock()
+	return ns.aliases[name]
+}
+
+func (ns *Namespace) unalias(name *string) {
+	nsLock.Lock()
+	delete(ns.aliases, name)
+	nsLock.Unlock()
+}
+
+// Mappings returns a copy of the mappings of ns.
 func (ns *Namespace) Mappings() map[*string]*Var {
-	return ns.mappings
+	nsLock.RLock()
+	defer nsLock.RUnlock()
+	res := make(map[*string]*Var, len(ns.mappings))
+	for k, v := range ns.mappings {
+		res[k] = v
+	}
+	return res
 }
 
+// Aliases returns a copy of the aliases of ns.
 func (ns *Namespace) Aliases() map[*string]*Namespace {
-	return ns.aliases
+	nsLock.RLock()
+	defer nsLock.RUnlock()
+	res := make(map[*string]*Namespace, len(ns.aliases))
+	for k, v := range ns.aliases {
+		res[k] = v
+	}
+	return res
 }
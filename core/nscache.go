@@ -0,0 +1,118 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// The namespace cache: when NS_CACHE_DIR is set (by --ns-cache), libs
+// loaded from the classpath are packed, as by PackReader, into a file
+// of that directory named after the hash of their source and the Joker
+// version. Later loads of the same source unpack and evaluate that file
+// instead of reading and parsing the source again.
+//
+// Macros are expanded when a lib is packed, so a lib using macros of
+// another lib that has changed since keeps the old expansions: clear
+// the cache directory after changing such macros.
+
+var NS_CACHE_DIR string
+
+// DefaultNSCacheDir returns ~/.joker-cache.
+func DefaultNSCacheDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".joker-cache"), nil
+}
+
+func nsCacheFilename(src []byte) string {
+	h := sha256.New()
+	io.WriteString(h, VERSION+"\n")
+	h.Write(src)
+	return filepath.Join(NS_CACHE_DIR, hex.EncodeToString(h.Sum(nil))+".pack")
+}
+
+// loadLib evaluates the lib whose source, read from filename, is src,
+// using the namespace cache if it's enabled.
+func loadLib(src []byte, filename string) {
+	reader := NewReader(bufio.NewReader(bytes.NewReader(src)), filename)
+	if NS_CACHE_DIR == "" {
+		ProcessReaderFromEval(reader, filename)
+		return
+	}
+	cacheFilename := nsCacheFilename(src)
+	if p, err := ioutil.ReadFile(cacheFilename); err == nil {
+		evalCachedLib(p, filename, cacheFilename)
+		return
+	}
+	p := packReaderFromEval(reader, filename)
+	// Caching is best effort: the lib is loaded either way.
+	if os.MkdirAll(NS_CACHE_DIR, 0777) == nil {
+		ioutil.WriteFile(cacheFilename, p, 0666)
+	}
+}
+
+// packReaderFromEval is ProcessReaderFromEval that also returns the
+// packed forms, as PackReader does.
+func packReaderFromEval(reader *Reader, filename string) []byte {
+	forgetSourceFile(reader.filename)
+	var p []byte
+	packEnv := NewPackEnv()
+	parseContext := &ParseContext{GlobalEnv: GLOBAL_ENV}
+	currentFilename := parseContext.GlobalEnv.file.Value
+	defer func() {
+		parseContext.GlobalEnv.SetFilename(currentFilename)
+	}()
+	s, err := filepath.Abs(filename)
+	PanicOnErr(err)
+	parseContext.GlobalEnv.SetFilename(MakeString(s))
+	for {
+		_, expr, err := ReadParse(reader, parseContext)
+		if err == io.EOF {
+			var hp []byte
+			hp = packEnv.Pack(hp)
+			return append(hp, p...)
+		}
+		PanicOnErr(err)
+		p = expr.Pack(p, packEnv)
+		_, err = TryEval(expr)
+		PanicOnErr(err)
+	}
+}
+
+func evalCachedLib(p []byte, filename string, cacheFilename string) {
+	currentFilename := GLOBAL_ENV.file.Resolve()
+	defer func() {
+		GLOBAL_ENV.SetFilename(currentFilename)
+	}()
+	s, err := filepath.Abs(filename)
+	PanicOnErr(err)
+	GLOBAL_ENV.SetFilename(MakeString(s))
+	header, p := UnpackHeader(p, GLOBAL_ENV)
+	for len(p) > 0 {
+		var expr Expr
+		expr, p = unpackCachedExpr(p, header, cacheFilename)
+		_, err = TryEval(expr)
+		PanicOnErr(err)
+	}
+}
+
+// unpackCachedExpr removes the cache file if it can't be unpacked
+// (e.g. because a macro embedded an unreadable object in a form), so
+// that the lib gets loaded from source next time.
+func unpackCachedExpr(p []byte, header *PackHeader, cacheFilename string) (Expr, []byte) {
+	defer func() {
+		if r := recover(); r != nil {
+			os.Remove(cacheFilename)
+			panic(r)
+		}
+	}()
+	return UnpackExpr(p, header)
+}
@@ -1,8 +1,11 @@
 package core
 
 import (
+	"fmt"
 	"math/big"
 	"math/bits"
+	"strconv"
+	"strings"
 )
 
 type (
@@ -37,6 +40,12 @@ type (
 	BigIntOps   struct{}
 	BigFloatOps struct{}
 	RatioOps    struct{}
+	// PromotingIntOps is used by the arbitrary precision variants of the
+	// arithmetic functions (+' etc.) instead of IntOps. Rather than
+	// failing on overflow, it promotes the result to BigInt.
+	PromotingIntOps struct {
+		IntOps
+	}
 )
 
 const (
@@ -54,6 +63,8 @@ var (
 	BIGINT_OPS   = BigIntOps{}
 	BIGFLOAT_OPS = BigFloatOps{}
 	RATIO_OPS    = RatioOps{}
+
+	PROMOTING_INT_OPS = PromotingIntOps{}
 )
 
 func ratioOrInt(r *big.Rat) Number {
@@ -252,10 +263,56 @@ func (r *Ratio) Ratio() *big.Rat {
 
 // Ops
 
+// Promoting returns the ops to use instead of ops for arithmetic that
+// shouldn't overflow.
+func Promoting(ops Ops) Ops {
+	if _, ok := ops.(IntOps); ok {
+		return PROMOTING_INT_OPS
+	}
+	return ops
+}
+
+// addInts, subtractInts and multiplyInts return false as the second
+// value if the result overflows.
+
+func addInts(x, y int) (int, bool) {
+	r := x + y
+	return r, (x^r)&(y^r) >= 0
+}
+
+func subtractInts(x, y int) (int, bool) {
+	r := x - y
+	return r, (x^y)&(x^r) >= 0
+}
+
+func multiplyInts(x, y int) (int, bool) {
+	r := x * y
+	if x == 0 {
+		return r, true
+	}
+	// The second check catches (* -1 min-int), where r / x == y.
+	return r, r/x == y && !(x == -1 && y != 0 && y == -y)
+}
+
+func panicOnOverflow() {
+	panic(RT.NewError("integer overflow"))
+}
+
 // Add
 
 func (ops IntOps) Add(x, y Number) Number {
-	return Int{I: x.Int().I + y.Int().I}
+	r, ok := addInts(x.Int().I, y.Int().I)
+	if !ok {
+		panicOnOverflow()
+	}
+	return Int{I: r}
+}
+
+func (ops PromotingIntOps) Add(x, y Number) Number {
+	if r, ok := addInts(x.Int().I, y.Int().I); ok {
+		return Int{I: r}
+	}
+	return BIGINT_OPS.Add(x, y)
 }
 
 func (ops DoubleOps) Add(x, y Number) Number {
@@ -285,7 +342,18 @@ func (ops RatioOps) Add(x, y Number) Number {
 // Subtract
 
 func (ops IntOps) Subtract(x, y Number) Number {
-	return Int{I: x.Int().I - y.Int().I}
+	r, ok := subtractInts(x.Int().I, y.Int().I)
+	if !ok {
+		panicOnOverflow()
+	}
+	return Int{I: r}
+}
+
+func (ops PromotingIntOps) Subtract(x, y Number) Number {
+	if r, ok := subtractInts(x.Int().I, y.Int().I); ok {
+		return Int{I: r}
+	}
+	return BIGINT_OPS.Subtract(x, y)
 }
 
 func (ops DoubleOps) Subtract(x, y Number) Number {
@@ -315,7 +383,18 @@ func (ops RatioOps) Subtract(x, y Number) Number {
 // Multiply
 
 func (ops IntOps) Multiply(x, y Number) Number {
-	return Int{I: x.Int().I * y.Int().I}
+	r, ok := multiplyInts(x.Int().I, y.Int().I)
+	if !ok {
+		panicOnOverflow()
+	}
+	return Int{I: r}
+}
+
+func (ops PromotingIntOps) Multiply(x, y Number) Number {
+	if r, ok := multiplyInts(x.Int().I, y.Int().I); ok {
+		return Int{I: r}
+	}
+	return BIGINT_OPS.Multiply(x, y)
 }
 
 func (ops DoubleOps) Multiply(x, y Number) Number {
@@ -380,9 +459,7 @@ func (ops BigFloatOps) Divide(x, y Number) Number {
 }
 
 func (ops RatioOps) Divide(x, y Number) Number {
-	if y.Ratio().Num().Int64() == 0 {
-		panic(RT.NewError("Division by zero"))
-	}
+	panicOnZero(ops, y)
 	r := big.Rat{}
 	r.Quo(x.Ratio(), y.Ratio())
 	return ratioOrInt(&r)
@@ -415,11 +492,17 @@ func (ops BigFloatOps) Quotient(x, y Number) Number {
 	return &BigFloat{b: z.SetInt64(i)}
 }
 
+// truncate returns the integer part of r.
+func truncate(r *big.Rat) *big.Int {
+	z := &big.Int{}
+	return z.Quo(r.Num(), r.Denom())
+}
+
 func (ops RatioOps) Quotient(x, y Number) Number {
 	panicOnZero(ops, y)
 	z := &big.Rat{}
-	f, _ := z.Quo(x.Ratio(), y.Ratio()).Float64()
-	return &BigInt{b: big.NewInt(int64(f))}
+	z.Quo(x.Ratio(), y.Ratio())
+	return &BigInt{b: truncate(z)}
 }
 
 // Remainder
@@ -459,11 +542,11 @@ func (ops RatioOps) Rem(x, y Number) Number {
 	panicOnZero(ops, y)
 	n := x.Ratio()
 	d := y.Ratio()
-	z := big.Rat{}
-	f, _ := z.Quo(n, d).Float64()
-	d.Mul(d, big.NewRat(int64(f), 1))
-	z.Sub(n, d)
-	return ratioOrInt(&z)
+	q := &big.Rat{}
+	q.SetInt(truncate(q.Quo(n, d)))
+	z := &big.Rat{}
+	z.Sub(n, z.Mul(d, q))
+	return ratioOrInt(z)
 }
 
 // IsZero
@@ -629,6 +712,147 @@ func Min(x Number, y Number) Number {
 	return y
 }
 
+// Rounding
+
+// DecimalRatio returns the exact value of x as a Ratio, except that
+// a Double is converted via its shortest decimal representation, so that
+// 2.675 becomes 2675/1000 rather than the nearest binary fraction.
+func DecimalRatio(x Number) *big.Rat {
+	switch x := x.(type) {
+	case Double:
+		if r, ok := new(big.Rat).SetString(strconv.FormatFloat(x.D, 'g', -1, 64)); ok {
+			return r
+		}
+		panic(RT.NewError("Cannot convert " + x.ToString(false) + " to a decimal"))
+	case *BigFloat:
+		if x.b.IsInf() {
+			panic(RT.NewError("Cannot convert " + x.ToString(false) + " to a decimal"))
+		}
+		r, _ := x.b.Rat(nil)
+		return r
+	default:
+		return x.Ratio()
+	}
+}
+
+// RoundRat rounds r to the given number of decimal places (negative
+// places round to tens, hundreds etc.). mode is the name of a rounding
+// mode keyword, e.g. ":half-even".
+func RoundRat(r *big.Rat, places int, mode string) *big.Rat {
+	scale := big.NewRat(1, 1)
+	p := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(places))), nil)
+	if places >= 0 {
+		scale.SetInt(p)
+	} else {
+		scale.SetFrac(big.NewInt(1), p)
+	}
+	r = new(big.Rat).Mul(r, scale)
+
+	q, m := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if m.Sign() != 0 {
+		// q is truncated towards zero; cmp compares the discarded fraction with 1/2.
+		cmp := new(big.Int).Abs(new(big.Int).Mul(m, big.NewInt(2))).Cmp(r.Denom())
+		away := false
+		switch mode {
+		case ":half-up":
+			away = cmp >= 0
+		case ":half-down":
+			away = cmp > 0
+		case ":half-even":
+			away = cmp > 0 || (cmp == 0 && q.Bit(0) == 1)
+		case ":up":
+			away = true
+		case ":down":
+		case ":ceiling":
+			away = r.Sign() > 0
+		case ":floor":
+			away = r.Sign() < 0
+		default:
+			panic(RT.NewError("Unsupported rounding mode " + mode +
+				". Supported modes are: :half-up, :half-down, :half-even, :up, :down, :ceiling, :floor"))
+		}
+		if away {
+			q.Add(q, big.NewInt(int64(r.Sign())))
+		}
+	}
+	return new(big.Rat).Quo(new(big.Rat).SetInt(q), scale)
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+// Strict parsing
+
+// stripDigitSeparators removes underscores separating digits, as in
+// 1_000_000. Returns false if an underscore doesn't separate two digits.
+func stripDigitSeparators(s string) (string, bool) {
+	if !strings.Contains(s, "_") {
+		return s, true
+	}
+	isDigit := func(c byte) bool {
+		return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '_' {
+			if i == 0 || i == len(s)-1 || !isDigit(s[i-1]) || !isDigit(s[i+1]) {
+				return "", false
+			}
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String(), true
+}
+
+func checkRadix(radix int) {
+	if radix < 2 || radix > 36 {
+		panic(RT.NewError(fmt.Sprintf("Radix must be between 2 and 36, got %d", radix)))
+	}
+}
+
+// ParseBigIntStrict parses s as an optionally signed integer in the given
+// radix, allowing underscores between digits. Returns nil if s is not
+// such an integer.
+func ParseBigIntStrict(s string, radix int) *big.Int {
+	checkRadix(radix)
+	digits, ok := stripDigitSeparators(s)
+	if !ok {
+		return nil
+	}
+	if b, ok := new(big.Int).SetString(digits, radix); ok {
+		return b
+	}
+	return nil
+}
+
+// ParseIntStrict is like ParseBigIntStrict, but also fails if
+// the result doesn't fit in an int.
+func ParseIntStrict(s string, radix int) (int, bool) {
+	checkRadix(radix)
+	digits, ok := stripDigitSeparators(s)
+	if !ok {
+		return 0, false
+	}
+	i, err := strconv.ParseInt(digits, radix, 0)
+	return int(i), err == nil
+}
+
+// ParseDoubleStrict parses s as a floating-point number, allowing
+// underscores between digits. Fails if s is not such a number or is out of range.
+func ParseDoubleStrict(s string) (float64, bool) {
+	digits, ok := stripDigitSeparators(s)
+	if !ok {
+		return 0, false
+	}
+	d, err := strconv.ParseFloat(digits, 64)
+	return d, err == nil
+}
+
 // Precision
 
 func (n *BigInt) Precision() *big.Int {
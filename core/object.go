@@ -1,5 +1,5 @@
-//go:generate go run gen/gen_types.go assert Comparable *Vector Char String Symbol Keyword *Regex Boolean Time Number Seqable Callable *Type Meta Int Double Stack Map Set Associative Reversible Named Comparator *Ratio *BigFloat *BigInt *Namespace *Var Error *Fn Deref *Atom Ref KVReduce Pending *File io.Reader io.Writer StringReader io.RuneReader *Channel
-//go:generate go run gen/gen_types.go info *List *ArrayMapSeq *ArrayMap *HashMap *ExInfo *Fn *Var Nil *Ratio *BigInt *BigFloat Char Double Int Boolean Time Keyword *Regex Symbol String Comment *LazySeq *MappingSeq *ArraySeq *ConsSeq *NodeSeq *ArrayNodeSeq *MapSet *Vector *VectorSeq *VectorRSeq
+//go:generate go run gen/gen_types.go assert Comparable *Vector Char String Symbol Keyword *Regex Boolean Time Number Seqable Callable *Type Meta Int Double Stack Map Set Associative Reversible Named Comparator *Ratio *BigFloat *BigInt *Namespace *Var Error *Fn Deref *Atom Ref KVReduce Pending *File io.Reader io.Writer StringReader io.RuneReader *Channel *Future *Promise Transient *TransientVector *TransientMap *TransientSet
+//go:generate go run gen/gen_types.go info *List *ArrayMapSeq *ArrayMap *HashMap *ExInfo *Fn *Var Nil *Ratio *BigInt *BigFloat Char Double Int Boolean Time Keyword *Regex Symbol String Comment *LazySeq *MappingSeq *ArraySeq *ConsSeq *NodeSeq *ArrayNodeSeq *MapSet *Vector *VectorSeq *VectorRSeq *Range *SortedMap
 //go:generate go run -tags gen_code gen_code/gen_code.go
 
 package core
@@ -156,7 +156,10 @@ type (
 		isUsed         bool
 		isGloballyUsed bool
 		isFake         bool
+		hasBindings    bool
 		taggedType     *Type
+		lintMeta       Map // meta of the def's symbol while linting
+		packedMeta     *VarMeta
 	}
 	ProcFn func([]Object) Object
 	Proc   struct {
@@ -180,6 +183,9 @@ type (
 		fn    Callable
 		value Object
 	}
+	Reduced struct {
+		value Object
+	}
 	Sequential interface {
 		sequential()
 	}
@@ -221,7 +227,7 @@ type (
 		Print(writer io.Writer, printReadably bool)
 	}
 	Pprinter interface {
-		Pprint(writer io.Writer, indent int) int
+		Pprint(pp *PrettyPrinter, writer io.Writer, indent int) int
 	}
 	Formatter interface {
 		Format(writer io.Writer, indent int) int
@@ -234,7 +240,9 @@ type (
 	}
 	Atom struct {
 		MetaHolder
-		value Object
+		value     Object
+		validator Callable
+		watches   Map
 	}
 	Deref interface {
 		Deref() Object
@@ -249,74 +257,83 @@ type (
 		IsRealized() bool
 	}
 	Types struct {
-		Associative    *Type
-		Callable       *Type
-		Collection     *Type
-		Comparable     *Type
-		Comparator     *Type
-		Counted        *Type
-		Deref          *Type
-		Channel        *Type
-		Error          *Type
-		Gettable       *Type
-		Indexed        *Type
-		IOReader       *Type
-		IOWriter       *Type
-		KVReduce       *Type
-		Map            *Type
-		Meta           *Type
-		Named          *Type
-		Number         *Type
-		Pending        *Type
-		Ref            *Type
-		Reversible     *Type
-		Seq            *Type
-		Seqable        *Type
-		Sequential     *Type
-		Set            *Type
-		Stack          *Type
-		ArrayMap       *Type
-		ArrayMapSeq    *Type
-		ArrayNodeSeq   *Type
-		ArraySeq       *Type
-		MapSet         *Type
-		Atom           *Type
-		BigFloat       *Type
-		BigInt         *Type
-		Boolean        *Type
-		Time           *Type
-		Buffer         *Type
-		Char           *Type
-		ConsSeq        *Type
-		Delay          *Type
-		Double         *Type
-		EvalError      *Type
-		ExInfo         *Type
-		Fn             *Type
-		File           *Type
-		BufferedReader *Type
-		HashMap        *Type
-		Int            *Type
-		Keyword        *Type
-		LazySeq        *Type
-		List           *Type
-		MappingSeq     *Type
-		Namespace      *Type
-		Nil            *Type
-		NodeSeq        *Type
-		ParseError     *Type
-		Proc           *Type
-		ProcFn         *Type
-		Ratio          *Type
-		RecurBindings  *Type
-		Regex          *Type
-		String         *Type
-		Symbol         *Type
-		Type           *Type
-		Var            *Type
-		Vector         *Type
-		VectorRSeq     *Type
-		VectorSeq      *Type
+		Associative     *Type
+		Callable        *Type
+		Collection      *Type
+		Comparable      *Type
+		Comparator      *Type
+		Counted         *Type
+		Deref           *Type
+		Channel         *Type
+		Error           *Type
+		Gettable        *Type
+		Indexed         *Type
+		IOReader        *Type
+		IOWriter        *Type
+		KVReduce        *Type
+		Map             *Type
+		Meta            *Type
+		Named           *Type
+		Number          *Type
+		Pending         *Type
+		Ref             *Type
+		Reversible      *Type
+		Seq             *Type
+		Seqable         *Type
+		Sequential      *Type
+		Set             *Type
+		Stack           *Type
+		Transient       *Type
+		ArrayMap        *Type
+		ArrayMapSeq     *Type
+		ArrayNodeSeq    *Type
+		ArraySeq        *Type
+		MapSet          *Type
+		Atom            *Type
+		BigFloat        *Type
+		BigInt          *Type
+		Boolean         *Type
+		Time            *Type
+		Buffer          *Type
+		Char            *Type
+		ConsSeq         *Type
+		Delay           *Type
+		Double          *Type
+		EvalError       *Type
+		ExInfo          *Type
+		Fn              *Type
+		Future          *Type
+		File            *Type
+		BufferedReader  *Type
+		HashMap         *Type
+		Int             *Type
+		Keyword         *Type
+		LazySeq         *Type
+		List            *Type
+		MappingSeq      *Type
+		Namespace       *Type
+		Nil             *Type
+		NodeSeq         *Type
+		ParseError      *Type
+		Proc            *Type
+		ProcFn          *Type
+		Promise         *Type
+		Range           *Type
+		Ratio           *Type
+		RecurBindings   *Type
+		Reduced         *Type
+		Regex           *Type
+		SortedMap       *Type
+		String          *Type
+		Symbol          *Type
+		TransientMap    *Type
+		TransientSet    *Type
+		TransientVector *Type
+		Type            *Type
+		Var             *Type
+		Vector          *Type
+		VectorRSeq      *Type
+		VectorSeq       *Type
 	}
 )
 
@@ -537,6 +554,27 @@ func (a *Atom) Deref() Object {
 	return a.value
 }
 
+func (a *Atom) validate(value Object) {
+	if a.validator != nil && !ToBool(a.validator.Call([]Object{value})) {
+		panic(RT.NewError("Invalid reference state"))
+	}
+}
+
+// set validates value, makes it the value of the atom and calls the
+// watches, returning the old value.
+func (a *Atom) set(value Object) Object {
+	a.validate(value)
+	oldValue := a.value
+	a.value = value
+	if a.watches != nil {
+		for iter := a.watches.Iter(); iter.HasNext(); {
+			p := iter.Next()
+			p.Value.(Callable).Call([]Object{p.Key, a, oldValue, value})
+		}
+	}
+	return oldValue
+}
+
 func (d *Delay) ToString(escape bool) string {
 	return "#object[Delay]"
 }
@@ -576,6 +614,34 @@ func (d *Delay) IsRealized() bool {
 	return d.value != nil
 }
 
+func (r *Reduced) ToString(escape bool) string {
+	return "#object[Reduced " + r.value.ToString(escape) + "]"
+}
+
+func (r *Reduced) Equals(other interface{}) bool {
+	return r == other
+}
+
+func (r *Reduced) GetInfo() *ObjectInfo {
+	return nil
+}
+
+func (r *Reduced) GetType() *Type {
+	return TYPE.Reduced
+}
+
+func (r *Reduced) Hash() uint32 {
+	return HashPtr(uintptr(unsafe.Pointer(r)))
+}
+
+func (r *Reduced) WithInfo(info *ObjectInfo) Object {
+	return r
+}
+
+func (r *Reduced) Deref() Object {
+	return r.value
+}
+
 func (t *Type) ToString(escape bool) string {
 	return t.name
 }
@@ -596,6 +662,10 @@ func (t *Type) Hash() uint32 {
 	return HashPtr(uintptr(unsafe.Pointer(t)))
 }
 
+func (t *Type) ReflectType() reflect.Type {
+	return t.reflectType
+}
+
 func (rb RecurBindings) ToString(escape bool) string {
 	return "#object[RecurBindings]"
 }
@@ -639,8 +709,10 @@ func (exInfo *ExInfo) Message() Object {
 	return NIL
 }
 
-func (exInfo *ExInfo) Error() string {
-	var pos Position
+// problem returns the position of the form exInfo was thrown for (if
+// any), its :_prefix (such as "Parse warning"), the linter rule that
+// reported it (:_rule, if any) and its message.
+func (exInfo *ExInfo) problem() (pos Position, prefix string, rule string, msg string) {
 	_, data := exInfo.Get(KEYWORDS.data)
 	ok, form := data.(Map).Get(KEYWORDS.form)
 	if ok {
@@ -648,16 +720,29 @@ func (exInfo *ExInfo) Error() string {
 			pos = form.GetInfo().Pos()
 		}
 	}
-	prefix := "Exception"
+	prefix = "Exception"
 	if ok, pr := data.(Map).Get(KEYWORDS._prefix); ok {
 		prefix = pr.ToString(false)
 	}
-	_, msg := exInfo.Get(KEYWORDS.message)
+	if ok, r := data.(Map).Get(KEYWORDS._rule); ok {
+		rule = r.ToString(false)
+	}
+	_, m := exInfo.Get(KEYWORDS.message)
+	return pos, prefix, rule, m.(String).S
+}
+
+func (exInfo *ExInfo) Error() string {
+	pos, prefix, _, msg := exInfo.problem()
+	var res string
 	if len(exInfo.rt.callstack.frames) > 0 && !LINTER_MODE {
-		return fmt.Sprintf("%s:%d:%d: %s: %s\nStacktrace:\n%s", pos.Filename(), pos.startLine, pos.startColumn, prefix, msg.(String).S, exInfo.rt.stacktrace())
+		res = fmt.Sprintf("%s:%d:%d: %s: %s\nStacktrace:\n%s", pos.Filename(), pos.startLine, pos.startColumn, prefix, msg, exInfo.rt.stacktrace())
 	} else {
-		return fmt.Sprintf("%s:%d:%d: %s: %s", pos.Filename(), pos.startLine, pos.startColumn, prefix, msg.(String).S)
+		res = fmt.Sprintf("%s:%d:%d: %s: %s", pos.Filename(), pos.startLine, pos.startColumn, prefix, msg)
 	}
+	if ok, cause := exInfo.Get(KEYWORDS.cause); ok {
+		res += "\nCaused by: " + cause.(Error).Error()
+	}
+	return res
 }
 
 func (fn *Fn) ToString(escape bool) string {
@@ -690,12 +775,14 @@ func (fn *Fn) Hash() uint32 {
 func (fn *Fn) Call(args []Object) Object {
 	min := math.MaxInt32
 	max := -1
-	for _, arity := range fn.fnExpr.arities {
+	for i := range fn.fnExpr.arities {
+		arity := &fn.fnExpr.arities[i]
 		a := len(arity.args)
 		if a == len(args) {
 			RT.pushFrame()
-			defer RT.popFrame()
-			return evalLoop(arity.body, fn.env.addFrame(args))
+			res := arity.run(fn.env.addFrame(args))
+			RT.popFrame()
+			return res
 		}
 		if min > a {
 			min = a
@@ -730,8 +817,9 @@ func (fn *Fn) Call(args []Object) Object {
 	}
 	vargs[len(vargs)-1] = restArgs
 	RT.pushFrame()
-	defer RT.popFrame()
-	return evalLoop(v.body, fn.env.addFrame(vargs))
+	res := v.run(fn.env.addFrame(vargs))
+	RT.popFrame()
+	return res
 }
 
 func compare(c Callable, a, b Object) int {
@@ -831,17 +919,20 @@ func (v *Var) Equals(other interface{}) bool {
 }
 
 func (v *Var) WithMeta(meta Map) Object {
+	v.unpackMeta()
 	res := *v
 	res.meta = SafeMerge(res.meta, meta)
 	return &res
 }
 
 func (v *Var) ResetMeta(newMeta Map) Map {
+	v.packedMeta = nil
 	v.meta = newMeta
 	return v.meta
 }
 
 func (v *Var) AlterMeta(fn *Fn, args []Object) Map {
+	v.unpackMeta()
 	return AlterMeta(&v.MetaHolder, fn, args)
 }
 
@@ -854,6 +945,11 @@ func (v *Var) Hash() uint32 {
 }
 
 func (v *Var) Resolve() Object {
+	if v.hasBindings {
+		if frame := RT.bindings.lookup(v); frame != nil {
+			return frame.bindings[v]
+		}
+	}
 	if v.Value == nil {
 		return NIL
 	}
@@ -1389,6 +1485,17 @@ func (k Keyword) Compare(other Object) int {
 }
 
 func (k Keyword) Call(args []Object) Object {
+	CheckArity(args, 1, 2)
+	// Look k up in array maps directly, without converting it to Object.
+	if m, ok := args[0].(*ArrayMap); ok {
+		if i := m.indexOfKeyword(k); i != -1 {
+			return m.arr[i+1]
+		}
+		if len(args) == 2 {
+			return args[1]
+		}
+		return NIL
+	}
 	return getMap(k, args)
 }
 
@@ -38,6 +38,7 @@ func init() {
 		Sequential:     RegInterface("Sequential", (*Sequential)(nil), ""),
 		Set:            RegInterface("Set", (*Set)(nil), ""),
 		Stack:          RegInterface("Stack", (*Stack)(nil), ""),
+		Transient:      RegInterface("Transient", (*Transient)(nil), ""),
 		ArrayMap:       RegRefType("ArrayMap", (*ArrayMap)(nil), ""),
 		ArrayMapSeq:    RegRefType("ArrayMapSeq", (*ArrayMapSeq)(nil), ""),
 		ArrayNodeSeq:   RegRefType("ArrayNodeSeq", (*ArrayNodeSeq)(nil), ""),
@@ -57,29 +58,37 @@ func init() {
 		EvalError:      RegRefType("EvalError", (*EvalError)(nil), ""),
 		ExInfo:         RegRefType("ExInfo", (*ExInfo)(nil), ""),
 		Fn:             RegRefType("Fn", (*Fn)(nil), "A callable function or macro implemented via Joker code"),
+		Future:         RegRefType("Future", (*Future)(nil), "A value computed by a goroutine"),
 		File:           RegRefType("File", (*File)(nil), ""),
 		BufferedReader: RegRefType("BufferedReader", (*BufferedReader)(nil), ""),
 		HashMap:        RegRefType("HashMap", (*HashMap)(nil), ""),
 		Int: RegType("Int", (*Int)(nil),
 			"Wraps the Go 'int' type, which is 32 bits wide on 32-bit hosts, 64 bits wide on 64-bit hosts, etc."),
-		Keyword:       RegType("Keyword", (*Keyword)(nil), "A possibly-namespace-qualified name prefixed by ':'"),
-		LazySeq:       RegRefType("LazySeq", (*LazySeq)(nil), ""),
-		List:          RegRefType("List", (*List)(nil), ""),
-		MappingSeq:    RegRefType("MappingSeq", (*MappingSeq)(nil), ""),
-		Namespace:     RegRefType("Namespace", (*Namespace)(nil), ""),
-		Nil:           RegType("Nil", (*Nil)(nil), "The 'nil' value"),
-		NodeSeq:       RegRefType("NodeSeq", (*NodeSeq)(nil), ""),
-		ParseError:    RegRefType("ParseError", (*ParseError)(nil), ""),
-		Proc:          RegRefType("Proc", (*Proc)(nil), "A callable function implemented via Go code"),
-		Ratio:         RegRefType("Ratio", (*Ratio)(nil), "Wraps the Go 'math.big/Rat' type"),
-		RecurBindings: RegRefType("RecurBindings", (*RecurBindings)(nil), ""),
-		Regex:         RegRefType("Regex", (*Regex)(nil), "Wraps the Go 'regexp.Regexp' type"),
-		String:        RegType("String", (*String)(nil), "Wraps the Go 'string' type"),
-		Symbol:        RegType("Symbol", (*Symbol)(nil), ""),
-		Type:          RegRefType("Type", (*Type)(nil), ""),
-		Var:           RegRefType("Var", (*Var)(nil), ""),
-		Vector:        RegRefType("Vector", (*Vector)(nil), ""),
-		VectorRSeq:    RegRefType("VectorRSeq", (*VectorRSeq)(nil), ""),
-		VectorSeq:     RegRefType("VectorSeq", (*VectorSeq)(nil), ""),
+		Keyword:         RegType("Keyword", (*Keyword)(nil), "A possibly-namespace-qualified name prefixed by ':'"),
+		LazySeq:         RegRefType("LazySeq", (*LazySeq)(nil), ""),
+		List:            RegRefType("List", (*List)(nil), ""),
+		MappingSeq:      RegRefType("MappingSeq", (*MappingSeq)(nil), ""),
+		Namespace:       RegRefType("Namespace", (*Namespace)(nil), ""),
+		Nil:             RegType("Nil", (*Nil)(nil), "The 'nil' value"),
+		NodeSeq:         RegRefType("NodeSeq", (*NodeSeq)(nil), ""),
+		ParseError:      RegRefType("ParseError", (*ParseError)(nil), ""),
+		Proc:            RegRefType("Proc", (*Proc)(nil), "A callable function implemented via Go code"),
+		Promise:         RegRefType("Promise", (*Promise)(nil), "A value delivered once, possibly by another goroutine"),
+		Range:           RegRefType("Range", (*Range)(nil), ""),
+		Ratio:           RegRefType("Ratio", (*Ratio)(nil), "Wraps the Go 'math.big/Rat' type"),
+		RecurBindings:   RegRefType("RecurBindings", (*RecurBindings)(nil), ""),
+		Reduced:         RegRefType("Reduced", (*Reduced)(nil), "Wraps the result of a reduction that should stop early"),
+		Regex:           RegRefType("Regex", (*Regex)(nil), "Wraps the Go 'regexp.Regexp' type"),
+		SortedMap:       RegRefType("SortedMap", (*SortedMap)(nil), "A map ordered by a comparator"),
+		String:          RegType("String", (*String)(nil), "Wraps the Go 'string' type"),
+		Symbol:          RegType("Symbol", (*Symbol)(nil), ""),
+		TransientMap:    RegRefType("TransientMap", (*TransientMap)(nil), ""),
+		TransientSet:    RegRefType("TransientSet", (*TransientSet)(nil), ""),
+		TransientVector: RegRefType("TransientVector", (*TransientVector)(nil), ""),
+		Type:            RegRefType("Type", (*Type)(nil), ""),
+		Var:             RegRefType("Var", (*Var)(nil), ""),
+		Vector:          RegRefType("Vector", (*Vector)(nil), ""),
+		VectorRSeq:      RegRefType("VectorRSeq", (*VectorRSeq)(nil), ""),
+		VectorSeq:       RegRefType("VectorSeq", (*VectorSeq)(nil), ""),
 	}
 }
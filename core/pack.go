@@ -303,7 +303,7 @@ func packObject(obj Object, p []byte, env *PackEnv) []byte {
 	default:
 		p = append(p, NULL)
 		var buf bytes.Buffer
-		PrintObject(obj, &buf)
+		printObject(obj, &buf, ToBool(GLOBAL_ENV.printReadably.Resolve()))
 		bb := buf.Bytes()
 		p = appendInt(p, len(bb))
 		p = append(p, bb...)
@@ -578,7 +578,7 @@ func (vr *Var) Pack(p []byte, env *PackEnv) []byte {
 func unpackVar(p []byte, header *PackHeader) (*Var, []byte) {
 	nsName, p := unpackSymbol(p, header)
 	name, p := unpackSymbol(p, header)
-	vr := GLOBAL_ENV.FindNamespace(nsName).mappings[name.name]
+	vr, _ := GLOBAL_ENV.FindNamespace(nsName).mapping(name.name)
 	if vr == nil {
 		panic(RT.NewError("Error unpacking var: cannot find var " + *nsName.name + "/" + *name.name))
 	}
@@ -819,7 +819,16 @@ func unpackThrowExpr(p []byte, header *PackHeader) (*ThrowExpr, []byte) {
 func (expr *CatchExpr) Pack(p []byte, env *PackEnv) []byte {
 	p = append(p, CATCH_EXPR)
 	p = expr.Pos().Pack(p, env)
-	p = appendUint16(p, env.stringIndex(STRINGS.Intern(expr.excType.name)))
+	p = appendInt(p, len(expr.excTypes))
+	for i, excType := range expr.excTypes {
+		p = appendUint16(p, env.stringIndex(STRINGS.Intern(excType.name)))
+		if expr.excTags[i] == nil {
+			p = append(p, NULL)
+		} else {
+			p = append(p, NOT_NULL)
+			p = packObject(expr.excTags[i], p, env)
+		}
+	}
 	p = expr.excSymbol.Pack(p, env)
 	p = packSeq(p, expr.body, env)
 	return p
@@ -828,15 +837,27 @@ func (expr *CatchExpr) Pack(p []byte, env *PackEnv) []byte {
 func unpackCatchExpr(p []byte, header *PackHeader) (*CatchExpr, []byte) {
 	p = p[1:]
 	pos, p := unpackPosition(p, header)
-	i, p := extractUInt16(p)
-	typeName := header.Strings[i]
+	c, p := extractInt(p)
+	excTypes := make([]*Type, c)
+	excTags := make([]Object, c)
+	for i := 0; i < c; i++ {
+		var j uint16
+		j, p = extractUInt16(p)
+		excTypes[i] = TYPES[header.Strings[j]]
+		if p[0] == NULL {
+			p = p[1:]
+		} else {
+			excTags[i], p = unpackObject(p[1:], header)
+		}
+	}
 	excSymbol, p := unpackSymbol(p, header)
 	body, p := unpackSeq(p, header)
 	res := &CatchExpr{
 		Position:  pos,
 		excSymbol: excSymbol,
 		body:      body,
-		excType:   TYPES[typeName],
+		excTypes:  excTypes,
+		excTags:   excTags,
 	}
 	return res, p
 }
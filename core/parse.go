@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"unicode/utf8"
 	"unsafe"
 )
 
@@ -77,8 +78,15 @@ type (
 		meta *MapExpr
 		expr Expr
 	}
+	// typeMemo caches the type inferred from the last expr of a body,
+	// which the linter may ask for many times.
+	typeMemo struct {
+		t        *Type
+		inferred bool
+	}
 	DoExpr struct {
 		Position
+		typeMemo
 		body             []Expr
 		isCreatedByMacro bool
 	}
@@ -87,6 +95,7 @@ type (
 		args       []Symbol
 		body       []Expr
 		taggedType *Type
+		compiled   compiledExpr
 	}
 	FnExpr struct {
 		Position
@@ -96,6 +105,7 @@ type (
 	}
 	LetExpr struct {
 		Position
+		typeMemo
 		names  []Symbol
 		values []Expr
 		body   []Expr
@@ -107,12 +117,15 @@ type (
 	}
 	CatchExpr struct {
 		Position
-		excType   *Type
+		typeMemo
+		excTypes  []*Type
+		excTags   []Object
 		excSymbol Symbol
 		body      []Expr
 	}
 	TryExpr struct {
 		Position
+		typeMemo
 		body        []Expr
 		catches     []*CatchExpr
 		finallyExpr []Expr
@@ -122,8 +135,9 @@ type (
 		vr *Var
 	}
 	ParseError struct {
-		obj Object
-		msg string
+		obj    Object
+		msg    string
+		macros []Seq // calls of the macros the error was expanded from, innermost first
 	}
 	Callable interface {
 		Call(args []Object) Object
@@ -134,6 +148,7 @@ type (
 		frame        int
 		isUsed       bool
 		inferredType *Type
+		isTypeExact  bool // inferredType is that of a literal, not a type hint
 	}
 	Bindings struct {
 		bindings map[*string]*Binding
@@ -153,11 +168,20 @@ type (
 		recur                  bool
 		noRecurAllowed         bool
 		isUnknownCallableScope bool
+		loopDepth              int
 	}
 	Warnings struct {
 		ifWithoutElse           bool
 		unusedFnParameters      bool
 		fnWithEmptyBody         bool
+		rePatternInLoop         bool
+		argTypeMismatch         bool
+		deprecatedVar           bool
+		shadowedBinding         bool
+		unreachableCode         bool
+		constantCondition       bool
+		formatArgs              bool
+		severities              map[string]string // rule id to "error", "warning", "info" or "off"
 		ignoredUnusedNamespaces Set
 		IgnoredFileRegexes      []*regexp.Regexp
 		entryPoints             Set
@@ -169,6 +193,7 @@ type (
 		line               Keyword
 		column             Keyword
 		file               Keyword
+		source             Keyword
 		ns                 Keyword
 		macro              Keyword
 		message            Keyword
@@ -176,15 +201,30 @@ type (
 		data               Keyword
 		cause              Keyword
 		arglist            Keyword
+		kwargs             Keyword
 		doc                Keyword
 		added              Keyword
 		meta               Keyword
+		validator          Keyword
 		knownMacros        Keyword
 		rules              Keyword
 		ifWithoutElse      Keyword
 		unusedFnParameters Keyword
 		fnWithEmptyBody    Keyword
+		rePatternInLoop    Keyword
+		argTypeMismatch    Keyword
+		deprecatedVar      Keyword
+		shadowedBinding    Keyword
+		unreachableCode    Keyword
+		constantCondition  Keyword
+		formatArgs         Keyword
+		lintCondition      Keyword
+		noReturn           Keyword
+		deprecated         Keyword
+		supersededBy       Keyword
 		_prefix            Keyword
+		_rule              Keyword
+		severity           Keyword
 		pos                Keyword
 		startLine          Keyword
 		endLine            Keyword
@@ -211,6 +251,7 @@ type (
 		ascii              Keyword
 		unicode            Keyword
 		any                Keyword
+		default_           Keyword
 	}
 	Symbols struct {
 		joker_core         Symbol
@@ -247,6 +288,7 @@ type (
 		hashMap            Symbol
 		hashSet            Symbol
 		defaultDataReaders Symbol
+		dataReaders        Symbol
 		backslash          Symbol
 		deref              Symbol
 		ns                 Symbol
@@ -287,6 +329,11 @@ var (
 	IN_NS_VAR      *Var
 	WARNINGS       = Warnings{
 		fnWithEmptyBody: true,
+		rePatternInLoop: true,
+		argTypeMismatch: true,
+		deprecatedVar:   true,
+		unreachableCode: true,
+		formatArgs:      true,
 		entryPoints:     EmptySet(),
 	}
 )
@@ -373,9 +420,12 @@ func (b *Bindings) AddBinding(sym Symbol, index int, skipUnused bool, inferredTy
 	if LINTER_MODE && !skipUnused {
 		old := b.bindings[sym.name]
 		if old != nil && needsUnusedWarning(old) {
-			printParseWarning(GetPosition(old.name), "Unused binding: "+old.name.ToString(false))
+			printParseWarning(GetPosition(old.name), "unused-binding", "Unused binding: "+old.name.ToString(false))
 		}
 	}
+	if LINTER_MODE && WARNINGS.shadowedBinding {
+		b.checkShadowing(sym)
+	}
 	b.bindings[sym.name] = &Binding{
 		name:         sym,
 		frame:        b.frame,
@@ -384,6 +434,26 @@ func (b *Bindings) AddBinding(sym Symbol, index int, skipUnused bool, inferredTy
 	}
 }
 
+// checkShadowing warns if sym shadows a binding of an outer frame or a
+// var referred from another namespace. Macros such as loop bind the
+// same symbol more than once; that isn't reported, as both bindings
+// come from the same place in the source.
+func (b *Bindings) checkShadowing(sym Symbol) {
+	if strings.HasPrefix(*sym.name, "_") || strings.HasPrefix(*sym.name, "&") {
+		return
+	}
+	pos := GetPosition(sym)
+	if outer := b.parent.GetBinding(sym); outer != nil {
+		if GetPosition(outer.name) != pos {
+			printParseWarning(pos, "shadowed-binding", "Binding "+sym.ToString(false)+" shadows an outer local binding")
+		}
+		return
+	}
+	if vr, ok := GLOBAL_ENV.Resolve(sym); ok && !vr.isFake && vr.ns != GLOBAL_ENV.CurrentNamespace() {
+		printParseWarning(pos, "shadowed-binding", "Binding "+sym.ToString(false)+" shadows var "+varCallableString(vr))
+	}
+}
+
 func (ctx *ParseContext) PushEmptyLocalFrame() {
 	ctx.localBindings = ctx.localBindings.PushFrame()
 }
@@ -421,26 +491,97 @@ func (pos Position) Pos() Position {
 	return pos
 }
 
-func printError(pos Position, msg string) {
+func countError() {
+	PROBLEM_COUNT++
+	ERROR_COUNT++
+}
+
+// ProblemLimitExceeded reports whether more problems have been found
+// than --max-problems allows, in which case no more are printed.
+func ProblemLimitExceeded() bool {
+	return MAX_PROBLEMS > 0 && PROBLEM_COUNT > MAX_PROBLEMS
+}
+
+// printError prints a problem of the given kind (such as "Parse
+// warning") found by the linter, or collects it for WriteLintProblems
+// unless LINT_FORMAT is "text". rule identifies the check that found
+// it, and may be ignored at pos or have its severity changed in .joker.
+func printError(pos Position, kind string, rule string, msg string) {
+	if isIgnored(pos, rule) {
+		return
+	}
+	if severity, ok := WARNINGS.severities[rule]; ok {
+		if severity == "off" {
+			return
+		}
+		kind = kind[:strings.IndexByte(kind, ' ')+1] + severity
+	}
+	p := lintProblem{pos: pos, kind: kind, rule: rule, msg: msg}
+	captureProblem(p, "")
+	reportProblem(p)
+}
+
+// reportProblem counts p and prints (or collects) it, unless there have
+// been too many problems already.
+func reportProblem(p lintProblem) {
 	PROBLEM_COUNT++
-	fmt.Fprintf(Stderr, "%s:%d:%d: %s\n", pos.Filename(), pos.startLine, pos.startColumn, msg)
+	switch kindSeverity(p.kind) {
+	case "error":
+		ERROR_COUNT++
+	case "info":
+		INFO_COUNT++
+	}
+	if ProblemLimitExceeded() {
+		return
+	}
+	if LINT_FORMAT != "text" {
+		lintProblems = append(lintProblems, p)
+		return
+	}
+	printLintText(Stderr, fmt.Sprintf("%s:%d:%d: %s: %s", p.pos.Filename(), p.pos.startLine, p.pos.startColumn, p.kind, p.msg))
+}
+
+// isIgnored returns whether a joker:ignore comment on the line of pos,
+// or the line before it, suppresses rule.
+func isIgnored(pos Position, rule string) bool {
+	lines := ignoreComments[pos.filename]
+	for _, line := range []int{pos.startLine, pos.startLine - 1} {
+		for _, r := range lines[line] {
+			if r == rule {
+				return true
+			}
+		}
+	}
+	return false
 }
 
-func printParseWarning(pos Position, msg string) {
-	printError(pos, "Parse warning: "+msg)
+// kindSeverity returns the severity ("error", "warning" or "info") of
+// problems of the given kind.
+func kindSeverity(kind string) string {
+	switch {
+	case strings.HasSuffix(kind, " warning"):
+		return "warning"
+	case strings.HasSuffix(kind, " info"):
+		return "info"
+	}
+	return "error"
 }
 
-func printParseError(pos Position, msg string) {
-	printError(pos, "Parse error: "+msg)
+func printParseWarning(pos Position, rule string, msg string) {
+	printError(pos, "Parse warning", rule, msg)
 }
 
-func printReadWarning(reader *Reader, msg string) {
+func printParseError(pos Position, rule string, msg string) {
+	printError(pos, "Parse error", rule, msg)
+}
+
+func printReadWarning(reader *Reader, rule string, msg string) {
 	pos := Position{
 		filename:    reader.filename,
 		startColumn: reader.column,
 		startLine:   reader.line,
 	}
-	printError(pos, "Read warning: "+msg)
+	printError(pos, "Read warning", rule, msg)
 }
 
 func printReadError(reader *Reader, msg string) {
@@ -449,7 +590,7 @@ func printReadError(reader *Reader, msg string) {
 		startColumn: reader.column,
 		startLine:   reader.line,
 	}
-	printError(pos, "Read error: "+msg)
+	printError(pos, "Read error", "read-error", msg)
 }
 
 func isIgnoredUnusedNamespace(ns *Namespace) bool {
@@ -461,12 +602,12 @@ func isIgnoredUnusedNamespace(ns *Namespace) bool {
 }
 
 func ResetUsage() {
-	for _, ns := range GLOBAL_ENV.Namespaces {
+	for _, ns := range GLOBAL_ENV.AllNamespaces() {
 		if ns == GLOBAL_ENV.CoreNamespace {
 			continue
 		}
 		ns.isUsed = true
-		for _, vr := range ns.mappings {
+		for _, vr := range ns.Mappings() {
 			vr.isUsed = true
 		}
 	}
@@ -481,7 +622,7 @@ func WarnOnGloballyUnusedNamespaces() {
 	var names []string
 	positions := make(map[string]Position)
 
-	for _, ns := range GLOBAL_ENV.Namespaces {
+	for _, ns := range GLOBAL_ENV.AllNamespaces() {
 		if !ns.isGloballyUsed && !isIgnoredUnusedNamespace(ns) && !isEntryPointNs(ns) {
 			pos := ns.Name.GetInfo()
 			if pos != nil && pos.Filename() != "<joker.core>" && pos.Filename() != "<user>" {
@@ -494,7 +635,7 @@ func WarnOnGloballyUnusedNamespaces() {
 
 	sort.Strings(names)
 	for _, name := range names {
-		printParseWarning(positions[name], "globally unused namespace "+name)
+		printParseWarning(positions[name], "globally-unused-namespace", "globally unused namespace "+name)
 	}
 }
 
@@ -502,7 +643,7 @@ func WarnOnUnusedNamespaces() {
 	var names []string
 	positions := make(map[string]Position)
 
-	for _, ns := range GLOBAL_ENV.Namespaces {
+	for _, ns := range GLOBAL_ENV.AllNamespaces() {
 		if ns != GLOBAL_ENV.CurrentNamespace() && !ns.isUsed && !isIgnoredUnusedNamespace(ns) {
 			pos := ns.Name.GetInfo()
 			if pos != nil && pos.Filename() != "<joker.core>" && pos.Filename() != "<user>" {
@@ -515,7 +656,7 @@ func WarnOnUnusedNamespaces() {
 
 	sort.Strings(names)
 	for _, name := range names {
-		printParseWarning(positions[name], "unused namespace "+name)
+		printParseWarning(positions[name], "unused-namespace", "unused namespace "+name)
 	}
 }
 
@@ -535,14 +676,14 @@ func WarnOnGloballyUnusedVars() {
 	var names []string
 	positions := make(map[string]Position)
 
-	for _, ns := range GLOBAL_ENV.Namespaces {
+	for _, ns := range GLOBAL_ENV.AllNamespaces() {
 		if ns == GLOBAL_ENV.CoreNamespace {
 			continue
 		}
-		for _, vr := range ns.mappings {
+		for _, vr := range ns.Mappings() {
 			if vr.ns == ns && !vr.isGloballyUsed && !vr.isPrivate && !isRecordConstructor(vr.name) && !isEntryPointVar(vr) {
 				pos := vr.GetInfo()
-				if pos != nil {
+				if pos != nil && pos.Filename() != "<joker.core>" && pos.Filename() != "<user>" {
 					varName := vr.Name()
 					names = append(names, varName)
 					positions[varName] = pos.Position
@@ -553,7 +694,7 @@ func WarnOnGloballyUnusedVars() {
 
 	sort.Strings(names)
 	for _, name := range names {
-		printParseWarning(positions[name], "globally unused var "+name)
+		printParseWarning(positions[name], "globally-unused-var", "globally unused var "+name)
 	}
 }
 
@@ -561,11 +702,11 @@ func WarnOnUnusedVars() {
 	var names []string
 	positions := make(map[string]Position)
 
-	for _, ns := range GLOBAL_ENV.Namespaces {
+	for _, ns := range GLOBAL_ENV.AllNamespaces() {
 		if ns == GLOBAL_ENV.CoreNamespace {
 			continue
 		}
-		for _, vr := range ns.mappings {
+		for _, vr := range ns.Mappings() {
 			if vr.ns == ns && !vr.isUsed && vr.isPrivate {
 				pos := vr.GetInfo()
 				if pos != nil {
@@ -578,7 +719,7 @@ func WarnOnUnusedVars() {
 
 	sort.Strings(names)
 	for _, name := range names {
-		printParseWarning(positions[name], "unused var "+name)
+		printParseWarning(positions[name], "unused-var", "unused var "+name)
 	}
 }
 
@@ -711,6 +852,9 @@ func updateVar(vr *Var, info *ObjectInfo, valueExpr Expr, sym Symbol) {
 			vr.isDynamic = ToBool(p)
 		}
 		vr.taggedType = getTaggedType(sym)
+		if LINTER_MODE {
+			vr.lintMeta = meta
+		}
 	}
 }
 
@@ -787,6 +931,7 @@ func parseBody(seq Seq, ctx *ParseContext) []Expr {
 	ctx.recur = false
 	defer func() { ctx.recur = recur }()
 	res := make([]Expr, 0)
+	reachable := true
 	for !seq.IsEmpty() {
 		ro := seq.First()
 		expr := Parse(ro, ctx)
@@ -797,15 +942,39 @@ func parseBody(seq Seq, ctx *ParseContext) []Expr {
 		res = append(res, expr)
 		if LINTER_MODE {
 			if defExpr, ok := expr.(*DefExpr); ok && !defExpr.isCreatedByMacro {
-				printParseWarning(defExpr.Pos(), "inline def")
+				printParseWarning(defExpr.Pos(), "inline-def", "inline def")
 			} else if doExpr, ok := expr.(*DoExpr); ok && !doExpr.isCreatedByMacro && !skipRedundantDo(ro) {
-				printParseWarning(doExpr.Pos(), "redundant do form")
+				printParseWarning(doExpr.Pos(), "redundant-do", "redundant do form")
+			}
+			if WARNINGS.unreachableCode && reachable && !seq.IsEmpty() && neverReturns(expr) {
+				// Don't report the code that macros add after it.
+				if pos := GetPosition(seq.First()); pos.filename != nil && pos.filename != STR.coreFilename {
+					printParseWarning(pos, "unreachable-code", "unreachable code")
+					reachable = false
+				}
 			}
 		}
 	}
 	return res
 }
 
+// neverReturns returns whether evaluating expr can't complete normally:
+// it's a throw, a recur or a call to a function declared ^:no-return.
+func neverReturns(expr Expr) bool {
+	switch expr := expr.(type) {
+	case *ThrowExpr, *RecurExpr:
+		return true
+	case *CallExpr:
+		if vref, ok := expr.callable.(*VarRefExpr); ok {
+			if meta := linterMeta(vref.vr); meta != nil {
+				ok, noReturn := meta.Get(KEYWORDS.noReturn)
+				return ok && ToBool(noReturn)
+			}
+		}
+	}
+	return false
+}
+
 func parseParams(params Object) (bindings []Symbol, isVariadic bool) {
 	res := make([]Symbol, 0)
 	v := params.(*Vector)
@@ -896,7 +1065,7 @@ func addArity(fn *FnExpr, sig Seq, ctx *ParseContext) {
 	if LINTER_MODE {
 		if WARNINGS.fnWithEmptyBody {
 			if len(arity.body) == 0 {
-				printParseWarning(arity.Position, "fn form with empty body")
+				printParseWarning(arity.Position, "fn-with-empty-body", "fn form with empty body")
 			}
 		}
 
@@ -909,7 +1078,7 @@ func addArity(fn *FnExpr, sig Seq, ctx *ParseContext) {
 			}
 			sort.Sort(BySymbolName(unused))
 			for _, u := range unused {
-				printParseWarning(GetPosition(u), "unused parameter: "+u.ToString(false))
+				printParseWarning(GetPosition(u), "unused-fn-parameters", "unused parameter: "+u.ToString(false))
 			}
 		}
 	}
@@ -998,7 +1167,38 @@ func parseCatch(obj Object, ctx *ParseContext) *CatchExpr {
 		panic(&ParseError{obj: obj, msg: "catch requires at least two arguments: type symbol and binding symbol"})
 	}
 	excSymbol := Second(seq)
-	excType := resolveType(seq.First(), ctx)
+	// excTypes and excTags are parallel: (catch :tag e body*) catches
+	// ExInfos whose data's :type isa? :tag, and has a nil type tag.
+	var excTypes []*Type
+	var excTags []Object
+	addExcType := func(obj Object) {
+		if tag, ok := obj.(Keyword); ok {
+			excTypes = append(excTypes, TYPE.ExInfo)
+			excTags = append(excTags, tag)
+		} else {
+			excTypes = append(excTypes, resolveType(obj, ctx))
+			excTags = append(excTags, nil)
+		}
+	}
+	// (catch [T1 T2 ...] e body*) catches any of the types or tags.
+	if v, ok := seq.First().(*Vector); ok {
+		if v.count == 0 {
+			panic(&ParseError{obj: v, msg: "catch requires at least one type or tag"})
+		}
+		for i := 0; i < v.count; i++ {
+			addExcType(v.at(i))
+		}
+	} else {
+		addExcType(seq.First())
+	}
+	body := seq.Rest().Rest()
+	if isDestructuring(excSymbol) {
+		// (catch T pattern body*) => (catch T e (let [pattern e] body*))
+		sym := generateSymbol("exc__")
+		let := body.Cons(NewVectorFrom(excSymbol, sym)).Cons(MakeSymbol("joker.core/let"))
+		body = NewListFrom(DeriveReadObject(obj, let))
+		excSymbol = sym
+	}
 	if !IsSymbol(excSymbol) {
 		panic(&ParseError{obj: excSymbol, msg: "Bad binding form, expected symbol, got: " + excSymbol.ToString(false)})
 	}
@@ -1006,9 +1206,10 @@ func parseCatch(obj Object, ctx *ParseContext) *CatchExpr {
 	defer ctx.PopLocalFrame()
 	return &CatchExpr{
 		Position:  GetPosition(obj),
-		excType:   excType,
+		excTypes:  excTypes,
+		excTags:   excTags,
 		excSymbol: excSymbol.(Symbol),
-		body:      parseBody(seq.Rest().Rest(), ctx),
+		body:      parseBody(body, ctx),
 	}
 }
 
@@ -1051,18 +1252,81 @@ func parseTry(obj Object, ctx *ParseContext) *TryExpr {
 	}
 	if LINTER_MODE {
 		if res.body == nil {
-			printParseWarning(res.Pos(), "try form with empty body")
+			printParseWarning(res.Pos(), "empty-body", "try form with empty body")
 		}
 		if res.catches == nil && res.finallyExpr == nil {
-			printParseWarning(res.Pos(), "try form without catch or finally")
+			printParseWarning(res.Pos(), "try-without-catch", "try form without catch or finally")
 		}
 		if res.finallyExpr != nil && len(res.finallyExpr) == 0 {
-			printParseWarning(GetPosition(obj), "finally form with empty body")
+			printParseWarning(GetPosition(obj), "empty-body", "finally form with empty body")
 		}
 	}
 	return res
 }
 
+// isDestructuring reports whether b is a binding form that has to be
+// destructured rather than bound as is.
+func isDestructuring(b Object) bool {
+	switch b.(type) {
+	case Map, *Vector:
+		return true
+	}
+	return false
+}
+
+func letUsesDestructuring(seq Seq) bool {
+	if b, ok := Second(seq).(*Vector); ok {
+		for i := 0; i < b.count; i += 2 {
+			if isDestructuring(b.at(i)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func paramsUseDestructuring(params Object) bool {
+	if v, ok := params.(*Vector); ok {
+		for i := 0; i < v.count; i++ {
+			if isDestructuring(v.at(i)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func fnUsesDestructuring(seq Seq) bool {
+	bodies := seq.Rest()
+	if IsSymbol(bodies.First()) {
+		bodies = bodies.Rest()
+	}
+	if IsVector(bodies.First()) {
+		return paramsUseDestructuring(bodies.First())
+	}
+	for ; !bodies.IsEmpty(); bodies = bodies.Rest() {
+		if s, ok := bodies.First().(Seq); ok && paramsUseDestructuring(s.First()) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDestructuring parses a let*, loop* or fn* form whose binding
+// forms need destructuring by expanding the core macro (let, loop or fn)
+// that rewrites them into plain symbol bindings. uses tells whether a
+// form still needs destructuring: if the macro isn't defined yet, or
+// expands into the same special form still needing it (as it does
+// while core.joke defines it), parsing the expansion would never end.
+func parseDestructuring(seq Seq, macro string, uses func(Seq) bool, ctx *ParseContext) Expr {
+	form := DeriveReadObject(seq, seq.Rest().Cons(MakeSymbol("joker.core/"+macro))).(Seq)
+	expanded := macroexpand1(form, ctx)
+	if s, ok := expanded.(Seq); expanded == Object(form) || (ok && s.First().Equals(seq.First()) && uses(s)) {
+		panic(&ParseError{obj: seq, msg: "Can't destructure binding forms until joker.core/" + macro + " is defined"})
+	}
+	return parseExpansion(form, expanded, ctx)
+}
+
 func parseLet(obj Object, ctx *ParseContext) *LetExpr {
 	return parseLetLoop(obj, "let", ctx)
 }
@@ -1096,7 +1360,7 @@ func parseLetLoop(obj Object, formName string, ctx *ParseContext) *LetExpr {
 		}
 		if LINTER_MODE && formName != "loop" && b.count == 0 {
 			pos := GetPosition(obj)
-			printParseWarning(pos, formName+" form with empty bindings vector")
+			printParseWarning(pos, "empty-bindings", formName+" form with empty bindings vector")
 		}
 		skipUnused := isSkipUnused(b)
 		res.names = make([]Symbol, b.count/2)
@@ -1111,7 +1375,7 @@ func parseLetLoop(obj Object, formName string, ctx *ParseContext) *LetExpr {
 				if sym.ns != nil {
 					msg := "Can't let qualified name: " + sym.ToString(false)
 					if LINTER_MODE {
-						printParseError(GetPosition(s), msg)
+						printParseError(GetPosition(s), "parse-error", msg)
 					} else {
 						panic(&ParseError{obj: s, msg: msg})
 					}
@@ -1127,11 +1391,15 @@ func parseLetLoop(obj Object, formName string, ctx *ParseContext) *LetExpr {
 			var inferredType *Type
 			if formName != "letfn" {
 				res.values[i] = Parse(b.at(i*2+1), ctx)
-				if LINTER_MODE {
+				if LINTER_MODE && (WARNINGS.argTypeMismatch || WARNINGS.constantCondition) {
 					inferredType = res.values[i].InferType()
 				}
 			}
 			ctx.localBindings.AddBinding(res.names[i], i, skipUnused, inferredType)
+			// Loop bindings get other values on recur.
+			if formName == "let" && inferredType != nil && hasExactType(res.values[i]) {
+				ctx.localBindings.bindings[res.names[i].name].isTypeExact = true
+			}
 		}
 
 		if formName == "letfn" {
@@ -1143,6 +1411,8 @@ func parseLetLoop(obj Object, formName string, ctx *ParseContext) *LetExpr {
 		if formName == "loop" {
 			ctx.PushLoopBindings(res.names)
 			defer ctx.PopLoopBindings()
+			ctx.loopDepth++
+			defer func() { ctx.loopDepth-- }()
 
 			noRecurAllowed := ctx.noRecurAllowed
 			ctx.noRecurAllowed = false
@@ -1154,7 +1424,7 @@ func parseLetLoop(obj Object, formName string, ctx *ParseContext) *LetExpr {
 		if LINTER_MODE {
 			if len(res.body) == 0 {
 				pos := GetPosition(obj)
-				printParseWarning(pos, formName+" form with empty body")
+				printParseWarning(pos, "empty-body", formName+" form with empty body")
 			}
 
 			if !skipUnused {
@@ -1166,7 +1436,7 @@ func parseLetLoop(obj Object, formName string, ctx *ParseContext) *LetExpr {
 				}
 				sort.Sort(BySymbolName(unused))
 				for _, u := range unused {
-					printParseWarning(GetPosition(u), "unused binding: "+u.ToString(false))
+					printParseWarning(GetPosition(u), "unused-binding", "unused binding: "+u.ToString(false))
 				}
 			}
 		}
@@ -1197,6 +1467,67 @@ func parseRecur(obj Object, ctx *ParseContext) *RecurExpr {
 	}
 }
 
+// hasExactType returns whether the type inferred for expr is the type
+// of its value, rather than a type hint, which doesn't rule out nil.
+func hasExactType(expr Expr) bool {
+	switch expr := expr.(type) {
+	case *LiteralExpr:
+		return !expr.isSurrogate
+	case *VectorExpr, *MapExpr, *SetExpr, *FnExpr:
+		return true
+	}
+	return false
+}
+
+// checkConstantCondition warns if the condition of the if form seq is
+// always true or always false: it's a literal, or a binding of a value
+// whose type rules out (or is) nil and false. Ifs created by macros are
+// only checked if marked with :lint-condition (as when's are) and their
+// condition comes from the code being linted.
+func checkConstantCondition(seq Seq, cond Expr) {
+	pos := cond.Pos()
+	if isCreatedByMacro(seq) {
+		m, ok := seq.(Meta)
+		if !ok || m.GetMeta() == nil {
+			return
+		}
+		if ok, _ := m.GetMeta().Get(KEYWORDS.lintCondition); !ok {
+			return
+		}
+		if pos.filename == nil || pos.filename == STR.coreFilename {
+			return
+		}
+	}
+	var truthy bool
+	switch cond := cond.(type) {
+	case *LiteralExpr:
+		if cond.isSurrogate {
+			return
+		}
+		truthy = ToBool(cond.obj)
+	case *BindingExpr:
+		t := cond.binding.inferredType
+		if t == nil || !cond.binding.isTypeExact {
+			return
+		}
+		switch {
+		case t == TYPE.Nil:
+			truthy = false
+		case IsEqualOrImplements(t, TYPE.Nil) || IsEqualOrImplements(t, TYPE.Boolean):
+			return
+		default:
+			truthy = true
+		}
+	default:
+		return
+	}
+	if truthy {
+		printParseWarning(pos, "constant-condition", "condition is always true")
+	} else {
+		printParseWarning(pos, "constant-condition", "condition is always false")
+	}
+}
+
 func resolveMacro(obj Object, ctx *ParseContext) *Var {
 	switch sym := obj.(type) {
 	case Symbol:
@@ -1292,8 +1623,22 @@ func macroexpand1(seq Seq, ctx *ParseContext) Object {
 	}
 }
 
+// parseExpansion parses the expansion of the macro call form, recording
+// form in any parse error raised so it can be reported.
+func parseExpansion(form Seq, expanded Object, ctx *ParseContext) Expr {
+	defer func() {
+		if r := recover(); r != nil {
+			if err, ok := r.(*ParseError); ok {
+				err.macros = append(err.macros, form)
+			}
+			panic(r)
+		}
+	}()
+	return Parse(expanded, ctx)
+}
+
 func reportNotAFunction(pos Position, name string) {
-	printParseWarning(pos, name+" is not a function")
+	printParseWarning(pos, "not-a-function", name+" is not a function")
 }
 
 func getTaggedType(obj Meta) *Type {
@@ -1352,13 +1697,16 @@ func typesString(types []*Type) string {
 }
 
 func checkTypes(declaredArgs []Symbol, call *CallExpr) bool {
+	if !WARNINGS.argTypeMismatch {
+		return false
+	}
 	res := false
 	for i, da := range declaredArgs {
 		if declaredTypes := getTaggedTypes(da); len(declaredTypes) > 0 {
 			passedType := call.args[i].InferType()
 			if passedType != nil {
 				if !isTypeOneOf(declaredTypes, passedType) {
-					printParseWarning(call.args[i].Pos(), fmt.Sprintf("arg[%d] of %s must have type %s, got %s", i, call.Name(), typesString(declaredTypes), passedType.ToString(false)))
+					printParseWarning(call.args[i].Pos(), "arg-type-mismatch", fmt.Sprintf("arg[%d] of %s must have type %s, got %s", i, call.Name(), typesString(declaredTypes), passedType.ToString(false)))
 					res = true
 				}
 			}
@@ -1385,12 +1733,42 @@ func reportWrongArity(expr *FnExpr, isMacro bool, call *CallExpr, pos Position)
 		passedArgsCount += 2
 	}
 	if v := selectArity(expr, passedArgsCount); v != nil {
+		if v == expr.variadic && !checkKeywordArgs(v, passedArgsCount, call) {
+			printParseWarning(pos, "wrong-arity", fmt.Sprintf("Wrong number of args (%d) passed to %s: keyword args must be key/value pairs, optionally followed by a map", len(call.args), call.Name()))
+			return true
+		}
 		return checkTypes(v.args, call)
 	}
-	printParseWarning(pos, fmt.Sprintf("Wrong number of args (%d) passed to %s", len(call.args), call.Name()))
+	printParseWarning(pos, "wrong-arity", fmt.Sprintf("Wrong number of args (%d) passed to %s", len(call.args), call.Name()))
 	return true
 }
 
+// checkKeywordArgs returns false if the variadic arity destructures its
+// rest args as keyword args, as in (fn [& {:keys [a b]}]), and they
+// can't be key/value pairs followed by an optional map: their count is
+// odd and the last one is a literal other than a map (or nil).
+func checkKeywordArgs(v *FnArityExpr, passedArgsCount int, call *CallExpr) bool {
+	m := v.args[len(v.args)-1].GetMeta()
+	if m == nil {
+		return true
+	}
+	if ok, kwargs := m.Get(KEYWORDS.kwargs); !ok || !ToBool(kwargs) {
+		return true
+	}
+	if (passedArgsCount-len(v.args)+1)%2 == 0 {
+		return true
+	}
+	last, ok := call.args[len(call.args)-1].(*LiteralExpr)
+	if !ok || last.isSurrogate {
+		return true
+	}
+	switch last.obj.(type) {
+	case Map, Nil:
+		return true
+	}
+	return false
+}
+
 func checkArglist(arglist Seq, passedArgsCount int) bool {
 	for !arglist.IsEmpty() {
 		if v, ok := arglist.First().(*Vector); ok {
@@ -1405,6 +1783,7 @@ func checkArglist(arglist Seq, passedArgsCount int) bool {
 }
 
 func setMacroMeta(vr *Var) {
+	vr.unpackMeta()
 	if vr.meta == nil {
 		vr.meta = EmptyArrayMap().Assoc(KEYWORDS.macro, Boolean{B: true}).(Map)
 	} else {
@@ -1478,6 +1857,143 @@ func isUnknownCallable(expr Expr) (bool, Seq) {
 	return false, nil
 }
 
+func isRePatternOfLiteral(vr *Var, args []Expr) bool {
+	if vr.ns != GLOBAL_ENV.CoreNamespace || *vr.name.name != "re-pattern" || len(args) != 1 {
+		return false
+	}
+	if arg, ok := args[0].(*LiteralExpr); ok {
+		_, ok = arg.obj.(String)
+		return ok
+	}
+	return false
+}
+
+// Kinds of native Go values format passes to fmt.Sprintf (see ToNative).
+const (
+	formatInt = 1 << iota
+	formatFloat
+	formatBool
+	formatString
+	formatAny = formatInt | formatFloat | formatBool | formatString
+)
+
+// formatVerbs maps fmt verbs to the kinds of values they accept.
+var formatVerbs = map[rune]int{
+	'v': formatAny,
+	'T': formatAny,
+	't': formatBool,
+	'b': formatInt | formatFloat,
+	'c': formatInt,
+	'd': formatInt,
+	'o': formatInt,
+	'O': formatInt,
+	'U': formatInt,
+	'q': formatInt | formatString,
+	'x': formatInt | formatFloat | formatString,
+	'X': formatInt | formatFloat | formatString,
+	'e': formatFloat,
+	'E': formatFloat,
+	'f': formatFloat,
+	'F': formatFloat,
+	'g': formatFloat,
+	'G': formatFloat,
+	's': formatString,
+}
+
+// formatKind returns the kind of native value format passes for
+// objects of type t, or 0 if it depends on the value.
+func formatKind(t *Type) int {
+	switch t {
+	case TYPE.Int, TYPE.Char:
+		return formatInt
+	case TYPE.Double:
+		return formatFloat
+	case TYPE.Boolean:
+		return formatBool
+	case TYPE.String, TYPE.Keyword, TYPE.Symbol, TYPE.Nil,
+		TYPE.Vector, TYPE.ArrayMap, TYPE.HashMap, TYPE.MapSet, TYPE.List:
+		return formatString
+	}
+	return 0
+}
+
+// checkFormatArgs warns if the args of a format or printf call don't
+// match the verbs of its (literal) format string, in number or kind.
+// Format strings with explicit arg indexes aren't checked.
+func checkFormatArgs(vr *Var, call *CallExpr) {
+	if vr.ns != GLOBAL_ENV.CoreNamespace || len(call.args) == 0 {
+		return
+	}
+	if name := *vr.name.name; name != "format" && name != "printf" {
+		return
+	}
+	lit, ok := call.args[0].(*LiteralExpr)
+	if !ok || lit.isSurrogate {
+		return
+	}
+	s, ok := lit.obj.(String)
+	if !ok {
+		return
+	}
+	f := s.S
+	args := call.args[1:]
+	n := 0
+	checkArg := func(verb string, kinds int) {
+		if n < len(args) {
+			if t := args[n].InferType(); t != nil {
+				if k := formatKind(t); k != 0 && k&kinds == 0 {
+					printParseWarning(args[n].Pos(), "format-args", fmt.Sprintf("%s format verb %s got an arg of type %s", call.Name(), verb, t.ToString(false)))
+				}
+			}
+		}
+		n++
+	}
+	skipWidth := func(i int) int {
+		if i < len(f) && f[i] == '*' {
+			checkArg("*", formatInt)
+			return i + 1
+		}
+		for i < len(f) && f[i] >= '0' && f[i] <= '9' {
+			i++
+		}
+		return i
+	}
+	for i := 0; i < len(f); i++ {
+		if f[i] != '%' {
+			continue
+		}
+		i++
+		for i < len(f) && strings.IndexByte("+-# 0", f[i]) >= 0 {
+			i++
+		}
+		i = skipWidth(i)
+		if i < len(f) && f[i] == '.' {
+			i = skipWidth(i + 1)
+		}
+		if i >= len(f) {
+			printParseWarning(lit.Pos(), "format-args", "format string ends with a lone %")
+			break
+		}
+		if f[i] == '[' {
+			return
+		}
+		verb, size := utf8.DecodeRuneInString(f[i:])
+		i += size - 1
+		if verb == '%' {
+			continue
+		}
+		kinds, ok := formatVerbs[verb]
+		if !ok {
+			printParseWarning(lit.Pos(), "format-args", fmt.Sprintf("unknown format verb %%%c", verb))
+			kinds = formatAny
+		}
+		checkArg("%"+string(verb), kinds)
+	}
+	if n != len(args) {
+		printParseWarning(call.Pos(), "format-args", fmt.Sprintf("%s format string needs %d args, got %d", call.Name(), n, len(args)))
+	}
+}
+
 func areAllLiteralExprs(exprs []Expr) bool {
 	for _, expr := range exprs {
 		if _, ok := expr.(*LiteralExpr); !ok {
@@ -1529,11 +2045,11 @@ func checkCall(expr Expr, isMacro bool, call *CallExpr, pos Position) {
 		reportWrongArity(expr, isMacro, call, pos)
 	case *MapExpr:
 		if argsCount == 0 || argsCount > 2 {
-			printParseWarning(pos, fmt.Sprintf("Wrong number of args (%d) passed to a map", argsCount))
+			printParseWarning(pos, "wrong-arity", fmt.Sprintf("Wrong number of args (%d) passed to a map", argsCount))
 		}
 	case *SetExpr:
 		if argsCount == 0 || argsCount > 1 {
-			printParseWarning(pos, fmt.Sprintf("Wrong number of args (%d) passed to a set", argsCount))
+			printParseWarning(pos, "wrong-arity", fmt.Sprintf("Wrong number of args (%d) passed to a set", argsCount))
 		}
 	case *LiteralExpr:
 		if _, ok := expr.obj.(Callable); !ok && !expr.isSurrogate {
@@ -1543,7 +2059,7 @@ func checkCall(expr Expr, isMacro bool, call *CallExpr, pos Position) {
 		switch expr.obj.(type) {
 		case Keyword:
 			if argsCount == 0 || argsCount > 2 {
-				printParseWarning(pos, fmt.Sprintf("Wrong number of args (%d) passed to %s", argsCount, call.Name()))
+				printParseWarning(pos, "wrong-arity", fmt.Sprintf("Wrong number of args (%d) passed to %s", argsCount, call.Name()))
 			}
 		}
 	case *RecurExpr:
@@ -1556,7 +2072,7 @@ func checkCall(expr Expr, isMacro bool, call *CallExpr, pos Position) {
 func parseList(obj Object, ctx *ParseContext) Expr {
 	expanded := macroexpand1(obj.(Seq), ctx)
 	if expanded != obj {
-		return Parse(expanded, ctx)
+		return parseExpansion(obj.(Seq), expanded, ctx)
 	}
 	seq := obj.(Seq)
 	if seq.IsEmpty() {
@@ -1579,21 +2095,34 @@ func parseList(obj Object, ctx *ParseContext) Expr {
 		case STR._if:
 			checkForm(obj, 3, 4)
 			if LINTER_MODE && SeqCount(seq) < 4 && WARNINGS.ifWithoutElse {
-				printParseWarning(pos, "missing else branch")
+				printParseWarning(pos, "if-without-else", "missing else branch")
+			}
+			cond := Parse(Second(seq), ctx)
+			if LINTER_MODE && WARNINGS.constantCondition {
+				checkConstantCondition(seq, cond)
 			}
-			return &IfExpr{
-				cond:     Parse(Second(seq), ctx),
+			return pruneDeadBranch(&IfExpr{
+				cond:     cond,
 				positive: Parse(Third(seq), ctx),
 				negative: Parse(Fourth(seq), ctx),
 				Position: pos,
-			}
+			})
 		case STR.fn_:
+			if fnUsesDestructuring(seq) {
+				return parseDestructuring(seq, "fn", fnUsesDestructuring, ctx)
+			}
 			return parseFn(obj, ctx)
 		case STR.let_:
+			if letUsesDestructuring(seq) {
+				return parseDestructuring(seq, "let", letUsesDestructuring, ctx)
+			}
 			return parseLet(obj, ctx)
 		case STR.letfn_:
 			return parseLetfn(obj, ctx)
 		case STR.loop_:
+			if letUsesDestructuring(seq) {
+				return parseDestructuring(seq, "loop", letUsesDestructuring, ctx)
+			}
 			return parseLoop(obj, ctx)
 		case STR.recur:
 			return parseRecur(obj, ctx)
@@ -1619,7 +2148,7 @@ func parseList(obj Object, ctx *ParseContext) Expr {
 					symNs := ctx.GlobalEnv.NamespaceFor(ctx.GlobalEnv.CurrentNamespace(), sym)
 					if !ctx.isUnknownCallableScope {
 						if symNs == nil || symNs == ctx.GlobalEnv.CurrentNamespace() {
-							printParseError(GetPosition(obj), "Unable to resolve symbol: "+sym.ToString(false))
+							printParseError(GetPosition(obj), "unresolved-symbol", "Unable to resolve symbol: "+sym.ToString(false))
 						}
 					}
 					vr = InternFakeSymbol(symNs, sym)
@@ -1643,9 +2172,9 @@ func parseList(obj Object, ctx *ParseContext) Expr {
 			}
 			if LINTER_MODE {
 				if len(res.body) == 0 {
-					printParseWarning(pos, "do form with empty body")
+					printParseWarning(pos, "empty-body", "do form with empty body")
 				} else if len(res.body) == 1 {
-					printParseWarning(pos, "redundant do form")
+					printParseWarning(pos, "redundant-do", "redundant do form")
 				}
 			}
 			return res
@@ -1687,6 +2216,12 @@ func parseList(obj Object, ctx *ParseContext) Expr {
 	if LINTER_MODE {
 		switch c := res.callable.(type) {
 		case *VarRefExpr:
+			if WARNINGS.rePatternInLoop && ctx.loopDepth > 0 && isRePatternOfLiteral(c.vr, res.args) {
+				printParseWarning(pos, "re-pattern-in-loop", "re-pattern of a literal string in a loop; use a regex literal or move it out of the loop")
+			}
+			if WARNINGS.formatArgs {
+				checkFormatArgs(c.vr, res)
+			}
 			if c.vr.Value != nil {
 				switch f := c.vr.Value.(type) {
 				case *Fn:
@@ -1710,7 +2245,7 @@ func parseList(obj Object, ctx *ParseContext) Expr {
 						if ok, arglist := m.Get(KEYWORDS.arglist); ok {
 							if arglist, ok := arglist.(Seq); ok {
 								if !checkArglist(arglist, len(res.args)) {
-									printParseWarning(pos, fmt.Sprintf("Wrong number of args (%d) passed to %s", len(res.args), res.Name()))
+									printParseWarning(pos, "wrong-arity", fmt.Sprintf("Wrong number of args (%d) passed to %s", len(res.args), res.Name()))
 								}
 							}
 						}
@@ -1726,7 +2261,7 @@ func parseList(obj Object, ctx *ParseContext) Expr {
 			checkCall(res.callable, false, res, pos)
 		}
 	}
-	return res
+	return foldConstant(res)
 }
 
 func InternFakeSymbol(ns *Namespace, sym Symbol) *Var {
@@ -1773,6 +2308,42 @@ func MakeVarRefExpr(vr *Var, obj Object) *VarRefExpr {
 	}
 }
 
+// linterMeta returns the meta of vr as seen by the linter. Defs aren't
+// evaluated while linting, so for the vars they define that's the meta
+// parsed from the def.
+func linterMeta(vr *Var) Map {
+	if vr.lintMeta != nil {
+		return vr.lintMeta
+	}
+	return vr.GetMeta()
+}
+
+// warnIfDeprecated warns about the use of vr if its meta has a truthy
+// :deprecated (usually the version it was deprecated in), suggesting
+// its :superseded-by replacement if there's one.
+func warnIfDeprecated(vr *Var, pos Position) {
+	meta := linterMeta(vr)
+	if meta == nil {
+		return
+	}
+	ok, since := meta.Get(KEYWORDS.deprecated)
+	if !ok || !ToBool(since) {
+		return
+	}
+	msg := "use of deprecated var " + varCallableString(vr)
+	if s, ok := since.(String); ok {
+		msg += " (deprecated since " + s.S + ")"
+	}
+	if ok, by := meta.Get(KEYWORDS.supersededBy); ok && by != NIL {
+		// The meta of a def being linted hasn't been evaluated.
+		if s, ok := by.(Seq); ok && s.First().Equals(SYMBOLS.quote) {
+			by = Second(s)
+		}
+		msg += ", use " + by.ToString(false) + " instead"
+	}
+	printParseWarning(pos, "deprecated-var", msg)
+}
+
 func parseSymbol(obj Object, ctx *ParseContext) Expr {
 	sym := obj.(Symbol)
 	b := ctx.GetLocalBinding(sym)
@@ -1784,6 +2355,9 @@ func parseSymbol(obj Object, ctx *ParseContext) Expr {
 		}
 	}
 	if vr, ok := ctx.GlobalEnv.Resolve(sym); ok {
+		if LINTER_MODE && WARNINGS.deprecatedVar {
+			warnIfDeprecated(vr, GetPosition(obj))
+		}
 		return MakeVarRefExpr(vr, obj)
 	}
 	if sym.ns == nil && TYPES[sym.name] != nil {
@@ -1799,7 +2373,7 @@ func parseSymbol(obj Object, ctx *ParseContext) Expr {
 		// Check if this is a "callable namespace"
 		ns := ctx.GlobalEnv.FindNamespace(sym)
 		if ns == nil {
-			ns = ctx.GlobalEnv.CurrentNamespace().aliases[sym.name]
+			ns = ctx.GlobalEnv.CurrentNamespace().alias(sym.name)
 		}
 		if ns != nil {
 			ns.isUsed = true
@@ -1825,7 +2399,7 @@ func parseSymbol(obj Object, ctx *ParseContext) Expr {
 		}
 		if !ctx.isUnknownCallableScope {
 			if ctx.linterBindings.GetBinding(sym) == nil {
-				printParseError(GetPosition(obj), "Unable to resolve symbol: "+sym.ToString(false))
+				printParseError(GetPosition(obj), "unresolved-symbol", "Unable to resolve symbol: "+sym.ToString(false))
 			}
 		}
 	}
@@ -1841,13 +2415,13 @@ func Parse(obj Object, ctx *ParseContext) Expr {
 		res = NewLiteralExpr(obj)
 	case *Vector:
 		canHaveMeta = true
-		res = parseVector(v, pos, ctx)
+		res = foldConstant(parseVector(v, pos, ctx))
 	case Map:
 		canHaveMeta = true
-		res = parseMap(v, pos, ctx)
+		res = foldConstant(parseMap(v, pos, ctx))
 	case *MapSet:
 		canHaveMeta = true
-		res = parseSet(v, pos, ctx)
+		res = foldConstant(parseSet(v, pos, ctx))
 	case Seq:
 		res = parseList(obj, ctx)
 	case Symbol:
@@ -1869,9 +2443,11 @@ func Parse(obj Object, ctx *ParseContext) Expr {
 }
 
 func TryParse(obj Object, ctx *ParseContext) (expr Expr, err error) {
+	s := RT.SaveCallState()
 	defer func() {
 		if r := recover(); r != nil {
-			PROBLEM_COUNT++
+			countError()
+			r = RT.Recovered(r, s)
 			switch r.(type) {
 			case *ParseError:
 				err = r.(error)
@@ -1886,3 +2462,36 @@ func TryParse(obj Object, ctx *ParseContext) (expr Expr, err error) {
 	}()
 	return Parse(obj, ctx), nil
 }
+
+// ReadParse reads the next top-level form from reader and parses it,
+// recovering from a read or parse error once for both rather than once
+// per step as TryRead followed by TryParse does. obj is nil if the form
+// couldn't be read; err is io.EOF at the end of input.
+func ReadParse(reader *Reader, ctx *ParseContext) (obj Object, expr Expr, err error) {
+	s := RT.SaveCallState()
+	defer func() {
+		if r := recover(); r != nil {
+			countError()
+			r = RT.Recovered(r, s)
+			switch r.(type) {
+			case ReadError:
+				err = r.(error)
+			case *ParseError:
+				err = r.(error)
+			case *EvalError:
+				err = r.(error)
+			case *ExInfo:
+				err = r.(error)
+			default:
+				panic(r)
+			}
+		}
+	}()
+	if obj, err = readTopLevel(reader); err != nil {
+		return nil, nil, err
+	}
+	if LINTER_MODE {
+		readTrailingComment(reader)
+	}
+	return obj, Parse(obj, ctx), nil
+}
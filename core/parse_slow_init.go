@@ -12,6 +12,7 @@ var (
 		line:               MakeKeyword("line"),
 		column:             MakeKeyword("column"),
 		file:               MakeKeyword("file"),
+		source:             MakeKeyword("source"),
 		ns:                 MakeKeyword("ns"),
 		macro:              MakeKeyword("macro"),
 		message:            MakeKeyword("message"),
@@ -19,15 +20,30 @@ var (
 		data:               MakeKeyword("data"),
 		cause:              MakeKeyword("cause"),
 		arglist:            MakeKeyword("arglists"),
+		kwargs:             MakeKeyword("kwargs"),
 		doc:                MakeKeyword("doc"),
 		added:              MakeKeyword("added"),
 		meta:               MakeKeyword("meta"),
+		validator:          MakeKeyword("validator"),
 		knownMacros:        MakeKeyword("known-macros"),
 		rules:              MakeKeyword("rules"),
 		ifWithoutElse:      MakeKeyword("if-without-else"),
 		unusedFnParameters: MakeKeyword("unused-fn-parameters"),
 		fnWithEmptyBody:    MakeKeyword("fn-with-empty-body"),
+		rePatternInLoop:    MakeKeyword("re-pattern-in-loop"),
+		argTypeMismatch:    MakeKeyword("arg-type-mismatch"),
+		deprecatedVar:      MakeKeyword("deprecated-var"),
+		shadowedBinding:    MakeKeyword("shadowed-binding"),
+		unreachableCode:    MakeKeyword("unreachable-code"),
+		constantCondition:  MakeKeyword("constant-condition"),
+		lintCondition:      MakeKeyword("lint-condition"),
+		formatArgs:         MakeKeyword("format-args"),
+		noReturn:           MakeKeyword("no-return"),
+		deprecated:         MakeKeyword("deprecated"),
+		supersededBy:       MakeKeyword("superseded-by"),
 		_prefix:            MakeKeyword("_prefix"),
+		_rule:              MakeKeyword("_rule"),
+		severity:           MakeKeyword("severity"),
 		pos:                MakeKeyword("pos"),
 		startLine:          MakeKeyword("start-line"),
 		endLine:            MakeKeyword("end-line"),
@@ -54,6 +70,7 @@ var (
 		ascii:              MakeKeyword("ascii"),
 		unicode:            MakeKeyword("unicode"),
 		any:                MakeKeyword("any"),
+		default_:           MakeKeyword("default"),
 	}
 	SYMBOLS = Symbols{
 		joker_core:         MakeSymbol("joker.core"),
@@ -90,6 +107,7 @@ var (
 		hashMap:            MakeSymbol("hash-map"),
 		hashSet:            MakeSymbol("hash-set"),
 		defaultDataReaders: MakeSymbol("default-data-readers"),
+		dataReaders:        MakeSymbol("*data-readers*"),
 		backslash:          MakeSymbol("/"),
 		deref:              MakeSymbol("deref"),
 		ns:                 MakeSymbol("ns"),
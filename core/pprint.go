@@ -0,0 +1,288 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+type (
+	// PrettyPrinter holds the settings of a single pprint call.
+	PrettyPrinter struct {
+		// Margin is the column objects should not extend beyond.
+		// Objects that fit within it are printed on a single line.
+		Margin int
+		// Code enables formatting of lists as Joker code.
+		Code bool
+	}
+)
+
+// Number of arguments printed on the first line of special forms and macros
+// whose remaining arguments form a body indented by two spaces.
+var codeHeaderArgs = map[string]int{
+	"binding":     1,
+	"case":        1,
+	"catch":       2,
+	"cond":        0,
+	"cond->":      1,
+	"cond->>":     1,
+	"condp":       2,
+	"def":         1,
+	"defmacro":    1,
+	"defmethod":   2,
+	"defmulti":    1,
+	"defn":        1,
+	"defn-":       1,
+	"defonce":     1,
+	"defprotocol": 1,
+	"defrecord":   2,
+	"deftest":     1,
+	"deftype":     2,
+	"do":          0,
+	"doseq":       1,
+	"dotimes":     1,
+	"finally":     0,
+	"fn":          0,
+	"fn*":         0,
+	"for":         1,
+	"if":          1,
+	"if-let":      1,
+	"if-not":      1,
+	"if-some":     1,
+	"let":         1,
+	"letfn":       1,
+	"locking":     1,
+	"loop":        1,
+	"ns":          1,
+	"testing":     1,
+	"try":         0,
+	"when":        1,
+	"when-first":  1,
+	"when-let":    1,
+	"when-not":    1,
+	"when-some":   1,
+	"while":       1,
+	"with-open":   1,
+	"with-redefs": 1,
+}
+
+// Forms whose first argument is a vector of bindings.
+var codeBindingForms = map[string]bool{
+	"binding":     true,
+	"doseq":       true,
+	"dotimes":     true,
+	"for":         true,
+	"if-let":      true,
+	"if-some":     true,
+	"let":         true,
+	"letfn":       true,
+	"loop":        true,
+	"when-first":  true,
+	"when-let":    true,
+	"when-some":   true,
+	"with-open":   true,
+	"with-redefs": true,
+}
+
+// Forms whose body consists of test/expression pairs.
+var codeClauseForms = map[string]bool{
+	"case":    true,
+	"cond":    true,
+	"cond->":  true,
+	"cond->>": true,
+	"condp":   true,
+}
+
+// Forms that may have a name and an argument vector before their body.
+var codeFnForms = map[string]bool{
+	"defmacro": true,
+	"defn":     true,
+	"defn-":    true,
+	"fn":       true,
+	"fn*":      true,
+}
+
+func isCodeForm(seq Seq) bool {
+	if seq.IsEmpty() {
+		return false
+	}
+	_, ok := seq.First().(Symbol)
+	return ok
+}
+
+func isQuoteForm(items []Object) bool {
+	if len(items) != 2 {
+		return false
+	}
+	sym, ok := items[0].(Symbol)
+	return ok && sym.ns == nil && sym.Name() == "quote"
+}
+
+// flatString returns the single-line representation of obj.
+func (pp *PrettyPrinter) flatString(obj Object) string {
+	if !pp.Code {
+		return obj.ToString(true)
+	}
+	var b bytes.Buffer
+	writeFlat := func(open string, objs []Object, sep string, close string) string {
+		b.WriteString(open)
+		for i, o := range objs {
+			if i > 0 {
+				b.WriteString(sep)
+			}
+			b.WriteString(pp.flatString(o))
+		}
+		b.WriteString(close)
+		return b.String()
+	}
+	switch obj := obj.(type) {
+	case Seq:
+		items := ToSlice(obj)
+		if isQuoteForm(items) {
+			return "'" + pp.flatString(items[1])
+		}
+		return writeFlat("(", items, " ", ")")
+	case *Vector:
+		return writeFlat("[", ToSlice(obj.Seq()), " ", "]")
+	case *MapSet:
+		return writeFlat("#{", ToSlice(obj.m.Keys()), " ", "}")
+	case Map:
+		b.WriteRune('{')
+		if obj.Count() > 0 {
+			for iter := obj.Iter(); ; {
+				p := iter.Next()
+				b.WriteString(pp.flatString(p.Key))
+				b.WriteRune(' ')
+				b.WriteString(pp.flatString(p.Value))
+				if !iter.HasNext() {
+					break
+				}
+				b.WriteString(", ")
+			}
+		}
+		b.WriteRune('}')
+		return b.String()
+	default:
+		return obj.ToString(true)
+	}
+}
+
+func (pp *PrettyPrinter) newline(w io.Writer, indent int) {
+	fmt.Fprint(w, "\n")
+	writeIndent(w, indent)
+}
+
+// pprintBindings prints a binding vector with one name/value pair per line.
+func (pp *PrettyPrinter) pprintBindings(v *Vector, w io.Writer, indent int) int {
+	s := pp.flatString(v)
+	if end := indent + len([]rune(s)); end <= pp.Margin {
+		fmt.Fprint(w, s)
+		return end
+	}
+	fmt.Fprint(w, "[")
+	i := indent + 1
+	for j := 0; j < v.count; j += 2 {
+		if j > 0 {
+			pp.newline(w, indent+1)
+		}
+		i = pprintObject(pp, v.at(j), indent+1, w)
+		if j+1 < v.count {
+			fmt.Fprint(w, " ")
+			i = pprintObject(pp, v.at(j+1), i+1, w)
+		}
+	}
+	fmt.Fprint(w, "]")
+	return i + 1
+}
+
+// pprintCode prints a list whose first element is a symbol as code.
+// Special forms and macros with a body get the body indented by two spaces,
+// while the arguments of function calls are aligned with the first one.
+func pprintCode(pp *PrettyPrinter, seq Seq, w io.Writer, indent int) int {
+	items := ToSlice(seq)
+	if isQuoteForm(items) {
+		fmt.Fprint(w, "'")
+		return pprintObject(pp, items[1], indent+1, w)
+	}
+	name := items[0].(Symbol).Name()
+	head := items[0].ToString(true)
+	fmt.Fprint(w, "(", head)
+	i := indent + 1 + len([]rune(head))
+	headerArgs, isBodyForm := codeHeaderArgs[name]
+	if !isBodyForm {
+		argIndent := i + 1
+		for j, item := range items[1:] {
+			if j > 0 {
+				pp.newline(w, argIndent)
+			} else {
+				fmt.Fprint(w, " ")
+			}
+			i = pprintObject(pp, item, argIndent, w)
+		}
+		fmt.Fprint(w, ")")
+		return i + 1
+	}
+	headerEnd := 1 + headerArgs
+	if codeFnForms[name] {
+		headerEnd = 1
+		if headerEnd < len(items) {
+			if _, ok := items[headerEnd].(Symbol); ok {
+				headerEnd++
+			}
+		}
+		if headerEnd < len(items) {
+			if _, ok := items[headerEnd].(*Vector); ok {
+				headerEnd++
+			}
+		}
+	}
+	j := 1
+	for ; j < headerEnd && j < len(items); j++ {
+		fmt.Fprint(w, " ")
+		if v, ok := items[j].(*Vector); ok && j == 1 && codeBindingForms[name] {
+			i = pp.pprintBindings(v, w, i+1)
+		} else {
+			i = pprintObject(pp, items[j], i+1, w)
+		}
+	}
+	for ; j < len(items); j++ {
+		pp.newline(w, indent+2)
+		i = pprintObject(pp, items[j], indent+2, w)
+		if codeClauseForms[name] && j+1 < len(items) {
+			fmt.Fprint(w, " ")
+			i = pprintObject(pp, items[j+1], i+1, w)
+			j++
+		}
+	}
+	fmt.Fprint(w, ")")
+	return i + 1
+}
+
+// pprintElements prints the elements of a collection starting at column indent
+// and returns the column after the last one. Elements are printed one per line,
+// unless none of them is a collection, in which case each line is filled
+// with as many elements as fit within the margin.
+func pprintElements(pp *PrettyPrinter, objs []Object, w io.Writer, indent int) int {
+	fill := true
+	for _, obj := range objs {
+		if _, ok := obj.(Pprinter); ok {
+			fill = false
+			break
+		}
+	}
+	i := indent
+	for j, obj := range objs {
+		if j > 0 {
+			if fill && i+1+utf8.RuneCountInString(obj.ToString(true)) < pp.Margin {
+				fmt.Fprint(w, " ")
+				i++
+			} else {
+				pp.newline(w, indent)
+				i = indent
+			}
+		}
+		i = pprintObject(pp, obj, i, w)
+	}
+	return i
+}
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"fmt"
+	"io"
+)
+
+// printOptions holds the values of the printer control vars
+// (*print-readably*, *print-length* etc.) for one pr/print call.
+type printOptions struct {
+	readably bool
+	meta     bool
+	length   int // -1 means no limit
+	level    int // -1 means no limit
+}
+
+func printLimit(vr *Var) int {
+	if vr.Resolve().Equals(NIL) {
+		return -1
+	}
+	return EnsureObjectIsInt(vr.Resolve(), "*print-length* and *print-level* must be nil or Int, got %s").I
+}
+
+func currentPrintOptions() *printOptions {
+	dup := ToBool(GLOBAL_ENV.printDup.Resolve())
+	opts := &printOptions{
+		readably: dup || ToBool(GLOBAL_ENV.printReadably.Resolve()),
+		meta:     dup || ToBool(GLOBAL_ENV.printMeta.Resolve()),
+		length:   printLimit(GLOBAL_ENV.printLength),
+		level:    printLimit(GLOBAL_ENV.printLevel),
+	}
+	opts.meta = opts.meta && opts.readably
+	return opts
+}
+
+func (opts *printOptions) isLimited() bool {
+	return opts.meta || opts.length >= 0 || opts.level >= 0
+}
+
+// printObject prints obj to w without regard to the printer control
+// vars other than *print-readably*.
+func printObject(obj Object, w io.Writer, printReadably bool) {
+	switch obj := obj.(type) {
+	case Printer:
+		obj.Print(w, printReadably)
+	default:
+		fmt.Fprint(w, obj.ToString(printReadably))
+	}
+}
+
+func (opts *printOptions) printMeta(obj Object, w io.Writer, depth int) {
+	if !opts.meta {
+		return
+	}
+	switch obj.(type) {
+	case *Vector, Map, *MapSet, Seq, Symbol:
+		if m, ok := obj.(Meta); ok {
+			if meta := m.GetMeta(); meta != nil && meta.Count() > 0 {
+				io.WriteString(w, "^")
+				opts.print(meta, w, depth)
+				io.WriteString(w, " ")
+			}
+		}
+	}
+}
+
+// printElements prints the objects from iter separated by sep, stopping
+// after *print-length* of them.
+func (opts *printOptions) printElements(iter Iterator, w io.Writer, depth int, sep string) {
+	for i := 0; iter.HasNext(); i++ {
+		if i > 0 {
+			io.WriteString(w, sep)
+		}
+		if i == opts.length {
+			io.WriteString(w, "...")
+			return
+		}
+		opts.print(iter.Next(), w, depth)
+	}
+}
+
+func (opts *printOptions) print(obj Object, w io.Writer, depth int) {
+	switch obj.(type) {
+	case *Vector, Map, *MapSet, Seq, *Atom:
+	default:
+		opts.printMeta(obj, w, depth)
+		printObject(obj, w, opts.readably)
+		return
+	}
+	if opts.level >= 0 && depth >= opts.level {
+		io.WriteString(w, "#")
+		return
+	}
+	opts.printMeta(obj, w, depth)
+	switch obj := obj.(type) {
+	case *Vector:
+		io.WriteString(w, "[")
+		opts.printElements(obj.Iter(), w, depth+1, " ")
+		io.WriteString(w, "]")
+	case Map:
+		io.WriteString(w, "{")
+		iter := obj.Iter()
+		for i := 0; iter.HasNext(); i++ {
+			if i > 0 {
+				io.WriteString(w, ", ")
+			}
+			if i == opts.length {
+				io.WriteString(w, "...")
+				break
+			}
+			p := iter.Next()
+			opts.print(p.Key, w, depth+1)
+			io.WriteString(w, " ")
+			opts.print(p.Value, w, depth+1)
+		}
+		io.WriteString(w, "}")
+	case *MapSet:
+		io.WriteString(w, "#{")
+		opts.printElements(iterOf(obj), w, depth+1, " ")
+		io.WriteString(w, "}")
+	case Seq:
+		io.WriteString(w, "(")
+		opts.printElements(iterOf(obj), w, depth+1, " ")
+		io.WriteString(w, ")")
+	case *Atom:
+		io.WriteString(w, "#object[Atom {:val ")
+		opts.print(obj.value, w, depth+1)
+		io.WriteString(w, "}]")
+	}
+}
@@ -203,7 +203,7 @@ var procAdd = func(args []Object) Object {
 var procAddEx = func(args []Object) Object {
 	x := EnsureObjectIsNumber(args[0], "")
 	y := EnsureObjectIsNumber(args[1], "")
-	ops := GetOps(x).Combine(GetOps(y)).Combine(BIGINT_OPS)
+	ops := Promoting(GetOps(x).Combine(GetOps(y)))
 	return ops.Add(x, y)
 }
 
@@ -217,7 +217,7 @@ var procMultiply = func(args []Object) Object {
 var procMultiplyEx = func(args []Object) Object {
 	x := EnsureObjectIsNumber(args[0], "")
 	y := EnsureObjectIsNumber(args[1], "")
-	ops := GetOps(x).Combine(GetOps(y)).Combine(BIGINT_OPS)
+	ops := Promoting(GetOps(x).Combine(GetOps(y)))
 	return ops.Multiply(x, y)
 }
 
@@ -243,7 +243,7 @@ var procSubtractEx = func(args []Object) Object {
 		a = args[0]
 		b = args[1]
 	}
-	ops := GetOps(a).Combine(GetOps(b)).Combine(BIGINT_OPS)
+	ops := Promoting(GetOps(a).Combine(GetOps(b)))
 	return ops.Subtract(EnsureObjectIsNumber(a, ""), EnsureObjectIsNumber(b, ""))
 }
 
@@ -366,7 +366,7 @@ var procExMessage = func(args []Object) Object {
 }
 
 var procRegex = func(args []Object) Object {
-	r, err := regexp.Compile(EnsureArgIsString(args, 0).S)
+	r, err := regexes.compile(EnsureArgIsString(args, 0).S)
 	if err != nil {
 		panic(RT.NewError("Invalid regex: " + err.Error()))
 	}
@@ -416,6 +416,129 @@ var procReFind = func(args []Object) Object {
 	return reGroups(s.S, match)
 }
 
+func reNamedGroups(re *regexp.Regexp, s string, indexes []int) Object {
+	if indexes == nil {
+		return NIL
+	}
+	res := EmptyArrayMap()
+	for i, name := range re.SubexpNames() {
+		if name == "" {
+			continue
+		}
+		if indexes[2*i] == -1 {
+			res.Add(MakeKeyword(name), NIL)
+		} else {
+			res.Add(MakeKeyword(name), String{S: s[indexes[2*i]:indexes[2*i+1]]})
+		}
+	}
+	return res
+}
+
+var procReFindNamed = func(args []Object) Object {
+	re := EnsureArgIsRegex(args, 0)
+	s := EnsureArgIsString(args, 1)
+	return reNamedGroups(re.R, s.S, re.R.FindStringSubmatchIndex(s.S))
+}
+
+var procReMatchesNamed = func(args []Object) Object {
+	re := EnsureArgIsRegex(args, 0)
+	s := EnsureArgIsString(args, 1)
+	match := re.R.FindStringSubmatchIndex(s.S)
+	if match == nil || match[0] != 0 || match[1] != len(s.S) {
+		return NIL
+	}
+	return reNamedGroups(re.R, s.S, match)
+}
+
+// reScanner finds successive matches of a regex in a reader, keeping
+// at most 2*lookahead bytes in memory. A match is only accepted when at least
+// lookahead bytes follow its start (or the input is exhausted), so matches
+// are exact as long as they are no longer than lookahead.
+type reScanner struct {
+	re         *regexp.Regexp
+	r          io.Reader
+	data       []byte
+	start, end int
+	lookahead  int
+	eof, done  bool
+	afterMatch bool // the previous match was non-empty and ended at start
+}
+
+func (s *reScanner) fill(force bool) {
+	if s.eof || (!force && s.end-s.start >= s.lookahead) {
+		return
+	}
+	s.end = copy(s.data, s.data[s.start:s.end])
+	s.start = 0
+	for !s.eof && s.end < len(s.data) {
+		n, err := s.r.Read(s.data[s.end:])
+		s.end += n
+		if err == io.EOF {
+			s.eof = true
+		} else if err != nil {
+			panic(RT.NewError(err.Error()))
+		}
+	}
+}
+
+func (s *reScanner) next() Object {
+	force := false
+	for !s.done {
+		s.fill(force)
+		force = false
+		buf := s.data[s.start:s.end]
+		limit := len(buf) - s.lookahead
+		loc := s.re.FindSubmatchIndex(buf)
+		if loc == nil || (!s.eof && loc[0] >= limit) {
+			if s.eof {
+				s.done = true
+				break
+			}
+			if limit > 0 {
+				s.start += limit
+				s.afterMatch = false
+			}
+			force = true
+			continue
+		}
+		if loc[0] == loc[1] && loc[0] == 0 && s.afterMatch {
+			// Like re-seq, ignore empty matches abutting the previous match.
+			s.afterMatch = false
+			if len(buf) == 0 {
+				s.done = true
+				break
+			}
+			_, size := utf8.DecodeRune(buf)
+			s.start += size
+			continue
+		}
+		res := reGroups(string(buf[:loc[1]]), loc)
+		s.start += loc[1]
+		s.afterMatch = loc[1] > loc[0]
+		if loc[0] == loc[1] {
+			if loc[1] == len(buf) {
+				s.done = true
+			} else {
+				_, size := utf8.DecodeRune(buf[loc[1]:])
+				s.start += size
+			}
+		}
+		return res
+	}
+	return NIL
+}
+
+var procReScanner = func(args []Object) Object {
+	re := EnsureArgIsRegex(args, 0)
+	rdr := EnsureArgIsio_Reader(args, 1)
+	lookahead := EnsureArgIsInt(args, 2).I
+	if lookahead < 1 {
+		panic(RT.NewError("lookahead must be positive"))
+	}
+	s := &reScanner{re: re.R, r: rdr, data: make([]byte, 2*lookahead), lookahead: lookahead}
+	return Proc{Fn: func(args []Object) Object { return s.next() }, Name: "re-scanner"}
+}
+
 var procRand = func(args []Object) Object {
 	r := rand.Float64()
 	return Double{D: r}
@@ -455,6 +578,7 @@ var procIntern = func(args []Object) Object {
 var procSetMeta = func(args []Object) Object {
 	vr := EnsureArgIsVar(args, 0)
 	meta := EnsureArgIsMap(args, 1)
+	vr.packedMeta = nil
 	vr.meta = meta
 	return NIL
 }
@@ -468,6 +592,10 @@ var procAtom = func(args []Object) Object {
 		if ok, v := m.Get(KEYWORDS.meta); ok {
 			res.meta = EnsureObjectIsMap(v, "")
 		}
+		if ok, v := m.Get(KEYWORDS.validator); ok && !v.Equals(NIL) {
+			res.validator = EnsureObjectIsCallable(v, "")
+			res.validate(res.value)
+		}
 	}
 	return res
 }
@@ -480,7 +608,7 @@ var procSwap = func(args []Object) Object {
 	a := EnsureArgIsAtom(args, 0)
 	f := EnsureArgIsCallable(args, 1)
 	fargs := append([]Object{a.value}, args[2:]...)
-	a.value = f.Call(fargs)
+	a.set(f.Call(fargs))
 	return a.value
 }
 
@@ -488,24 +616,71 @@ var procSwapVals = func(args []Object) Object {
 	a := EnsureArgIsAtom(args, 0)
 	f := EnsureArgIsCallable(args, 1)
 	fargs := append([]Object{a.value}, args[2:]...)
-	oldValue := a.value
-	a.value = f.Call(fargs)
+	oldValue := a.set(f.Call(fargs))
 	return NewVectorFrom(oldValue, a.value)
 }
 
 var procReset = func(args []Object) Object {
 	a := EnsureArgIsAtom(args, 0)
-	a.value = args[1]
+	a.set(args[1])
 	return a.value
 }
 
 var procResetVals = func(args []Object) Object {
 	a := EnsureArgIsAtom(args, 0)
-	oldValue := a.value
-	a.value = args[1]
+	oldValue := a.set(args[1])
 	return NewVectorFrom(oldValue, a.value)
 }
 
+var procCompareAndSet = func(args []Object) Object {
+	a := EnsureArgIsAtom(args, 0)
+	if a.value != args[1] {
+		return Boolean{B: false}
+	}
+	a.set(args[2])
+	return Boolean{B: true}
+}
+
+var procSetValidator = func(args []Object) Object {
+	a := EnsureArgIsAtom(args, 0)
+	if args[1].Equals(NIL) {
+		a.validator = nil
+		return NIL
+	}
+	validator := EnsureArgIsCallable(args, 1)
+	if !ToBool(validator.Call([]Object{a.value})) {
+		panic(RT.NewError("Invalid reference state"))
+	}
+	a.validator = validator
+	return NIL
+}
+
+var procGetValidator = func(args []Object) Object {
+	a := EnsureArgIsAtom(args, 0)
+	if a.validator == nil {
+		return NIL
+	}
+	return a.validator.(Object)
+}
+
+var procAddWatch = func(args []Object) Object {
+	a := EnsureArgIsAtom(args, 0)
+	EnsureArgIsCallable(args, 2)
+	if a.watches == nil {
+		a.watches = EmptyArrayMap()
+	}
+	a.watches = a.watches.Assoc(args[1], args[2]).(Map)
+	return a
+}
+
+var procRemoveWatch = func(args []Object) Object {
+	a := EnsureArgIsAtom(args, 0)
+	if a.watches != nil {
+		a.watches = a.watches.Without(args[1])
+	}
+	return a
+}
+
 var procAlterMeta = func(args []Object) Object {
 	r := EnsureArgIsRef(args, 0)
 	f := EnsureArgIsFn(args, 1)
@@ -529,7 +704,7 @@ var procEmpty = func(args []Object) Object {
 
 var procIsBound = func(args []Object) Object {
 	vr := EnsureArgIsVar(args, 0)
-	return Boolean{B: vr.Value != nil}
+	return Boolean{B: vr.Value != nil || RT.bindings.lookup(vr) != nil}
 }
 
 // Convert Joker object to native Go object. For those satisfying the
@@ -859,6 +1034,26 @@ var procDenominator = func(args []Object) Object {
 	return &BigInt{b: bi}
 }
 
+var procRationalize = func(args []Object) Object {
+	CheckArity(args, 1, 1)
+	var s string
+	switch n := EnsureArgIsNumber(args, 0).(type) {
+	case Double:
+		// The shortest decimal representation, so that 0.1 becomes 1/10
+		// rather than the exact value of the nearest float64.
+		s = strconv.FormatFloat(n.D, 'g', -1, 64)
+	case *BigFloat:
+		s = n.b.Text('g', -1)
+	default:
+		return n
+	}
+	r, ok := (&big.Rat{}).SetString(s)
+	if !ok {
+		panic(RT.NewError("Cannot rationalize " + args[0].ToString(false)))
+	}
+	return ratioOrInt(r)
+}
+
 var procBigInt = func(args []Object) Object {
 	switch n := args[0].(type) {
 	case Number:
@@ -889,6 +1084,27 @@ var procBigFloat = func(args []Object) Object {
 	}
 }
 
+var procParseLong = func(args []Object) Object {
+	if i, ok := ParseIntStrict(EnsureArgIsString(args, 0).S, EnsureArgIsInt(args, 1).I); ok {
+		return MakeInt(i)
+	}
+	return NIL
+}
+
+var procParseBigInt = func(args []Object) Object {
+	if b := ParseBigIntStrict(EnsureArgIsString(args, 0).S, EnsureArgIsInt(args, 1).I); b != nil {
+		return &BigInt{b: b}
+	}
+	return NIL
+}
+
+var procParseDouble = func(args []Object) Object {
+	if d, ok := ParseDoubleStrict(EnsureArgIsString(args, 0).S); ok {
+		return Double{D: d}
+	}
+	return NIL
+}
+
 var procNth = func(args []Object) Object {
 	n := EnsureArgIsNumber(args, 1).Int().I
 	switch coll := args[0].(type) {
@@ -955,13 +1171,13 @@ var procMin = func(args []Object) Object {
 
 var procIncEx = func(args []Object) Object {
 	x := EnsureArgIsNumber(args, 0)
-	ops := GetOps(x).Combine(BIGINT_OPS)
+	ops := Promoting(GetOps(x).Combine(INT_OPS))
 	return ops.Add(x, Int{I: 1})
 }
 
 var procDecEx = func(args []Object) Object {
 	x := EnsureArgIsNumber(args, 0)
-	ops := GetOps(x).Combine(BIGINT_OPS)
+	ops := Promoting(GetOps(x).Combine(INT_OPS))
 	return ops.Subtract(x, Int{I: 1})
 }
 
@@ -1087,26 +1303,29 @@ var procType = func(args []Object) Object {
 
 var procPprint = func(args []Object) Object {
 	obj := args[0]
-	w := EnsureObjectIsio_Writer(GLOBAL_ENV.stdout.Value, "")
-	pprintObject(obj, 0, w)
+	pp := &PrettyPrinter{
+		Margin: ExtractInt(args, 1),
+		Code:   ToBool(args[2]),
+	}
+	w := EnsureObjectIsio_Writer(GLOBAL_ENV.stdout.Resolve(), "")
+	pprintObject(pp, obj, 0, w)
 	fmt.Fprint(w, "\n")
 	return NIL
 }
 
 func PrintObject(obj Object, w io.Writer) {
-	printReadably := ToBool(GLOBAL_ENV.printReadably.Value)
-	switch obj := obj.(type) {
-	case Printer:
-		obj.Print(w, printReadably)
-	default:
-		fmt.Fprint(w, obj.ToString(printReadably))
+	opts := currentPrintOptions()
+	if opts.isLimited() {
+		opts.print(obj, w, 0)
+		return
 	}
+	printObject(obj, w, opts.readably)
 }
 
 var procPr = func(args []Object) Object {
 	n := len(args)
 	if n > 0 {
-		f := EnsureObjectIsio_Writer(GLOBAL_ENV.stdout.Value, "")
+		f := EnsureObjectIsio_Writer(GLOBAL_ENV.stdout.Resolve(), "")
 		for _, arg := range args[:n-1] {
 			PrintObject(arg, f)
 			fmt.Fprint(f, " ")
@@ -1117,7 +1336,7 @@ var procPr = func(args []Object) Object {
 }
 
 var procNewline = func(args []Object) Object {
-	f := EnsureObjectIsio_Writer(GLOBAL_ENV.stdout.Value, "")
+	f := EnsureObjectIsio_Writer(GLOBAL_ENV.stdout.Resolve(), "")
 	fmt.Fprintln(f)
 	return NIL
 }
@@ -1174,7 +1393,7 @@ func readLine(r StringReader) (s string, e error) {
 
 var procReadLine = func(args []Object) Object {
 	CheckArity(args, 0, 0)
-	f := EnsureObjectIsStringReader(GLOBAL_ENV.stdin.Value, "")
+	f := EnsureObjectIsStringReader(GLOBAL_ENV.stdin.Resolve(), "")
 	line, err := readLine(f)
 	if err != nil {
 		return NIL
@@ -1210,17 +1429,13 @@ func loadReader(reader *Reader) (Object, error) {
 	parseContext := &ParseContext{GlobalEnv: GLOBAL_ENV}
 	var lastObj Object = NIL
 	for {
-		obj, err := TryRead(reader)
+		_, expr, err := ReadParse(reader, parseContext)
 		if err == io.EOF {
 			return lastObj, nil
 		}
 		if err != nil {
 			return nil, err
 		}
-		expr, err := TryParse(obj, parseContext)
-		if err != nil {
-			return nil, err
-		}
 		lastObj, err = TryEval(expr)
 		if err != nil {
 			return nil, err
@@ -1281,8 +1496,9 @@ var procRemoveNamespace = func(args []Object) Object {
 }
 
 var procAllNamespaces = func(args []Object) Object {
-	s := make([]Object, 0, len(GLOBAL_ENV.Namespaces))
-	for _, ns := range GLOBAL_ENV.Namespaces {
+	namespaces := GLOBAL_ENV.AllNamespaces()
+	s := make([]Object, 0, len(namespaces))
+	for _, ns := range namespaces {
 		s = append(s, ns)
 	}
 	return &ArraySeq{arr: s}
@@ -1294,7 +1510,7 @@ var procNamespaceName = func(args []Object) Object {
 
 var procNamespaceMap = func(args []Object) Object {
 	r := &ArrayMap{}
-	for k, v := range EnsureArgIsNamespace(args, 0).mappings {
+	for k, v := range EnsureArgIsNamespace(args, 0).Mappings() {
 		r.Add(MakeSymbol(*k), v)
 	}
 	return r
@@ -1306,7 +1522,7 @@ var procNamespaceUnmap = func(args []Object) Object {
 	if sym.ns != nil {
 		panic(RT.NewError("Can't unintern namespace-qualified symbol"))
 	}
-	delete(ns.mappings, sym.name)
+	ns.unmap(sym.name)
 	return NIL
 }
 
@@ -1329,7 +1545,7 @@ var procAlias = func(args []Object) Object {
 
 var procNamespaceAliases = func(args []Object) Object {
 	r := &ArrayMap{}
-	for k, v := range EnsureArgIsNamespace(args, 0).aliases {
+	for k, v := range EnsureArgIsNamespace(args, 0).Aliases() {
 		r.Add(MakeSymbol(*k), v)
 	}
 	return r
@@ -1341,7 +1557,7 @@ var procNamespaceUnalias = func(args []Object) Object {
 	if sym.ns != nil {
 		panic(RT.NewError("Alias can't be namespace-qualified"))
 	}
-	delete(ns.aliases, sym.name)
+	ns.unalias(sym.name)
 	return NIL
 }
 
@@ -1350,10 +1566,24 @@ var procVarGet = func(args []Object) Object {
 }
 
 var procVarSet = func(args []Object) Object {
-	EnsureArgIsVar(args, 0).Value = args[1]
+	EnsureArgIsVar(args, 0).setValue(args[1])
 	return args[1]
 }
 
+// procAlterVarRoot sets the root binding of a var to the result of
+// calling f on its current root value and any further args, regardless
+// of the bindings of the var in the current goroutine.
+var procAlterVarRoot = func(args []Object) Object {
+	vr := EnsureArgIsVar(args, 0)
+	f := EnsureArgIsCallable(args, 1)
+	var root Object = NIL
+	if vr.Value != nil {
+		root = vr.Value
+	}
+	vr.Value = f.Call(append([]Object{root}, args[2:]...))
+	return vr.Value
+}
+
 var procNsResolve = func(args []Object) Object {
 	ns := EnsureArgIsNamespace(args, 0)
 	sym := EnsureArgIsSymbol(args, 1)
@@ -1482,10 +1712,24 @@ var procLoadFile = func(args []Object) Object {
 	return loadFile(filename.S)
 }
 
+// libFilename returns the name of the source file of the lib at base (the
+// path of the file without an extension): base.joke, or base.cljc if only
+// that exists, so libs can be shared with Clojure and ClojureScript using
+// reader conditionals.
+func libFilename(base string) string {
+	filename := base + ".joke"
+	if _, err := os.Stat(filename); err != nil {
+		if _, err := os.Stat(base + ".cljc"); err == nil {
+			return base + ".cljc"
+		}
+	}
+	return filename
+}
+
 var procLoadLibFromPath = func(args []Object) Object {
 	libname := EnsureArgIsSymbol(args, 0).Name()
 	pathname := EnsureArgIsString(args, 1).S
-	cp := GLOBAL_ENV.classPath.Value
+	cp := GLOBAL_ENV.classPath.Resolve()
 	cpvec := EnsureObjectIsVector(cp, "*classpath*: %s")
 	count := cpvec.Count()
 	var f *os.File
@@ -1499,7 +1743,7 @@ var procLoadLibFromPath = func(args []Object) Object {
 		if s == "" {
 			filename = pathname
 		} else {
-			filename = filepath.Join(s, filepath.Join(strings.Split(libname, ".")...)) + ".joke" // could cache inner join....
+			filename = libFilename(filepath.Join(s, filepath.Join(strings.Split(libname, ".")...))) // could cache inner join....
 		}
 		f, err = os.Open(filename)
 		if err == nil {
@@ -1512,8 +1756,10 @@ var procLoadLibFromPath = func(args []Object) Object {
 	}
 	PanicOnErr(canonicalErr)
 	PanicOnErr(err)
-	reader := NewReader(bufio.NewReader(f), filename)
-	ProcessReaderFromEval(reader, filename)
+	src, err := ioutil.ReadAll(f)
+	f.Close()
+	PanicOnErr(err)
+	loadLib(src, filename)
 	return NIL
 }
 
@@ -1524,6 +1770,65 @@ var procReduceKv = func(args []Object) Object {
 	return coll.kvreduce(f, init)
 }
 
+var procReduce = func(args []Object) Object {
+	f := EnsureArgIsCallable(args, 0)
+	res := args[1]
+	for it := iterOf(EnsureArgIsSeqable(args, 2)); it.HasNext(); {
+		res = f.Call([]Object{res, it.Next()})
+		if r, ok := res.(*Reduced); ok {
+			return r.value
+		}
+	}
+	return res
+}
+
+var procReduced = func(args []Object) Object {
+	return &Reduced{value: args[0]}
+}
+
+var procIsReduced = func(args []Object) Object {
+	_, ok := args[0].(*Reduced)
+	return Boolean{B: ok}
+}
+
+// lazyMap returns a lazy seq of the results of calling f on the
+// elements of coll, walking coll with an iterator created on first use.
+func lazyMap(f Callable, coll Seqable) *LazySeq {
+	var it Iterator
+	var step func() *LazySeq
+	step = func() *LazySeq {
+		var x Object
+		return NewLazySeq(Proc{Fn: func(args []Object) Object {
+			if x == nil {
+				if it == nil {
+					it = iterOf(coll)
+					coll = nil
+				}
+				if !it.HasNext() {
+					return EmptyList
+				}
+				x = it.Next()
+			}
+			return NewConsSeq(f.Call([]Object{x}), step())
+		}, Name: "map"})
+	}
+	return step()
+}
+
+var procMap = func(args []Object) Object {
+	return lazyMap(EnsureArgIsCallable(args, 0), EnsureArgIsSeqable(args, 1))
+}
+
+var procRange = func(args []Object) Object {
+	start := EnsureArgIsInt(args, 0).I
+	end := EnsureArgIsInt(args, 1).I
+	step := EnsureArgIsInt(args, 2).I
+	if step == 0 {
+		panic(RT.NewError("range step must not be zero"))
+	}
+	return NewRange(start, end, step)
+}
+
 var procIndexOf = func(args []Object) Object {
 	s := EnsureArgIsString(args, 0)
 	ch := EnsureArgIsChar(args, 1)
@@ -1568,12 +1873,12 @@ var procLibPath = func(args []Object) Object {
 
 	if !ok {
 		var file string
-		if GLOBAL_ENV.file.Value == nil {
+		if f := GLOBAL_ENV.file.Resolve(); f.Equals(NIL) {
 			var err error
 			file, err = filepath.Abs("user")
 			PanicOnErr(err)
 		} else {
-			file = EnsureObjectIsString(GLOBAL_ENV.file.Value, "").S
+			file = EnsureObjectIsString(f, "").S
 			if linkDest, err := os.Readlink(file); err == nil {
 				file = linkDest
 			}
@@ -1588,7 +1893,7 @@ var procLibPath = func(args []Object) Object {
 			}
 			file = file[:len(file)-1]
 		}
-		path = filepath.Join(append([]string{file}, strings.Split(sym.Name(), ".")...)...) + ".joke"
+		path = libFilename(filepath.Join(append([]string{file}, strings.Split(sym.Name(), ".")...)...))
 	}
 	return String{S: path}
 }
@@ -1603,6 +1908,8 @@ var procInternFakeVar = func(args []Object) Object {
 }
 
 var procParse = func(args []Object) Object {
+	CheckArity(args, 1, 2)
+	pos := len(args) == 2 && ToBool(args[1])
 	lm, _ := GLOBAL_ENV.Resolve(MakeSymbol("joker.core/*linter-mode*"))
 	lm.Value = Boolean{B: true}
 	LINTER_MODE = true
@@ -1612,14 +1919,14 @@ var procParse = func(args []Object) Object {
 	}()
 	parseContext := &ParseContext{GlobalEnv: GLOBAL_ENV}
 	res := Parse(args[0], parseContext)
-	return res.Dump(false)
+	return res.Dump(pos)
 }
 
 var procTypes = func(args []Object) Object {
 	CheckArity(args, 0, 0)
-	res := EmptyArrayMap()
+	var res Associative = EmptyArrayMap()
 	for k, v := range TYPES {
-		res.Add(String{S: *k}, v)
+		res = res.Assoc(String{S: *k}, v)
 	}
 	return res
 }
@@ -1648,24 +1955,25 @@ var procSend = func(args []Object) (obj Object) {
 		return MakeBoolean(false)
 	}
 	obj = MakeBoolean(true)
+	var gs *GoroutineState
 	defer func() {
 		if r := recover(); r != nil {
-			RT.GIL.Lock()
+			RT.GIL.Lock(gs)
 			obj = MakeBoolean(false)
 		}
 	}()
-	RT.GIL.Unlock()
+	gs = RT.GIL.Unlock()
 	ch.ch <- MakeFutureResult(v, nil)
-	RT.GIL.Lock()
+	RT.GIL.Lock(gs)
 	return
 }
 
 var procReceive = func(args []Object) Object {
 	CheckArity(args, 1, 1)
 	ch := EnsureArgIsChannel(args, 0)
-	RT.GIL.Unlock()
+	gs := RT.GIL.Unlock()
 	res, ok := <-ch.ch
-	RT.GIL.Lock()
+	RT.GIL.Lock(gs)
 	if !ok {
 		return NIL
 	}
@@ -1675,15 +1983,120 @@ var procReceive = func(args []Object) Object {
 	return res.value
 }
 
+// altsCase returns the select case for a port of alts!!, which is
+// either a channel to take from or a [channel value] vector to put to.
+func altsCase(port Object) (*Channel, reflect.SelectCase) {
+	switch port := port.(type) {
+	case *Channel:
+		return port, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(port.ch)}
+	case *Vector:
+		if port.Count() == 2 {
+			ch := EnsureObjectIsChannel(port.Nth(0), "alts!! port: %s")
+			v := port.Nth(1)
+			if v.Equals(NIL) {
+				panic(RT.NewError("Can't put nil on channel"))
+			}
+			return ch, reflect.SelectCase{Dir: reflect.SelectSend, Chan: reflect.ValueOf(ch.ch), Send: reflect.ValueOf(MakeFutureResult(v, nil))}
+		}
+	}
+	panic(RT.NewError("alts!! port must be a channel or a [channel value] vector, got " + port.ToString(true)))
+}
+
+func altsResult(chans []*Channel, cases []reflect.SelectCase, i int, v reflect.Value, ok bool) Object {
+	if cases[i].Dir == reflect.SelectSend {
+		return NewVectorFrom(Boolean{B: true}, chans[i])
+	}
+	if !ok {
+		return NewVectorFrom(NIL, chans[i])
+	}
+	res := v.Interface().(FutureResult)
+	if res.err != nil {
+		panic(res.err)
+	}
+	return NewVectorFrom(res.value, chans[i])
+}
+
+// procAlts takes the ports, whether to try them in order (rather than
+// at random) and, optionally, the value to return, with :default as
+// the port, when none of them is ready.
+var procAlts = func(args []Object) Object {
+	CheckArity(args, 2, 3)
+	ports := ToSlice(EnsureArgIsSeqable(args, 0).Seq())
+	priority := EnsureArgIsBoolean(args, 1).B
+	if len(ports) == 0 {
+		panic(RT.NewError("alts!! must have at least one port"))
+	}
+	chans := make([]*Channel, len(ports))
+	cases := make([]reflect.SelectCase, len(ports))
+	for i, port := range ports {
+		chans[i], cases[i] = altsCase(port)
+		if cases[i].Dir == reflect.SelectSend && chans[i].isClosed {
+			return NewVectorFrom(Boolean{B: false}, chans[i])
+		}
+	}
+	defaultCase := reflect.SelectCase{Dir: reflect.SelectDefault}
+	if priority {
+		for i := range cases {
+			if chosen, v, ok := reflect.Select([]reflect.SelectCase{cases[i], defaultCase}); chosen == 0 {
+				return altsResult(chans, cases, i, v, ok)
+			}
+		}
+	}
+	if len(args) == 3 {
+		chosen, v, ok := reflect.Select(append(cases, defaultCase))
+		if chosen == len(cases) {
+			return NewVectorFrom(args[2], KEYWORDS.default_)
+		}
+		return altsResult(chans, cases, chosen, v, ok)
+	}
+	gs := RT.GIL.Unlock()
+	chosen, v, ok := blockingSelect(cases)
+	RT.GIL.Lock(gs)
+	if chosen < 0 {
+		for i, ch := range chans {
+			if cases[i].Dir == reflect.SelectSend && ch.isClosed {
+				return NewVectorFrom(Boolean{B: false}, ch)
+			}
+		}
+		panic(RT.NewError("Send on closed channel"))
+	}
+	return altsResult(chans, cases, chosen, v, ok)
+}
+
+// blockingSelect is reflect.Select, except that it returns -1 as chosen
+// if a channel being put to gets closed meanwhile.
+func blockingSelect(cases []reflect.SelectCase) (chosen int, v reflect.Value, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			chosen = -1
+		}
+	}()
+	return reflect.Select(cases)
+}
+
+var procTimeout = func(args []Object) Object {
+	CheckArity(args, 1, 1)
+	d := time.Duration(EnsureArgIsInt(args, 0).I) * time.Millisecond
+	ch := MakeChannel(make(chan FutureResult))
+	time.AfterFunc(d, func() {
+		RT.GIL.Lock(nil)
+		ch.Close()
+		RT.GIL.Unlock()
+	})
+	return ch
+}
+
 var procGo = func(args []Object) Object {
 	CheckArity(args, 1, 1)
 	f := EnsureArgIsCallable(args, 0)
 	ch := MakeChannel(make(chan FutureResult, 1))
+	gs := RT.childState()
 	go func() {
-
+		RT.GIL.Lock(gs)
+		s := RT.SaveCallState()
 		defer func() {
 			if r := recover(); r != nil {
-				switch r := r.(type) {
+				switch r := RT.Recovered(r, s).(type) {
 				case Error:
 					ch.ch <- MakeFutureResult(NIL, r)
 					ch.Close()
@@ -1694,8 +2107,6 @@ var procGo = func(args []Object) Object {
 			}
 			RT.GIL.Unlock()
 		}()
-
-		RT.GIL.Lock()
 		res := f.Call([]Object{})
 		ch.ch <- MakeFutureResult(res, nil)
 		ch.Close()
@@ -1734,29 +2145,34 @@ func PackReader(reader *Reader, filename string) ([]byte, error) {
 			return append(hp, p...), nil
 		}
 		if err != nil {
-			fmt.Fprintln(Stderr, err)
+			PrintError(GLOBAL_ENV.Err(), err)
 			return nil, err
 		}
 		expr, err := TryParse(obj, parseContext)
 		if err != nil {
-			fmt.Fprintln(Stderr, err)
+			PrintError(GLOBAL_ENV.Err(), err)
 			return nil, err
 		}
 		p = expr.Pack(p, packEnv)
 		_, err = TryEval(expr)
 		if err != nil {
-			fmt.Fprintln(Stderr, err)
+			PrintError(GLOBAL_ENV.Err(), err)
 			return nil, err
 		}
 	}
 }
 
-var procIncProblemCount = func(args []Object) Object {
-	PROBLEM_COUNT++
+// procPrintLinterProblem reports a problem a macro found while linting,
+// given as an ExInfo, like those found by the parser.
+var procPrintLinterProblem = func(args []Object) Object {
+	CheckArity(args, 1, 1)
+	p := errorProblem(EnsureArgIsError(args, 0))
+	printError(p.pos, p.kind, p.ruleID(), p.msg)
 	return NIL
 }
 
 func ProcessReader(reader *Reader, filename string, phase Phase) error {
+	forgetSourceFile(reader.filename)
 	if phase == FORMAT {
 		FORMAT_MODE = true
 		HASHMAP_THRESHOLD = 100000
@@ -1773,15 +2189,25 @@ func ProcessReader(reader *Reader, filename string, phase Phase) error {
 	}
 	var prevObj Object
 	for {
-		obj, err := TryRead(reader)
+		if LINTER_MODE && ProblemLimitExceeded() {
+			return nil
+		}
+		var obj Object
+		var expr Expr
+		var err error
+		if phase == READ || phase == FORMAT {
+			obj, err = TryRead(reader)
+		} else {
+			obj, expr, err = ReadParse(reader, parseContext)
+		}
 		if err == io.EOF {
 			if FORMAT_MODE && prevObj != nil {
 				fmt.Fprint(Stdout, "\n")
 			}
 			return nil
 		}
-		if err != nil {
-			fmt.Fprintln(Stderr, err)
+		if err != nil && (obj == nil || phase == READ || phase == FORMAT) {
+			PrintError(GLOBAL_ENV.Err(), err)
 			return err
 		}
 		if phase == READ {
@@ -1801,9 +2227,8 @@ func ProcessReader(reader *Reader, filename string, phase Phase) error {
 			prevObj = obj
 			continue
 		}
-		expr, err := TryParse(obj, parseContext)
 		if err != nil {
-			fmt.Fprintln(Stderr, err)
+			PrintError(GLOBAL_ENV.Err(), err)
 		}
 		if phase == PARSE {
 			continue
@@ -1813,7 +2238,7 @@ func ProcessReader(reader *Reader, filename string, phase Phase) error {
 		}
 		obj, err = TryEval(expr)
 		if err != nil {
-			fmt.Fprintln(Stderr, err)
+			PrintError(GLOBAL_ENV.Err(), err)
 			return err
 		}
 		if phase == EVAL {
@@ -1826,6 +2251,7 @@ func ProcessReader(reader *Reader, filename string, phase Phase) error {
 }
 
 func ProcessReaderFromEval(reader *Reader, filename string) {
+	forgetSourceFile(reader.filename)
 	parseContext := &ParseContext{GlobalEnv: GLOBAL_ENV}
 	if filename != "" {
 		currentFilename := parseContext.GlobalEnv.file.Value
@@ -1837,14 +2263,12 @@ func ProcessReaderFromEval(reader *Reader, filename string) {
 		parseContext.GlobalEnv.SetFilename(MakeString(s))
 	}
 	for {
-		obj, err := TryRead(reader)
+		_, expr, err := ReadParse(reader, parseContext)
 		if err == io.EOF {
 			return
 		}
 		PanicOnErr(err)
-		expr, err := TryParse(obj, parseContext)
-		PanicOnErr(err)
-		obj, err = TryEval(expr)
+		_, err = TryEval(expr)
 		PanicOnErr(err)
 	}
 }
@@ -1888,8 +2312,8 @@ var procIsNamespaceInitialized = func(args []Object) Object {
 		panic(RT.NewError("Can't ask for namespace info on namespace-qualified symbol"))
 	}
 	// First look for registered (e.g. std) libs
-	ns, found := GLOBAL_ENV.Namespaces[sym.name]
-	return MakeBoolean(found && ns.Lazy == nil)
+	ns := GLOBAL_ENV.namespace(sym.name)
+	return MakeBoolean(ns != nil && ns.Lazy == nil)
 }
 
 func findConfigFile(filename string, workingDir string, findDir bool) string {
@@ -1971,6 +2395,7 @@ func ReadConfig(filename string, workingDir string) {
 	if configFileName == "" {
 		return
 	}
+	lintConfigFiles = append(lintConfigFiles, configFileName)
 	f, err := os.Open(configFileName)
 	if err != nil {
 		printConfigError(configFileName, err.Error())
@@ -2040,6 +2465,11 @@ func ReadConfig(filename string, workingDir string) {
 			return
 		}
 	}
+	if ok, dialects := configMap.Get(MakeKeyword("dialects")); ok {
+		if !readDialects(configFileName, dialects) {
+			return
+		}
+	}
 	ok, knownMacros := configMap.Get(KEYWORDS.knownMacros)
 	if ok {
 		_, ok1 := knownMacros.(Seqable)
@@ -2070,6 +2500,49 @@ func ReadConfig(filename string, workingDir string) {
 		if ok, v := m.Get(KEYWORDS.fnWithEmptyBody); ok {
 			WARNINGS.fnWithEmptyBody = ToBool(v)
 		}
+		if ok, v := m.Get(KEYWORDS.rePatternInLoop); ok {
+			WARNINGS.rePatternInLoop = ToBool(v)
+		}
+		if ok, v := m.Get(KEYWORDS.argTypeMismatch); ok {
+			WARNINGS.argTypeMismatch = ToBool(v)
+		}
+		if ok, v := m.Get(KEYWORDS.deprecatedVar); ok {
+			WARNINGS.deprecatedVar = ToBool(v)
+		}
+		if ok, v := m.Get(KEYWORDS.shadowedBinding); ok {
+			WARNINGS.shadowedBinding = ToBool(v)
+		}
+		if ok, v := m.Get(KEYWORDS.unreachableCode); ok {
+			WARNINGS.unreachableCode = ToBool(v)
+		}
+		if ok, v := m.Get(KEYWORDS.constantCondition); ok {
+			WARNINGS.constantCondition = ToBool(v)
+		}
+		if ok, v := m.Get(KEYWORDS.formatArgs); ok {
+			WARNINGS.formatArgs = ToBool(v)
+		}
+	}
+	if ok, severity := configMap.Get(KEYWORDS.severity); ok {
+		m, ok := severity.(Map)
+		if !ok {
+			printConfigError(configFileName, ":severity value must be a map, got "+severity.GetType().ToString(false))
+			return
+		}
+		WARNINGS.severities = map[string]string{}
+		for iter := m.Iter(); iter.HasNext(); {
+			p := iter.Next()
+			rule, ok := p.Key.(Keyword)
+			if !ok {
+				printConfigError(configFileName, ":severity keys must be keywords, got "+p.Key.GetType().ToString(false))
+				return
+			}
+			s, ok := p.Value.(Keyword)
+			if !ok || (*s.name != "error" && *s.name != "warning" && *s.name != "info" && *s.name != "off") {
+				printConfigError(configFileName, ":severity values must be :error, :warning, :info, or :off; got "+p.Value.ToString(false)+" for "+rule.ToString(false))
+				return
+			}
+			WARNINGS.severities[*rule.name] = *s.name
+		}
 	}
 	if ok, valid := configMap.Get(KEYWORDS.validIdent); ok {
 		m, ok := valid.(Map)
@@ -2110,6 +2583,8 @@ func ReadConfig(filename string, workingDir string) {
 }
 
 func RemoveJokerNamespaces() {
+	nsLock.Lock()
+	defer nsLock.Unlock()
 	for k, ns := range GLOBAL_ENV.Namespaces {
 		if ns != GLOBAL_ENV.CoreNamespace && strings.HasPrefix(*k, "joker.") {
 			delete(GLOBAL_ENV.Namespaces, k)
@@ -2118,7 +2593,7 @@ func RemoveJokerNamespaces() {
 }
 
 func markJokerNamespacesAsUsed() {
-	for k, ns := range GLOBAL_ENV.Namespaces {
+	for k, ns := range GLOBAL_ENV.AllNamespaces() {
 		if ns != GLOBAL_ENV.CoreNamespace && strings.HasPrefix(*k, "joker.") {
 			ns.isUsed = true
 			ns.isGloballyUsed = true
@@ -2135,9 +2610,68 @@ func NewReaderFromFile(filename string) (*Reader, error) {
 	return NewReader(bufio.NewReader(f), filename), nil
 }
 
+// LoadDataReaders adds the data readers in dir/data_readers.joke, if it
+// exists, to the root binding of *data-readers*. The file holds a map
+// from namespace-qualified tag symbols to the fully qualified names of
+// the vars to call with the tagged forms. If intern is true those vars
+// are interned (but their namespaces aren't loaded), otherwise the names
+// are kept, for the linter, which doesn't call readers in *data-readers*.
+func LoadDataReaders(dir string, intern bool) {
+	filename := filepath.Join(dir, "data_readers.joke")
+	if _, err := os.Stat(filename); err != nil {
+		return
+	}
+	reader, err := NewReaderFromFile(filename)
+	if err != nil {
+		return
+	}
+	obj, err := TryRead(reader)
+	if err != nil {
+		printConfigError(filename, err.Error())
+		return
+	}
+	m, ok := obj.(Map)
+	if !ok {
+		printConfigError(filename, "data readers must be a map, got "+obj.GetType().ToString(false))
+		return
+	}
+	vr := GLOBAL_ENV.CoreNamespace.Resolve("*data-readers*")
+	var readers Associative = EnsureObjectIsMap(vr.Value, "*data-readers*: %s")
+	for iter := m.Iter(); iter.HasNext(); {
+		p := iter.Next()
+		tag, ok1 := p.Key.(Symbol)
+		name, ok2 := p.Value.(Symbol)
+		if !ok1 || !ok2 || tag.ns == nil || name.ns == nil {
+			printConfigError(filename, "data readers must map qualified symbols to qualified symbols, got "+
+				p.Key.ToString(true)+" "+p.Value.ToString(true))
+			return
+		}
+		var readFunc Object = name
+		if intern {
+			readFunc = GLOBAL_ENV.EnsureSymbolIsNamespace(MakeSymbol(name.Namespace())).Intern(MakeSymbol(name.Name()))
+		}
+		readers = readers.Assoc(tag, readFunc)
+	}
+	vr.Value = readers
+}
+
+// LoadClassPathDataReaders loads the data_readers.joke files at the roots
+// of *classpath*, the empty root standing for dir.
+func LoadClassPathDataReaders(dir string) {
+	cp := EnsureObjectIsVector(GLOBAL_ENV.classPath.Resolve(), "*classpath*: %s")
+	for i := 0; i < cp.Count(); i++ {
+		root := EnsureObjectIsString(cp.at(i), "*classpath*["+strconv.Itoa(i)+"]: %s").S
+		if root == "" {
+			root = dir
+		}
+		LoadDataReaders(root, true)
+	}
+}
+
 func ProcessLinterFile(configDir string, filename string) {
 	linterFileName := filepath.Join(configDir, filename)
 	if _, err := os.Stat(linterFileName); err == nil {
+		lintConfigFiles = append(lintConfigFiles, linterFileName)
 		if reader, err := NewReaderFromFile(linterFileName); err == nil {
 			ProcessReader(reader, linterFileName, EVAL)
 		}
@@ -2152,6 +2686,7 @@ func ProcessLinterFiles(dialect Dialect, filename string, workingDir string) {
 	if configDir == "" {
 		return
 	}
+	LoadDataReaders(configDir, false)
 	if dialect == JOKER {
 		ProcessLinterFile(configDir, "linter.joke")
 		return
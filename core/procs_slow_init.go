@@ -103,8 +103,12 @@ func init() {
 	intern("boolean__", procBoolean, "procBoolean")
 	intern("numerator__", procNumerator, "procNumerator")
 	intern("denominator__", procDenominator, "procDenominator")
+	intern("rationalize__", procRationalize, "procRationalize")
 	intern("bigint__", procBigInt, "procBigInt")
 	intern("bigfloat__", procBigFloat, "procBigFloat")
+	intern("parse-long__", procParseLong, "procParseLong")
+	intern("parse-bigint__", procParseBigInt, "procParseBigInt")
+	intern("parse-double__", procParseDouble, "procParseDouble")
 	intern("pr__", procPr, "procPr")
 	intern("pprint__", procPprint, "procPprint")
 	intern("newline__", procNewline, "procNewline")
@@ -133,6 +137,12 @@ func init() {
 	intern("ns-unalias__", procNamespaceUnalias, "procNamespaceUnalias")
 	intern("var-get__", procVarGet, "procVarGet")
 	intern("var-set__", procVarSet, "procVarSet")
+	intern("alter-var-root__", procAlterVarRoot, "procAlterVarRoot")
+	intern("push-thread-bindings__", procPushThreadBindings, "procPushThreadBindings")
+	intern("pop-thread-bindings__", procPopThreadBindings, "procPopThreadBindings")
+	intern("get-thread-bindings__", procGetThreadBindings, "procGetThreadBindings")
+	intern("thread-bound?__", procIsThreadBound, "procIsThreadBound")
+	intern("has-root__", procHasRoot, "procHasRoot")
 	intern("ns-resolve__", procNsResolve, "procNsResolve")
 	intern("array-map__", procArrayMap, "procArrayMap")
 	intern("buffer__", procBuffer, "procBuffer")
@@ -144,6 +154,9 @@ func init() {
 	intern("regex__", procRegex, "procRegex")
 	intern("re-seq__", procReSeq, "procReSeq")
 	intern("re-find__", procReFind, "procReFind")
+	intern("re-find-named__", procReFindNamed, "procReFindNamed")
+	intern("re-scanner__", procReScanner, "procReScanner")
+	intern("re-matches-named__", procReMatchesNamed, "procReMatchesNamed")
 	intern("rand__", procRand, "procRand")
 	intern("special-symbol?__", procIsSpecialSymbol, "procIsSpecialSymbol")
 	intern("subs__", procSubs, "procSubs")
@@ -152,6 +165,11 @@ func init() {
 	intern("atom__", procAtom, "procAtom")
 	intern("deref__", procDeref, "procDeref")
 	intern("swap__", procSwap, "procSwap")
+	intern("compare-and-set__", procCompareAndSet, "procCompareAndSet")
+	intern("set-validator__", procSetValidator, "procSetValidator")
+	intern("get-validator__", procGetValidator, "procGetValidator")
+	intern("add-watch__", procAddWatch, "procAddWatch")
+	intern("remove-watch__", procRemoveWatch, "procRemoveWatch")
 	intern("swap-vals__", procSwapVals, "procSwapVals")
 	intern("reset__", procReset, "procReset")
 	intern("reset-vals__", procResetVals, "procResetVals")
@@ -163,6 +181,11 @@ func init() {
 	intern("load-file__", procLoadFile, "procLoadFile")
 	intern("load-lib-from-path__", procLoadLibFromPath, "procLoadLibFromPath")
 	intern("reduce-kv__", procReduceKv, "procReduceKv")
+	intern("reduce__", procReduce, "procReduce")
+	intern("reduced__", procReduced, "procReduced")
+	intern("reduced?__", procIsReduced, "procIsReduced")
+	intern("map__", procMap, "procMap")
+	intern("range__", procRange, "procRange")
 	intern("slurp__", procSlurp, "procSlurp")
 	intern("spit__", procSpit, "procSpit")
 	intern("shuffle__", procShuffle, "procShuffle")
@@ -176,13 +199,38 @@ func init() {
 	intern("lib-path__", procLibPath, "procLibPath")
 	intern("intern-fake-var__", procInternFakeVar, "procInternFakeVar")
 	intern("parse__", procParse, "procParse")
-	intern("inc-problem-count__", procIncProblemCount, "procIncProblemCount")
+	intern("print-linter-problem__", procPrintLinterProblem, "procPrintLinterProblem")
 	intern("types__", procTypes, "procTypes")
+	intern("add-call-hook__", procAddCallHook, "procAddCallHook")
+	intern("remove-call-hook__", procRemoveCallHook, "procRemoveCallHook")
 	intern("go__", procGo, "procGo")
 	intern("<!__", procReceive, "procReceive")
 	intern(">!__", procSend, "procSend")
 	intern("chan__", procCreateChan, "procCreateChan")
 	intern("close!__", procCloseChan, "procCloseChan")
+	intern("alts__", procAlts, "procAlts")
+	intern("timeout__", procTimeout, "procTimeout")
+	intern("future__", procFuture, "procFuture")
+	intern("future-cancel__", procFutureCancel, "procFutureCancel")
+	intern("future-cancelled?__", procIsFutureCancelled, "procIsFutureCancelled")
+	intern("promise__", procPromise, "procPromise")
+	intern("deliver__", procDeliver, "procDeliver")
+	intern("deref-timeout__", procDerefWithTimeout, "procDerefWithTimeout")
+	intern("sorted-map__", procSortedMap, "procSortedMap")
+	intern("sorted-set__", procSortedSet, "procSortedSet")
+	intern("sorted?__", procIsSorted, "procIsSorted")
+	intern("sorted-seq__", procSortedSeq, "procSortedSeq")
+	intern("sorted-compare__", procSortedCompare, "procSortedCompare")
+	intern("transient__", procTransient, "procTransient")
+	intern("persistent!__", procPersistent, "procPersistent")
+	intern("conj!__", procConjBang, "procConjBang")
+	intern("assoc!__", procAssocBang, "procAssocBang")
+	intern("dissoc!__", procDissocBang, "procDissocBang")
+	intern("disj!__", procDisjBang, "procDisjBang")
+	intern("pop!__", procPopBang, "procPopBang")
+	intern("add-tap__", procAddTap, "procAddTap")
+	intern("remove-tap__", procRemoveTap, "procRemoveTap")
+	intern("tap>__", procTap, "procTap")
 
 	intern("go-spew__", procGoSpew, "procGoSpew")
 	intern("verbosity-level__", procVerbosityLevel, "procVerbosityLevel")
@@ -0,0 +1,109 @@
+package core
+
+import "io"
+
+type (
+	// Range is the seq of count ints from start by step, as returned by
+	// (range start end step) when all three are ints. It's never empty.
+	Range struct {
+		InfoHolder
+		MetaHolder
+		start int
+		step  int
+		count int
+	}
+	RangeIterator struct {
+		next      int
+		step      int
+		remaining int
+	}
+)
+
+// NewRange returns the ints from start (inclusive) to end (exclusive)
+// by step, which must not be zero.
+func NewRange(start, end, step int) Seq {
+	var count int
+	switch {
+	case step > 0 && start < end:
+		count = (end-start-1)/step + 1
+	case step < 0 && start > end:
+		count = (start-end-1)/-step + 1
+	default:
+		return EmptyList
+	}
+	return &Range{start: start, step: step, count: count}
+}
+
+func (r *Range) Seq() Seq {
+	return r
+}
+
+func (r *Range) Equals(other interface{}) bool {
+	return IsSeqEqual(r, other)
+}
+
+func (r *Range) ToString(escape bool) string {
+	return SeqToString(r, escape)
+}
+
+func (r *Range) Pprint(pp *PrettyPrinter, w io.Writer, indent int) int {
+	return pprintSeq(pp, r, w, indent)
+}
+
+func (r *Range) Format(w io.Writer, indent int) int {
+	return formatSeq(r, w, indent)
+}
+
+func (r *Range) WithMeta(meta Map) Object {
+	res := *r
+	res.meta = SafeMerge(res.meta, meta)
+	return &res
+}
+
+func (r *Range) GetType() *Type {
+	return TYPE.Range
+}
+
+func (r *Range) Hash() uint32 {
+	return hashOrdered(r)
+}
+
+func (r *Range) First() Object {
+	return Int{I: r.start}
+}
+
+func (r *Range) Rest() Seq {
+	if r.count > 1 {
+		return &Range{start: r.start + r.step, step: r.step, count: r.count - 1}
+	}
+	return EmptyList
+}
+
+func (r *Range) IsEmpty() bool {
+	return false
+}
+
+func (r *Range) Cons(obj Object) Seq {
+	return &ConsSeq{first: obj, rest: r}
+}
+
+func (r *Range) Count() int {
+	return r.count
+}
+
+func (r *Range) sequential() {}
+
+func (r *Range) Iter() Iterator {
+	return &RangeIterator{next: r.start, step: r.step, remaining: r.count}
+}
+
+func (iter *RangeIterator) HasNext() bool {
+	return iter.remaining > 0
+}
+
+func (iter *RangeIterator) Next() Object {
+	res := Int{I: iter.next}
+	iter.next += iter.step
+	iter.remaining--
+	return res
+}
@@ -9,6 +9,7 @@ import (
 	"math/rand"
 	"regexp"
 	"strconv"
+	"strings"
 	"unicode"
 	"unicode/utf8"
 )
@@ -33,6 +34,10 @@ var (
 	LINTER_MODE   bool = false
 	FORMAT_MODE   bool = false
 	PROBLEM_COUNT      = 0
+	ERROR_COUNT        = 0     // problems that are errors rather than warnings
+	INFO_COUNT         = 0     // problems that are merely informational
+	MAX_PROBLEMS       = 0     // stop reporting problems past this many; 0 means no limit
+	RETAIN_SOURCE bool = false // keep the source of defs in their vars' :source meta
 	DIALECT       Dialect
 	LINTER_CONFIG *Var
 	SUPPRESS_READ bool = false
@@ -199,6 +204,50 @@ func readComment(reader *Reader) Object {
 	return MakeReadObject(reader, Comment{C: b.String()})
 }
 
+// ignoreComments maps files being linted to the lines with
+// ";; joker:ignore[rule-id ...]" comments and the rules these suppress
+// on the same or the next line.
+var ignoreComments = map[*string]map[int][]string{}
+
+var ignoreCommentRegex = regexp.MustCompile(`joker:ignore\[([^\]]*)\]`)
+
+// readLineComment reads the rest of a comment, starting at line, and
+// records the rules it ignores when linting.
+func readLineComment(reader *Reader, line int) {
+	var b strings.Builder
+	for r := reader.Get(); r != '\n' && r != EOF; r = reader.Get() {
+		b.WriteRune(r)
+	}
+	if !LINTER_MODE {
+		return
+	}
+	for _, m := range ignoreCommentRegex.FindAllStringSubmatch(b.String(), -1) {
+		lines := ignoreComments[reader.filename]
+		if lines == nil {
+			lines = map[int][]string{}
+			ignoreComments[reader.filename] = lines
+		}
+		lines[line] = append(lines[line], strings.FieldsFunc(m[1], func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		})...)
+	}
+}
+
+// readTrailingComment reads the comment (if any) following a top-level
+// form on the same line, so that its joker:ignore rules are known
+// before the form is linted.
+func readTrailingComment(reader *Reader) {
+	r := reader.Get()
+	for r == ' ' || r == '\t' || r == ',' {
+		r = reader.Get()
+	}
+	if r == ';' {
+		readLineComment(reader, reader.line)
+		return
+	}
+	reader.Unget()
+}
+
 func eatWhitespace(reader *Reader) {
 	r := reader.Get()
 	for r != EOF {
@@ -211,9 +260,7 @@ func eatWhitespace(reader *Reader) {
 			continue
 		}
 		if (r == ';' || (r == '#' && reader.Peek() == '!')) && !FORMAT_MODE {
-			for r != '\n' && r != EOF {
-				r = reader.Get()
-			}
+			readLineComment(reader, reader.line)
 			r = reader.Get()
 			continue
 		}
@@ -452,7 +499,7 @@ func readIdent(reader *Reader, first rune) Object {
 			if ns == nil {
 				msg := fmt.Sprintf("Unable to resolve namespace %s in keyword %s", *sym.ns, ":"+str)
 				if LINTER_MODE {
-					printReadWarning(reader, msg)
+					printReadWarning(reader, "unresolved-namespace", msg)
 					return MakeReadObject(reader, MakeKeyword(*sym.name))
 				}
 				panic(MakeReadError(reader, msg))
@@ -552,7 +599,7 @@ func warnInvalidIdent(reader *Reader, s *string) {
 				explain = identValidationSetWhy + "; " + identValidationRangeWhy
 			}
 			msg := fmt.Sprintf("Impermissible character %q at %d in %q (%s)", r, k, *s, explain)
-			printReadWarning(reader, msg)
+			printReadWarning(reader, "invalid-ident", msg)
 		}
 		k++
 	}
@@ -1132,13 +1179,97 @@ func handleNoReaderError(reader *Reader, s Symbol) Object {
 	}
 	if LINTER_MODE {
 		if DIALECT != EDN {
-			printReadWarning(reader, "No reader function for tag "+s.ToString(false))
+			printReadWarning(reader, "unknown-reader-tag", "No reader function for tag "+s.ToString(false))
 		}
 		return readFirst(reader)
 	}
 	panic(MakeReadError(reader, "No reader function for tag "+s.ToString(false)))
 }
 
+// readInterpolation turns the string following #str into a call to str,
+// with the forms enclosed in ${} evaluated and $${ standing for a literal ${.
+// The forms are read by the reader, so they are linted like any other code.
+func readInterpolation(reader *Reader, obj Object) Object {
+	s, ok := obj.(String)
+	if !ok {
+		panic(MakeReadError(reader, "#str must be followed by a string"))
+	}
+	info := obj.GetInfo()
+	line, column := reader.line, reader.column
+	if info != nil {
+		line, column = info.startLine, info.startColumn
+	}
+	parts := []Object{MakeSymbol("joker.core/str")}
+	var b bytes.Buffer
+	for i := 0; i < len(s.S); {
+		switch {
+		case strings.HasPrefix(s.S[i:], "$${"):
+			b.WriteString("${")
+			i += 3
+			column += 3
+			continue
+		case strings.HasPrefix(s.S[i:], "${"):
+			if b.Len() > 0 {
+				parts = append(parts, String{S: b.String()})
+				b.Reset()
+			}
+			sr := strings.NewReader(s.S[i+2:])
+			sub := NewReader(sr, *reader.filename)
+			sub.line, sub.column = line, column+2
+			eatWhitespace(sub)
+			if sub.Peek() == '}' {
+				panic(MakeReadError(sub, "Empty interpolation in #str"))
+			}
+			parts = append(parts, readFirst(sub))
+			eatWhitespace(sub)
+			if sub.Get() != '}' {
+				panic(MakeReadError(sub, "Unterminated interpolation in #str, expected }"))
+			}
+			consumed := int(sr.Size()) - sr.Len()
+			for j := 0; j <= sub.rewind; j++ {
+				consumed -= utf8.RuneLen(top(sub.rw, j))
+			}
+			i += 2 + consumed
+			line, column = sub.line, sub.column
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s.S[i:])
+		if r == '\n' {
+			line++
+			column = 0
+		} else {
+			column++
+		}
+		b.WriteRune(r)
+		i += size
+	}
+	if len(parts) == 1 {
+		return DeriveReadObject(obj, String{S: b.String()})
+	}
+	if b.Len() > 0 {
+		parts = append(parts, String{S: b.String()})
+	}
+	return DeriveReadObject(obj, NewListFrom(parts...))
+}
+
+// dataReader returns the reader for tag in the map of data readers held
+// by the joker.core var named sym.
+func dataReader(sym Symbol, tag Symbol) (Object, bool) {
+	vr, ok := GLOBAL_ENV.CoreNamespace.mapping(sym.name)
+	if !ok {
+		return nil, false
+	}
+	readers, ok := vr.Resolve().(Map)
+	if !ok {
+		return nil, false
+	}
+	ok, readFunc := readers.Get(tag)
+	return readFunc, ok
+}
+
+// readTagged calls the data reader for the tag on the form following it.
+// In linter mode the readers in *data-readers*, whose namespaces aren't
+// loaded, aren't called: the tagged forms are read as they are.
 func readTagged(reader *Reader) Object {
 	obj := readFirst(reader)
 	if FORMAT_MODE {
@@ -1147,19 +1278,22 @@ func readTagged(reader *Reader) Object {
 	}
 	switch s := obj.(type) {
 	case Symbol:
-		readersVar, ok := GLOBAL_ENV.CoreNamespace.mappings[SYMBOLS.defaultDataReaders.name]
-		if !ok {
-			return handleNoReaderError(reader, s)
+		if s.ns == nil && s.Name() == "str" && (!LINTER_MODE || DIALECT == JOKER) {
+			return readInterpolation(reader, readFirst(reader))
 		}
-		readersMap, ok := readersVar.Value.(Map)
+		isUserReader := true
+		readFunc, ok := dataReader(SYMBOLS.dataReaders, s)
 		if !ok {
-			return handleNoReaderError(reader, s)
+			isUserReader = false
+			readFunc, ok = dataReader(SYMBOLS.defaultDataReaders, s)
 		}
-		ok, readFunc := readersMap.Get(s)
 		if !ok {
 			return handleNoReaderError(reader, s)
 		}
-		return EnsureObjectIsVar(readFunc, "").Call([]Object{readFirst(reader)})
+		if SUPPRESS_READ || (LINTER_MODE && isUserReader) {
+			return readFirst(reader)
+		}
+		return EnsureObjectIsCallable(readFunc, "Data reader must be a function, got %s").Call([]Object{readFirst(reader)})
 	default:
 		panic(MakeReadError(reader, "Reader tag must be a symbol"))
 	}
@@ -1255,9 +1389,9 @@ func readNamespacedMap(reader *Reader) Object {
 			if !ok || sym.ns != nil {
 				panic(MakeReadError(reader, "Namespaced map must specify a valid namespace: "+sym.ToString(false)))
 			}
-			ns := GLOBAL_ENV.CurrentNamespace().aliases[sym.name]
+			ns := GLOBAL_ENV.CurrentNamespace().alias(sym.name)
 			if ns == nil {
-				ns = GLOBAL_ENV.Namespaces[sym.name]
+				ns = GLOBAL_ENV.namespace(sym.name)
 			}
 			if ns == nil {
 				panic(MakeReadError(reader, "Unknown auto-resolved namespace alias: "+sym.ToString(false)))
@@ -1488,9 +1622,11 @@ func Read(reader *Reader) (Object, bool) {
 }
 
 func TryRead(reader *Reader) (obj Object, err error) {
+	s := RT.SaveCallState()
 	defer func() {
 		if r := recover(); r != nil {
-			PROBLEM_COUNT++
+			countError()
+			r = RT.Recovered(r, s)
 			switch r.(type) {
 			case ReadError:
 				err = r.(error)
@@ -1505,6 +1641,12 @@ func TryRead(reader *Reader) (obj Object, err error) {
 			}
 		}
 	}()
+	return readTopLevel(reader)
+}
+
+// readTopLevel reads the next top-level form, returning io.EOF at the end
+// of input. Panics on read errors.
+func readTopLevel(reader *Reader) (Object, error) {
 	for {
 		eatWhitespace(reader)
 		if reader.Peek() == EOF {
@@ -1518,7 +1660,7 @@ func TryRead(reader *Reader) (obj Object, err error) {
 		// legitimate empty vector as read from the source
 		// and surrogate value that means "no object was read".
 		if obj.GetInfo() != nil {
-			PROBLEM_COUNT++
+			countError()
 			return NIL, MakeReadError(reader, "Reader conditional splicing not allowed at the top level.")
 		}
 	}
@@ -0,0 +1,47 @@
+package core
+
+import (
+	"container/list"
+	"regexp"
+)
+
+const regexCacheSize = 256
+
+type (
+	// regexCache is an LRU cache of regexes compiled from strings by
+	// re-pattern, so that compiling the same pattern repeatedly (e.g. in
+	// a loop) is cheap. Guarded by the GIL.
+	regexCache struct {
+		entries map[string]*list.Element
+		order   *list.List // of *regexCacheEntry, most recently used first
+		size    int
+	}
+	regexCacheEntry struct {
+		pattern string
+		re      *regexp.Regexp
+	}
+)
+
+var regexes = &regexCache{
+	entries: map[string]*list.Element{},
+	order:   list.New(),
+	size:    regexCacheSize,
+}
+
+func (c *regexCache) compile(pattern string) (*regexp.Regexp, error) {
+	if e, ok := c.entries[pattern]; ok {
+		c.order.MoveToFront(e)
+		return e.Value.(*regexCacheEntry).re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	c.entries[pattern] = c.order.PushFront(&regexCacheEntry{pattern: pattern, re: re})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*regexCacheEntry).pattern)
+	}
+	return re, nil
+}
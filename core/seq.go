@@ -18,6 +18,16 @@ type (
 	Seqable interface {
 		Seq() Seq
 	}
+	// Iterator walks the elements of a collection one at a time.
+	Iterator interface {
+		HasNext() bool
+		Next() Object
+	}
+	// Iterable is implemented by collections that can be walked
+	// without allocating a seq for each element.
+	Iterable interface {
+		Iter() Iterator
+	}
 	SeqIterator struct {
 		seq Seq
 	}
@@ -48,8 +58,8 @@ type (
 )
 
 func SeqsEqual(seq1, seq2 Seq) bool {
-	iter2 := iter(seq2)
-	for iter1 := iter(seq1); iter1.HasNext(); {
+	iter2 := iterOf(seq2)
+	for iter1 := iterOf(seq1); iter1.HasNext(); {
 		if !iter2.HasNext() || !iter2.Next().Equals(iter1.Next()) {
 			return false
 		}
@@ -83,8 +93,8 @@ func (seq *MappingSeq) ToString(escape bool) string {
 	return SeqToString(seq, escape)
 }
 
-func (seq *MappingSeq) Pprint(w io.Writer, indent int) int {
-	return pprintSeq(seq, w, indent)
+func (seq *MappingSeq) Pprint(pp *PrettyPrinter, w io.Writer, indent int) int {
+	return pprintSeq(pp, seq, w, indent)
 }
 
 func (seq *MappingSeq) Format(w io.Writer, indent int) int {
@@ -130,10 +140,26 @@ func (seq *LazySeq) Seq() Seq {
 	return seq
 }
 
-func (seq *LazySeq) realize() {
-	if seq.seq == nil {
+// sval calls fn, unless it's been called already, and returns what it
+// returned, which may be another (nested) lazy seq.
+func (seq *LazySeq) sval() Seq {
+	if seq.fn != nil {
 		seq.seq = EnsureObjectIsSeqable(seq.fn.Call([]Object{}), "").Seq()
+		// Let go of fn and whatever it closes over.
+		seq.fn = nil
 	}
+	return seq.seq
+}
+
+func (seq *LazySeq) realize() {
+	s := seq.sval()
+	// Unwrap the lazy seqs returned by fn (e.g. by filter skipping
+	// items) in a loop, calling only their fns, so that walking them
+	// takes constant stack and doesn't retain what they skipped.
+	for ls, ok := s.(*LazySeq); ok; ls, ok = s.(*LazySeq) {
+		s = ls.sval()
+	}
+	seq.seq = s
 }
 
 func (seq *LazySeq) IsRealized() bool {
@@ -148,8 +174,8 @@ func (seq *LazySeq) ToString(escape bool) string {
 	return SeqToString(seq, escape)
 }
 
-func (seq *LazySeq) Pprint(w io.Writer, indent int) int {
-	return pprintSeq(seq, w, indent)
+func (seq *LazySeq) Pprint(pp *PrettyPrinter, w io.Writer, indent int) int {
+	return pprintSeq(pp, seq, w, indent)
 }
 
 func (seq *LazySeq) Format(w io.Writer, indent int) int {
@@ -207,8 +233,8 @@ func (seq *ArraySeq) ToString(escape bool) string {
 	return SeqToString(seq, escape)
 }
 
-func (seq *ArraySeq) Pprint(w io.Writer, indent int) int {
-	return pprintSeq(seq, w, indent)
+func (seq *ArraySeq) Pprint(pp *PrettyPrinter, w io.Writer, indent int) int {
+	return pprintSeq(pp, seq, w, indent)
 }
 
 func (seq *ArraySeq) Format(w io.Writer, indent int) int {
@@ -284,8 +310,8 @@ func (seq *ConsSeq) ToString(escape bool) string {
 	return SeqToString(seq, escape)
 }
 
-func (seq *ConsSeq) Pprint(w io.Writer, indent int) int {
-	return pprintSeq(seq, w, indent)
+func (seq *ConsSeq) Pprint(pp *PrettyPrinter, w io.Writer, indent int) int {
+	return pprintSeq(pp, seq, w, indent)
 }
 
 func (seq *ConsSeq) Format(w io.Writer, indent int) int {
@@ -329,6 +355,18 @@ func iter(seq Seq) *SeqIterator {
 	return &SeqIterator{seq: seq}
 }
 
+// iterOf returns an iterator over the elements of s, avoiding
+// seq allocations when s is Iterable or a map.
+func iterOf(s Seqable) Iterator {
+	switch s := s.(type) {
+	case Iterable:
+		return s.Iter()
+	case Map:
+		return &MapEntryIterator{iter: s.Iter()}
+	}
+	return iter(s.Seq())
+}
+
 func (iter *SeqIterator) Next() Object {
 	res := iter.seq.First()
 	iter.seq = iter.seq.Rest()
@@ -352,6 +390,17 @@ func Fourth(seq Seq) Object {
 }
 
 func ToSlice(seq Seq) []Object {
+	if it, ok := seq.(Iterable); ok {
+		n := 0
+		if c, ok := seq.(Counted); ok {
+			n = c.Count()
+		}
+		res := make([]Object, 0, n)
+		for i := it.Iter(); i.HasNext(); {
+			res = append(res, i.Next())
+		}
+		return res
+	}
 	res := make([]Object, 0)
 	for !seq.IsEmpty() {
 		res = append(res, seq.First())
@@ -422,16 +471,12 @@ func hashOrdered(seq Seq) uint32 {
 	return h.Sum32()
 }
 
-func pprintSeq(seq Seq, w io.Writer, indent int) int {
-	i := indent + 1
-	fmt.Fprint(w, "(")
-	for iter := iter(seq); iter.HasNext(); {
-		i = pprintObject(iter.Next(), indent+1, w)
-		if iter.HasNext() {
-			fmt.Fprint(w, "\n")
-			writeIndent(w, indent+1)
-		}
+func pprintSeq(pp *PrettyPrinter, seq Seq, w io.Writer, indent int) int {
+	if pp.Code && isCodeForm(seq) {
+		return pprintCode(pp, seq, w, indent)
 	}
+	fmt.Fprint(w, "(")
+	i := pprintElements(pp, ToSlice(seq), w, indent+1)
 	fmt.Fprint(w, ")")
 	return i + 1
 }
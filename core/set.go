@@ -15,7 +15,8 @@ type (
 	MapSet struct {
 		InfoHolder
 		MetaHolder
-		m Map
+		m    Map
+		hash uint32
 	}
 )
 
@@ -30,15 +31,30 @@ func (set *MapSet) Disjoin(key Object) Set {
 }
 
 func (set *MapSet) Add(obj Object) bool {
+	set.hash = 0
 	switch m := set.m.(type) {
 	case *ArrayMap:
-		return m.Add(obj, Boolean{B: true})
+		if int64(len(m.arr)) < HASHMAP_THRESHOLD {
+			return m.Add(obj, Boolean{B: true})
+		}
+		if m.indexOf(obj) != -1 {
+			return false
+		}
+		// Assoc turns a full ArrayMap into a HashMap.
+		set.m = m.Assoc(obj, Boolean{B: true}).(Map)
+		return true
 	case *HashMap:
 		if m.containsKey(obj) {
 			return false
 		}
 		set.m = set.m.Assoc(obj, Boolean{B: true}).(Map)
 		return true
+	case *SortedMap:
+		if ok, _ := m.Get(obj); ok {
+			return false
+		}
+		set.m = m.Assoc(obj, Boolean{B: true}).(Map)
+		return true
 	default:
 		return false
 	}
@@ -86,7 +102,10 @@ func (seq *MapSet) GetType() *Type {
 }
 
 func (set *MapSet) Hash() uint32 {
-	return hashUnordered(set.Seq(), 2)
+	if set.hash == 0 {
+		set.hash = hashUnordered(set.Seq(), 2)
+	}
+	return set.hash
 }
 
 func (set *MapSet) Seq() Seq {
@@ -106,6 +125,9 @@ func (set *MapSet) Call(args []Object) Object {
 }
 
 func (set *MapSet) Empty() Collection {
+	if m, ok := set.m.(*SortedMap); ok {
+		return &MapSet{m: m.Empty().(Map)}
+	}
 	return EmptySet()
 }
 
@@ -118,16 +140,9 @@ func NewSetFromSeq(s Seq) *MapSet {
 	return res
 }
 
-func (set *MapSet) Pprint(w io.Writer, indent int) int {
-	i := indent + 1
+func (set *MapSet) Pprint(pp *PrettyPrinter, w io.Writer, indent int) int {
 	fmt.Fprint(w, "#{")
-	for iter := iter(set.m.Keys()); iter.HasNext(); {
-		i = pprintObject(iter.Next(), indent+2, w)
-		if iter.HasNext() {
-			fmt.Fprint(w, "\n")
-			writeIndent(w, indent+2)
-		}
-	}
+	i := pprintElements(pp, ToSlice(set.m.Keys()), w, indent+2)
 	fmt.Fprint(w, "}")
 	return i + 1
 }
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+)
+
+// A snapshot holds the parsed (packed) top-level forms of a script, as
+// produced by PackReader while the script is evaluated, so that later
+// runs only unpack and evaluate them, skipping reading and parsing.
+// joker.core itself needs no snapshot: fast init already builds it from
+// Go code generated at build time. Snapshots are only valid for the
+// Joker version that wrote them.
+
+func snapshotMagic() []byte {
+	return []byte("joker-snapshot " + VERSION + "\n")
+}
+
+// WriteSnapshot evaluates the code from reader, writing its packed forms
+// to the snapshot file filename.
+func WriteSnapshot(reader *Reader, sourceFilename string, filename string) error {
+	p, err := PackReader(reader, sourceFilename)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, append(snapshotMagic(), p...), 0666)
+}
+
+// RunSnapshot evaluates the forms packed in the snapshot file filename,
+// printing and returning the first error.
+func RunSnapshot(filename string) (err error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		fmt.Fprintln(GLOBAL_ENV.Err(), "Error: ", err)
+		return err
+	}
+	magic := snapshotMagic()
+	if !bytes.HasPrefix(data, magic) {
+		err = errors.New(filename + " is not a snapshot written by Joker " + VERSION)
+		fmt.Fprintln(GLOBAL_ENV.Err(), "Error: ", err)
+		return err
+	}
+	header, p := UnpackHeader(data[len(magic):], GLOBAL_ENV)
+	for len(p) > 0 {
+		var expr Expr
+		expr, p = UnpackExpr(p, header)
+		if _, err = TryEval(expr); err != nil {
+			PrintError(GLOBAL_ENV.Err(), err)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,426 @@
+package core
+
+import (
+	"io"
+)
+
+type (
+	// SortedMap is a persistent map ordered by a comparator, implemented
+	// as an AVL tree. Sorted sets are MapSets backed by a SortedMap.
+	SortedMap struct {
+		InfoHolder
+		MetaHolder
+		cmp   Comparator
+		root  *sortedNode
+		count int
+		hash  uint32
+	}
+	sortedNode struct {
+		key    Object
+		val    Object
+		left   *sortedNode
+		right  *sortedNode
+		height int
+	}
+	SortedMapIterator struct {
+		stack     []*sortedNode
+		ascending bool
+	}
+)
+
+func NewSortedMap(cmp Comparator) *SortedMap {
+	return &SortedMap{cmp: cmp}
+}
+
+func (n *sortedNode) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func newSortedNode(key, val Object, left, right *sortedNode) *sortedNode {
+	h := left.getHeight()
+	if hr := right.getHeight(); hr > h {
+		h = hr
+	}
+	return &sortedNode{key: key, val: val, left: left, right: right, height: h + 1}
+}
+
+// balanceSorted returns a node with key and val and the given subtrees,
+// rotated as needed to keep the heights of the subtrees within one of
+// each other, given that they were before the subtrees were changed.
+func balanceSorted(key, val Object, left, right *sortedNode) *sortedNode {
+	hl, hr := left.getHeight(), right.getHeight()
+	switch {
+	case hl > hr+1:
+		if left.left.getHeight() >= left.right.getHeight() {
+			return newSortedNode(left.key, left.val, left.left, newSortedNode(key, val, left.right, right))
+		}
+		lr := left.right
+		return newSortedNode(lr.key, lr.val,
+			newSortedNode(left.key, left.val, left.left, lr.left),
+			newSortedNode(key, val, lr.right, right))
+	case hr > hl+1:
+		if right.right.getHeight() >= right.left.getHeight() {
+			return newSortedNode(right.key, right.val, newSortedNode(key, val, left, right.left), right.right)
+		}
+		rl := right.left
+		return newSortedNode(rl.key, rl.val,
+			newSortedNode(key, val, left, rl.left),
+			newSortedNode(right.key, right.val, rl.right, right.right))
+	}
+	return newSortedNode(key, val, left, right)
+}
+
+func (m *SortedMap) insert(n *sortedNode, key, val Object, added *bool) *sortedNode {
+	if n == nil {
+		*added = true
+		return newSortedNode(key, val, nil, nil)
+	}
+	c := m.cmp.Compare(key, n.key)
+	switch {
+	case c < 0:
+		return balanceSorted(n.key, n.val, m.insert(n.left, key, val, added), n.right)
+	case c > 0:
+		return balanceSorted(n.key, n.val, n.left, m.insert(n.right, key, val, added))
+	}
+	return &sortedNode{key: n.key, val: val, left: n.left, right: n.right, height: n.height}
+}
+
+func removeMinSorted(n *sortedNode) *sortedNode {
+	if n.left == nil {
+		return n.right
+	}
+	return balanceSorted(n.key, n.val, removeMinSorted(n.left), n.right)
+}
+
+func (m *SortedMap) remove(n *sortedNode, key Object, removed *bool) *sortedNode {
+	if n == nil {
+		return nil
+	}
+	c := m.cmp.Compare(key, n.key)
+	switch {
+	case c < 0:
+		left := m.remove(n.left, key, removed)
+		if !*removed {
+			return n
+		}
+		return balanceSorted(n.key, n.val, left, n.right)
+	case c > 0:
+		right := m.remove(n.right, key, removed)
+		if !*removed {
+			return n
+		}
+		return balanceSorted(n.key, n.val, n.left, right)
+	}
+	*removed = true
+	if n.left == nil {
+		return n.right
+	}
+	if n.right == nil {
+		return n.left
+	}
+	min := n.right
+	for min.left != nil {
+		min = min.left
+	}
+	return balanceSorted(min.key, min.val, n.left, removeMinSorted(n.right))
+}
+
+func (m *SortedMap) find(key Object) *sortedNode {
+	for n := m.root; n != nil; {
+		c := m.cmp.Compare(key, n.key)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+func (iter *SortedMapIterator) pushPath(n *sortedNode) {
+	for n != nil {
+		iter.stack = append(iter.stack, n)
+		if iter.ascending {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+}
+
+func (iter *SortedMapIterator) HasNext() bool {
+	return len(iter.stack) > 0
+}
+
+func (iter *SortedMapIterator) Next() *Pair {
+	if len(iter.stack) == 0 {
+		panic(newIteratorError())
+	}
+	n := iter.stack[len(iter.stack)-1]
+	iter.stack = iter.stack[:len(iter.stack)-1]
+	if iter.ascending {
+		iter.pushPath(n.right)
+	} else {
+		iter.pushPath(n.left)
+	}
+	return &Pair{Key: n.key, Value: n.val}
+}
+
+func (m *SortedMap) iter(ascending bool) *SortedMapIterator {
+	iter := &SortedMapIterator{ascending: ascending}
+	iter.pushPath(m.root)
+	return iter
+}
+
+// iterFrom returns an iterator starting at the first entry whose key is
+// not less (or, if descending, not greater) than key.
+func (m *SortedMap) iterFrom(key Object, ascending bool) *SortedMapIterator {
+	iter := &SortedMapIterator{ascending: ascending}
+	for n := m.root; n != nil; {
+		c := m.cmp.Compare(key, n.key)
+		if c == 0 {
+			iter.stack = append(iter.stack, n)
+			break
+		}
+		if ascending == (c < 0) {
+			iter.stack = append(iter.stack, n)
+		}
+		if c < 0 {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return iter
+}
+
+// entries returns the entries (or, if keysOnly, the keys) iter walks
+// over, or nil if there are none.
+func sortedEntries(iter *SortedMapIterator, keysOnly bool) Seq {
+	var res []Object
+	for iter.HasNext() {
+		p := iter.Next()
+		if keysOnly {
+			res = append(res, p.Key)
+		} else {
+			res = append(res, NewVectorFrom(p.Key, p.Value))
+		}
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	return &ArraySeq{arr: res}
+}
+
+func (m *SortedMap) WithMeta(meta Map) Object {
+	res := *m
+	res.meta = SafeMerge(res.meta, meta)
+	return &res
+}
+
+func (m *SortedMap) ToString(escape bool) string {
+	return mapToString(m, escape)
+}
+
+func (m *SortedMap) Equals(other interface{}) bool {
+	return mapEquals(m, other)
+}
+
+func (m *SortedMap) GetType() *Type {
+	return TYPE.SortedMap
+}
+
+func (m *SortedMap) Hash() uint32 {
+	if m.hash == 0 {
+		m.hash = hashUnordered(m.Seq(), 1)
+	}
+	return m.hash
+}
+
+func (m *SortedMap) Seq() Seq {
+	if s := sortedEntries(m.iter(true), false); s != nil {
+		return s
+	}
+	return EmptyList
+}
+
+func (m *SortedMap) Rseq() Seq {
+	if s := sortedEntries(m.iter(false), false); s != nil {
+		return s
+	}
+	return EmptyList
+}
+
+func (m *SortedMap) Count() int {
+	return m.count
+}
+
+func (m *SortedMap) Assoc(key, val Object) Associative {
+	added := false
+	res := &SortedMap{
+		cmp:  m.cmp,
+		root: m.insert(m.root, key, val, &added),
+	}
+	res.count = m.count
+	if added {
+		res.count++
+	}
+	res.meta = m.meta
+	return res
+}
+
+func (m *SortedMap) EntryAt(key Object) *Vector {
+	if n := m.find(key); n != nil {
+		return NewVectorFrom(n.key, n.val)
+	}
+	return nil
+}
+
+func (m *SortedMap) Get(key Object) (bool, Object) {
+	if n := m.find(key); n != nil {
+		return true, n.val
+	}
+	return false, nil
+}
+
+func (m *SortedMap) Conj(obj Object) Conjable {
+	return mapConj(m, obj)
+}
+
+func (m *SortedMap) Iter() MapIterator {
+	return m.iter(true)
+}
+
+func (m *SortedMap) Keys() Seq {
+	return &MappingSeq{
+		seq: m.Seq(),
+		fn: func(obj Object) Object {
+			return obj.(*Vector).Nth(0)
+		},
+	}
+}
+
+func (m *SortedMap) Vals() Seq {
+	return &MappingSeq{
+		seq: m.Seq(),
+		fn: func(obj Object) Object {
+			return obj.(*Vector).Nth(1)
+		},
+	}
+}
+
+func (m *SortedMap) Merge(other Map) Map {
+	var res Associative = m
+	for iter := other.Iter(); iter.HasNext(); {
+		p := iter.Next()
+		res = res.Assoc(p.Key, p.Value)
+	}
+	return res.(Map)
+}
+
+func (m *SortedMap) Without(key Object) Map {
+	removed := false
+	root := m.remove(m.root, key, &removed)
+	if !removed {
+		return m
+	}
+	res := &SortedMap{
+		cmp:   m.cmp,
+		root:  root,
+		count: m.count - 1,
+	}
+	res.meta = m.meta
+	return res
+}
+
+func (m *SortedMap) Call(args []Object) Object {
+	return callMap(m, args)
+}
+
+func (m *SortedMap) Empty() Collection {
+	return NewSortedMap(m.cmp)
+}
+
+func (m *SortedMap) Pprint(pp *PrettyPrinter, w io.Writer, indent int) int {
+	return pprintMap(pp, m, w, indent)
+}
+
+// sortedMapOf returns the SortedMap of a sorted map or set, and whether
+// it's a set.
+func sortedMapOf(obj Object) (*SortedMap, bool) {
+	switch obj := obj.(type) {
+	case *SortedMap:
+		return obj, false
+	case *MapSet:
+		if m, ok := obj.m.(*SortedMap); ok {
+			return m, true
+		}
+	}
+	return nil, false
+}
+
+func ensureArgIsSorted(args []Object, index int) (*SortedMap, bool) {
+	m, isSet := sortedMapOf(args[index])
+	if m == nil {
+		panic(FailArg(args[index], "sorted map or set", index))
+	}
+	return m, isSet
+}
+
+var procSortedMap = func(args []Object) Object {
+	cmp := EnsureArgIsComparator(args, 0)
+	if len(args)%2 == 0 {
+		panic(RT.NewError("No value supplied for key " + args[len(args)-1].ToString(false)))
+	}
+	var res Associative = NewSortedMap(cmp)
+	for i := 1; i < len(args); i += 2 {
+		res = res.Assoc(args[i], args[i+1])
+	}
+	return res
+}
+
+var procSortedSet = func(args []Object) Object {
+	cmp := EnsureArgIsComparator(args, 0)
+	var m Associative = NewSortedMap(cmp)
+	for _, key := range args[1:] {
+		m = m.Assoc(key, Boolean{B: true})
+	}
+	return &MapSet{m: m.(Map)}
+}
+
+var procIsSorted = func(args []Object) Object {
+	CheckArity(args, 1, 1)
+	m, _ := sortedMapOf(args[0])
+	return Boolean{B: m != nil}
+}
+
+// procSortedSeq returns the entries (the keys, for a set) of a sorted
+// collection in ascending or descending order, optionally starting at
+// a key, or nil if there are none.
+var procSortedSeq = func(args []Object) Object {
+	CheckArity(args, 2, 3)
+	m, isSet := ensureArgIsSorted(args, 0)
+	ascending := EnsureArgIsBoolean(args, 1).B
+	var iter *SortedMapIterator
+	if len(args) == 3 {
+		iter = m.iterFrom(args[2], ascending)
+	} else {
+		iter = m.iter(ascending)
+	}
+	if s := sortedEntries(iter, isSet); s != nil {
+		return s
+	}
+	return NIL
+}
+
+var procSortedCompare = func(args []Object) Object {
+	CheckArity(args, 3, 3)
+	m, _ := ensureArgIsSorted(args, 0)
+	return Int{I: m.cmp.Compare(args[1], args[2])}
+}
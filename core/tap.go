@@ -0,0 +1,81 @@
+package core
+
+import (
+	"sync"
+)
+
+const tapQueueSize = 1024
+
+var (
+	// Functions registered with add-tap. Guarded by the GIL.
+	tapFns []Object
+
+	tapQueue     = make(chan Object, tapQueueSize)
+	tapLoopStart sync.Once
+)
+
+// tapLoop delivers values sent by tap> to the tap functions, one value
+// at a time, in the order they were sent. Exceptions thrown by tap
+// functions are ignored.
+func tapLoop() {
+	var gs *GoroutineState
+	for x := range tapQueue {
+		RT.GIL.Lock(gs)
+		fns := append([]Object(nil), tapFns...)
+		for _, f := range fns {
+			callTap(f.(Callable), x)
+		}
+		gs = RT.GIL.Unlock()
+	}
+}
+
+func callTap(f Callable, x Object) {
+	s := RT.SaveCallState()
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := RT.Recovered(r, s).(Error); !ok {
+				RT.GIL.Unlock()
+				panic(r)
+			}
+		}
+	}()
+	f.Call([]Object{x})
+}
+
+var procAddTap = func(args []Object) Object {
+	CheckArity(args, 1, 1)
+	EnsureArgIsCallable(args, 0)
+	f := args[0]
+	for _, g := range tapFns {
+		if g.Equals(f) {
+			return NIL
+		}
+	}
+	tapFns = append(tapFns, f)
+	return NIL
+}
+
+var procRemoveTap = func(args []Object) Object {
+	CheckArity(args, 1, 1)
+	f := args[0]
+	for i, g := range tapFns {
+		if g.Equals(f) {
+			tapFns = append(tapFns[:i:i], tapFns[i+1:]...)
+			break
+		}
+	}
+	return NIL
+}
+
+var procTap = func(args []Object) Object {
+	CheckArity(args, 1, 1)
+	tapLoopStart.Do(func() {
+		go tapLoop()
+	})
+	select {
+	case tapQueue <- args[0]:
+		return Boolean{B: true}
+	default:
+		return Boolean{B: false}
+	}
+}
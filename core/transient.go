@@ -0,0 +1,344 @@
+package core
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+type (
+	// Transient is a mutable version of a vector, map or set, used to
+	// build up a collection without paying for a persistent update per
+	// element. It can't be used anymore once Persistent has been called.
+	Transient interface {
+		Object
+		Counted
+		Gettable
+		Persistent() Object
+		conj(obj Object)
+	}
+	TransientVector struct {
+		arr      []Object
+		isFrozen bool
+	}
+	TransientMap struct {
+		m        Map
+		isFrozen bool
+	}
+	TransientSet struct {
+		m        TransientMap
+		isFrozen bool
+	}
+)
+
+func ensureEditable(isFrozen bool) {
+	if isFrozen {
+		panic(RT.NewError("Transient used after persistent! call"))
+	}
+}
+
+// MakeTransient returns a transient version of coll, which is left
+// unchanged.
+func MakeTransient(coll Object) Transient {
+	switch coll := coll.(type) {
+	case *Vector:
+		arr := make([]Object, coll.count)
+		for i := range arr {
+			arr[i] = coll.at(i)
+		}
+		return &TransientVector{arr: arr}
+	case *ArrayMap, *HashMap:
+		return &TransientMap{m: ownMap(coll.(Map))}
+	case *MapSet:
+		if _, ok := coll.m.(*SortedMap); !ok {
+			return &TransientSet{m: TransientMap{m: ownMap(coll.m)}}
+		}
+	}
+	panic(RT.NewError("Can't create a transient from " + coll.GetType().ToString(false)))
+}
+
+// ownMap returns a map the transient can change in place, without
+// affecting m.
+func ownMap(m Map) Map {
+	if am, ok := m.(*ArrayMap); ok {
+		return am.Clone()
+	}
+	return m
+}
+
+func (v *TransientVector) ToString(escape bool) string {
+	return "#object[TransientVector]"
+}
+
+func (v *TransientVector) Equals(other interface{}) bool {
+	return v == other
+}
+
+func (v *TransientVector) GetInfo() *ObjectInfo {
+	return nil
+}
+
+func (v *TransientVector) GetType() *Type {
+	return TYPE.TransientVector
+}
+
+func (v *TransientVector) Hash() uint32 {
+	return HashPtr(uintptr(unsafe.Pointer(v)))
+}
+
+func (v *TransientVector) WithInfo(info *ObjectInfo) Object {
+	return v
+}
+
+func (v *TransientVector) Count() int {
+	ensureEditable(v.isFrozen)
+	return len(v.arr)
+}
+
+func (v *TransientVector) Get(key Object) (bool, Object) {
+	ensureEditable(v.isFrozen)
+	if key, ok := key.(Int); ok && key.I >= 0 && key.I < len(v.arr) {
+		return true, v.arr[key.I]
+	}
+	return false, nil
+}
+
+func (v *TransientVector) Nth(i int) Object {
+	ensureEditable(v.isFrozen)
+	if i < 0 || i >= len(v.arr) {
+		panic(RT.NewError(fmt.Sprintf("Index %d is out of bounds [0..%d]", i, len(v.arr)-1)))
+	}
+	return v.arr[i]
+}
+
+func (v *TransientVector) TryNth(i int, d Object) Object {
+	ensureEditable(v.isFrozen)
+	if i < 0 || i >= len(v.arr) {
+		return d
+	}
+	return v.arr[i]
+}
+
+func (v *TransientVector) conj(obj Object) {
+	ensureEditable(v.isFrozen)
+	v.arr = append(v.arr, obj)
+}
+
+func (v *TransientVector) assoc(key, val Object) {
+	ensureEditable(v.isFrozen)
+	i := assertInteger(key)
+	if i < 0 || i > len(v.arr) {
+		panic(RT.NewError((fmt.Sprintf("Index %d is out of bounds [0..%d]", i, len(v.arr)))))
+	}
+	if i == len(v.arr) {
+		v.arr = append(v.arr, val)
+	} else {
+		v.arr[i] = val
+	}
+}
+
+func (v *TransientVector) pop() {
+	ensureEditable(v.isFrozen)
+	if len(v.arr) == 0 {
+		panic(RT.NewError("Can't pop empty vector"))
+	}
+	v.arr[len(v.arr)-1] = nil
+	v.arr = v.arr[:len(v.arr)-1]
+}
+
+func (v *TransientVector) Persistent() Object {
+	ensureEditable(v.isFrozen)
+	v.isFrozen = true
+	return NewVectorFromSlice(v.arr)
+}
+
+func (m *TransientMap) ToString(escape bool) string {
+	return "#object[TransientMap]"
+}
+
+func (m *TransientMap) Equals(other interface{}) bool {
+	return m == other
+}
+
+func (m *TransientMap) GetInfo() *ObjectInfo {
+	return nil
+}
+
+func (m *TransientMap) GetType() *Type {
+	return TYPE.TransientMap
+}
+
+func (m *TransientMap) Hash() uint32 {
+	return HashPtr(uintptr(unsafe.Pointer(m)))
+}
+
+func (m *TransientMap) WithInfo(info *ObjectInfo) Object {
+	return m
+}
+
+func (m *TransientMap) Count() int {
+	ensureEditable(m.isFrozen)
+	return m.m.Count()
+}
+
+func (m *TransientMap) Get(key Object) (bool, Object) {
+	ensureEditable(m.isFrozen)
+	return m.m.Get(key)
+}
+
+// assoc changes small ArrayMaps in place, and turns them into HashMaps
+// (via Assoc) once they grow past HASHMAP_THRESHOLD.
+func (m *TransientMap) assoc(key, val Object) {
+	ensureEditable(m.isFrozen)
+	if am, ok := m.m.(*ArrayMap); ok {
+		if int64(len(am.arr)) < HASHMAP_THRESHOLD || am.indexOf(key) != -1 {
+			am.Set(key, val)
+			return
+		}
+	}
+	m.m = m.m.Assoc(key, val).(Map)
+}
+
+func (m *TransientMap) without(key Object) {
+	ensureEditable(m.isFrozen)
+	m.m = m.m.Without(key)
+}
+
+func (m *TransientMap) conj(obj Object) {
+	ensureEditable(m.isFrozen)
+	switch obj := obj.(type) {
+	case *Vector:
+		if obj.count != 2 {
+			panic(RT.NewError("Vector argument to map's conj must be a vector with two elements"))
+		}
+		m.assoc(obj.at(0), obj.at(1))
+	case Map:
+		for iter := obj.Iter(); iter.HasNext(); {
+			p := iter.Next()
+			m.assoc(p.Key, p.Value)
+		}
+	case Nil:
+	default:
+		panic(RT.NewError("Argument to map's conj must be a vector with two elements or a map"))
+	}
+}
+
+func (m *TransientMap) Persistent() Object {
+	ensureEditable(m.isFrozen)
+	m.isFrozen = true
+	return m.m
+}
+
+func (s *TransientSet) ToString(escape bool) string {
+	return "#object[TransientSet]"
+}
+
+func (s *TransientSet) Equals(other interface{}) bool {
+	return s == other
+}
+
+func (s *TransientSet) GetInfo() *ObjectInfo {
+	return nil
+}
+
+func (s *TransientSet) GetType() *Type {
+	return TYPE.TransientSet
+}
+
+func (s *TransientSet) Hash() uint32 {
+	return HashPtr(uintptr(unsafe.Pointer(s)))
+}
+
+func (s *TransientSet) WithInfo(info *ObjectInfo) Object {
+	return s
+}
+
+func (s *TransientSet) Count() int {
+	ensureEditable(s.isFrozen)
+	return s.m.Count()
+}
+
+func (s *TransientSet) Get(key Object) (bool, Object) {
+	ensureEditable(s.isFrozen)
+	if ok, _ := s.m.Get(key); ok {
+		return true, key
+	}
+	return false, nil
+}
+
+func (s *TransientSet) conj(obj Object) {
+	ensureEditable(s.isFrozen)
+	if ok, _ := s.m.Get(obj); !ok {
+		s.m.assoc(obj, Boolean{B: true})
+	}
+}
+
+func (s *TransientSet) disj(key Object) {
+	ensureEditable(s.isFrozen)
+	s.m.without(key)
+}
+
+func (s *TransientSet) Persistent() Object {
+	ensureEditable(s.isFrozen)
+	s.isFrozen = true
+	return &MapSet{m: s.m.Persistent().(Map)}
+}
+
+var procTransient = func(args []Object) Object {
+	CheckArity(args, 1, 1)
+	return MakeTransient(args[0])
+}
+
+var procPersistent = func(args []Object) Object {
+	CheckArity(args, 1, 1)
+	return EnsureArgIsTransient(args, 0).Persistent()
+}
+
+var procConjBang = func(args []Object) Object {
+	t := EnsureArgIsTransient(args, 0)
+	for _, obj := range args[1:] {
+		t.conj(obj)
+	}
+	return t
+}
+
+var procAssocBang = func(args []Object) Object {
+	if len(args)%2 == 0 {
+		panic(RT.NewError("assoc! expects even number of arguments after transient, found odd number"))
+	}
+	switch t := args[0].(type) {
+	case *TransientVector:
+		for i := 1; i < len(args); i += 2 {
+			t.assoc(args[i], args[i+1])
+		}
+		return t
+	case *TransientMap:
+		for i := 1; i < len(args); i += 2 {
+			t.assoc(args[i], args[i+1])
+		}
+		return t
+	}
+	panic(FailArg(args[0], "TransientVector or TransientMap", 0))
+}
+
+var procDissocBang = func(args []Object) Object {
+	t := EnsureArgIsTransientMap(args, 0)
+	for _, key := range args[1:] {
+		t.without(key)
+	}
+	return t
+}
+
+var procDisjBang = func(args []Object) Object {
+	t := EnsureArgIsTransientSet(args, 0)
+	for _, key := range args[1:] {
+		t.disj(key)
+	}
+	return t
+}
+
+var procPopBang = func(args []Object) Object {
+	CheckArity(args, 1, 1)
+	t := EnsureArgIsTransientVector(args, 0)
+	t.pop()
+	return t
+}
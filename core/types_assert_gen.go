@@ -620,3 +620,93 @@ func EnsureArgIsChannel(args []Object, index int) *Channel {
 	}
 	panic(FailArg(obj, "Channel", index))
 }
+
+func EnsureObjectIsFuture(obj Object, pattern string) *Future {
+	if c, yes := obj.(*Future); yes {
+		return c
+	}
+	panic(FailObject(obj, "Future", pattern))
+}
+
+func EnsureArgIsFuture(args []Object, index int) *Future {
+	obj := args[index]
+	if c, yes := obj.(*Future); yes {
+		return c
+	}
+	panic(FailArg(obj, "Future", index))
+}
+
+func EnsureObjectIsPromise(obj Object, pattern string) *Promise {
+	if c, yes := obj.(*Promise); yes {
+		return c
+	}
+	panic(FailObject(obj, "Promise", pattern))
+}
+
+func EnsureArgIsPromise(args []Object, index int) *Promise {
+	obj := args[index]
+	if c, yes := obj.(*Promise); yes {
+		return c
+	}
+	panic(FailArg(obj, "Promise", index))
+}
+
+func EnsureObjectIsTransient(obj Object, pattern string) Transient {
+	if c, yes := obj.(Transient); yes {
+		return c
+	}
+	panic(FailObject(obj, "Transient", pattern))
+}
+
+func EnsureArgIsTransient(args []Object, index int) Transient {
+	obj := args[index]
+	if c, yes := obj.(Transient); yes {
+		return c
+	}
+	panic(FailArg(obj, "Transient", index))
+}
+
+func EnsureObjectIsTransientVector(obj Object, pattern string) *TransientVector {
+	if c, yes := obj.(*TransientVector); yes {
+		return c
+	}
+	panic(FailObject(obj, "TransientVector", pattern))
+}
+
+func EnsureArgIsTransientVector(args []Object, index int) *TransientVector {
+	obj := args[index]
+	if c, yes := obj.(*TransientVector); yes {
+		return c
+	}
+	panic(FailArg(obj, "TransientVector", index))
+}
+
+func EnsureObjectIsTransientMap(obj Object, pattern string) *TransientMap {
+	if c, yes := obj.(*TransientMap); yes {
+		return c
+	}
+	panic(FailObject(obj, "TransientMap", pattern))
+}
+
+func EnsureArgIsTransientMap(args []Object, index int) *TransientMap {
+	obj := args[index]
+	if c, yes := obj.(*TransientMap); yes {
+		return c
+	}
+	panic(FailArg(obj, "TransientMap", index))
+}
+
+func EnsureObjectIsTransientSet(obj Object, pattern string) *TransientSet {
+	if c, yes := obj.(*TransientSet); yes {
+		return c
+	}
+	panic(FailObject(obj, "TransientSet", pattern))
+}
+
+func EnsureArgIsTransientSet(args []Object, index int) *TransientSet {
+	obj := args[index]
+	if c, yes := obj.(*TransientSet); yes {
+		return c
+	}
+	panic(FailArg(obj, "TransientSet", index))
+}
@@ -156,3 +156,13 @@ func (x *VectorRSeq) WithInfo(info *ObjectInfo) Object {
 	x.info = info
 	return x
 }
+
+func (x *Range) WithInfo(info *ObjectInfo) Object {
+	x.info = info
+	return x
+}
+
+func (x *SortedMap) WithInfo(info *ObjectInfo) Object {
+	x.info = info
+	return x
+}
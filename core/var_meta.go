@@ -0,0 +1,58 @@
+package core
+
+import "strings"
+
+// VarMeta is the packed metadata of a var interned by a std library.
+// Arglists and Extra hold Joker source (a list of vectors and a map,
+// respectively), so that nothing is built at startup; the meta map is
+// materialized the first time the var's meta is needed (by doc, the
+// linter and so on).
+type VarMeta struct {
+	Arglists string
+	Doc      string
+	Added    string
+	Extra    string
+}
+
+func readPackedMeta(s string) Object {
+	return readFromReader(strings.NewReader(s))
+}
+
+func (vm *VarMeta) unpack(vr *Var) *ArrayMap {
+	var arglists Seq
+	if vm.Arglists != "" {
+		arglists = readPackedMeta(vm.Arglists).(Seq)
+	}
+	res := MakeMeta(arglists, vm.Doc, vm.Added)
+	if vm.Extra != "" {
+		for iter := readPackedMeta(vm.Extra).(Map).Iter(); iter.HasNext(); {
+			p := iter.Next()
+			res.Add(p.Key, p.Value)
+		}
+	}
+	res.Add(KEYWORDS.ns, vr.ns)
+	res.Add(KEYWORDS.name, vr.name)
+	return res
+}
+
+// InternVarPacked is like InternVar, but defers building the var's
+// meta until it's asked for.
+func (ns *Namespace) InternVarPacked(name string, val Object, meta *VarMeta) *Var {
+	vr := ns.Intern(MakeSymbol(name))
+	vr.Value = val
+	vr.meta = nil
+	vr.packedMeta = meta
+	return vr
+}
+
+func (v *Var) unpackMeta() {
+	if v.packedMeta != nil {
+		v.meta = v.packedMeta.unpack(v)
+		v.packedMeta = nil
+	}
+}
+
+func (v *Var) GetMeta() Map {
+	v.unpackMeta()
+	return v.meta
+}
@@ -14,6 +14,7 @@ type (
 		tail  []interface{}
 		count int
 		shift uint
+		hash  uint32
 	}
 	VectorSeq struct {
 		InfoHolder
@@ -27,6 +28,10 @@ type (
 		vector *Vector
 		index  int
 	}
+	VectorIterator struct {
+		vector *Vector
+		index  int
+	}
 )
 
 var empty_node []interface{} = make([]interface{}, 32)
@@ -141,7 +146,10 @@ func (v *Vector) GetType() *Type {
 }
 
 func (v *Vector) Hash() uint32 {
-	return hashOrdered(v.Seq())
+	if v.hash == 0 {
+		v.hash = hashOrdered(v.Seq())
+	}
+	return v.hash
 }
 
 func (seq *VectorSeq) Seq() Seq {
@@ -156,8 +164,8 @@ func (vseq *VectorSeq) ToString(escape bool) string {
 	return SeqToString(vseq, escape)
 }
 
-func (seq *VectorSeq) Pprint(w io.Writer, indent int) int {
-	return pprintSeq(seq, w, indent)
+func (seq *VectorSeq) Pprint(pp *PrettyPrinter, w io.Writer, indent int) int {
+	return pprintSeq(pp, seq, w, indent)
 }
 
 func (seq *VectorSeq) Format(w io.Writer, indent int) int {
@@ -202,6 +210,20 @@ func (vseq *VectorSeq) Cons(obj Object) Seq {
 
 func (vseq *VectorSeq) sequential() {}
 
+func (vseq *VectorSeq) Iter() Iterator {
+	return &VectorIterator{vector: vseq.vector, index: vseq.index}
+}
+
+func (iter *VectorIterator) HasNext() bool {
+	return iter.index < iter.vector.count
+}
+
+func (iter *VectorIterator) Next() Object {
+	res := iter.vector.at(iter.index)
+	iter.index++
+	return res
+}
+
 func (seq *VectorRSeq) Seq() Seq {
 	return seq
 }
@@ -214,8 +236,8 @@ func (vseq *VectorRSeq) ToString(escape bool) string {
 	return SeqToString(vseq, escape)
 }
 
-func (seq *VectorRSeq) Pprint(w io.Writer, indent int) int {
-	return pprintSeq(seq, w, indent)
+func (seq *VectorRSeq) Pprint(pp *PrettyPrinter, w io.Writer, indent int) int {
+	return pprintSeq(pp, seq, w, indent)
 }
 
 func (seq *VectorRSeq) Format(w io.Writer, indent int) int {
@@ -264,6 +286,10 @@ func (v *Vector) Seq() Seq {
 	return &VectorSeq{vector: v, index: 0}
 }
 
+func (v *Vector) Iter() Iterator {
+	return &VectorIterator{vector: v, index: 0}
+}
+
 func (v *Vector) Conj(obj Object) Conjable {
 	return v.Conjoin(obj)
 }
@@ -459,6 +485,45 @@ func NewVectorFromSeq(seq Seq) *Vector {
 	return res
 }
 
+// NewVectorFromSlice builds the tree of the vector directly from objs,
+// which is much cheaper than conjoining them one at a time.
+func NewVectorFromSlice(objs []Object) *Vector {
+	count := len(objs)
+	tailoff := 0
+	if count > 0 {
+		tailoff = ((count - 1) >> 5) << 5
+	}
+	tail := make([]interface{}, count-tailoff, 32)
+	for i := range tail {
+		tail[i] = objs[tailoff+i]
+	}
+	if tailoff == 0 {
+		return &Vector{count: count, shift: 5, root: empty_node, tail: tail}
+	}
+	var nodes []interface{}
+	for i := 0; i < tailoff; i += 32 {
+		leaf := make([]interface{}, 32)
+		for j := range leaf {
+			leaf[j] = objs[i+j]
+		}
+		nodes = append(nodes, leaf)
+	}
+	shift := uint(5)
+	for len(nodes) > 32 {
+		var parents []interface{}
+		for i := 0; i < len(nodes); i += 32 {
+			parent := make([]interface{}, 32)
+			copy(parent, nodes[i:])
+			parents = append(parents, parent)
+		}
+		nodes = parents
+		shift += 5
+	}
+	root := make([]interface{}, 32)
+	copy(root, nodes)
+	return &Vector{count: count, shift: shift, root: root, tail: tail}
+}
+
 func (v *Vector) Empty() Collection {
 	return EmptyVector()
 }
@@ -471,17 +536,9 @@ func (v *Vector) kvreduce(c Callable, init Object) Object {
 	return res
 }
 
-func (v *Vector) Pprint(w io.Writer, indent int) int {
-	ind := indent + 1
+func (v *Vector) Pprint(pp *PrettyPrinter, w io.Writer, indent int) int {
 	fmt.Fprint(w, "[")
-	if v.count > 0 {
-		for i := 0; i < v.count-1; i++ {
-			pprintObject(v.at(i), indent+1, w)
-			fmt.Fprint(w, "\n")
-			writeIndent(w, indent+1)
-		}
-		ind = pprintObject(v.at(v.count-1), indent+1, w)
-	}
+	ind := pprintElements(pp, ToSlice(v.Seq()), w, indent+1)
 	fmt.Fprint(w, "]")
 	return ind + 1
 }
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// gitignore holds the patterns of the .gitignore file in dir, which
+// apply to the paths under dir.
+type gitignore struct {
+	dir      string
+	patterns []gitignorePattern
+}
+
+type gitignorePattern struct {
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// readGitignore returns the patterns of dir's .gitignore, or nil if it
+// has none.
+func readGitignore(dir string) *gitignore {
+	f, err := os.Open(filepath.Join(dir, ".gitignore"))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	g := &gitignore{dir: dir}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var p gitignorePattern
+		if strings.HasPrefix(line, "!") {
+			p.negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			p.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		// Patterns with a slash (other than a trailing one) are relative
+		// to dir, others match at any depth.
+		prefix := "^(.*/)?"
+		if strings.Contains(line, "/") {
+			prefix = "^"
+			line = strings.TrimPrefix(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		if re, err := regexp.Compile(prefix + globToRegexp(line) + "$"); err == nil {
+			p.re = re
+			g.patterns = append(g.patterns, p)
+		}
+	}
+	return g
+}
+
+// globToRegexp translates a gitignore glob, in which * and ? don't
+// match slashes but ** matches any number of directories.
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			if j := strings.IndexByte(glob[i:], ']'); j > 0 {
+				class := glob[i+1 : i+j]
+				if strings.HasPrefix(class, "!") {
+					class = "^" + class[1:]
+				}
+				b.WriteString("[" + class + "]")
+				i += j
+			} else {
+				b.WriteString(`\[`)
+			}
+		case c == '\\' && i+1 < len(glob):
+			i++
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		default:
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	return b.String()
+}
+
+// match returns whether a pattern of g matches path and, if so,
+// whether the last one that does ignores it (rather than negating an
+// earlier match).
+func (g *gitignore) match(path string, isDir bool) (matched bool, ignored bool) {
+	rel, err := filepath.Rel(g.dir, path)
+	if err != nil {
+		return false, false
+	}
+	rel = filepath.ToSlash(rel)
+	for _, p := range g.patterns {
+		if (!p.dirOnly || isDir) && p.re.MatchString(rel) {
+			matched, ignored = true, !p.negate
+		}
+	}
+	return matched, ignored
+}
+
+// isGitignored returns whether path is ignored by the .gitignore files
+// read so far in its parent directories up to root, with those deeper
+// down taking precedence.
+func isGitignored(gitignores map[string]*gitignore, root string, path string, isDir bool) bool {
+	var dirs []string
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		dirs = append(dirs, dir)
+		if dir == root || dir == filepath.Dir(dir) {
+			break
+		}
+	}
+	ignored := false
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if g := gitignores[dirs[i]]; g != nil {
+			if m, ign := g.match(path, isDir); m {
+				ignored = ign
+			}
+		}
+	}
+	return ignored
+}
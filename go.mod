@@ -1,13 +1,49 @@
 module github.com/candid82/joker
 
-go 1.14
+go 1.22
 
 require (
+	github.com/andybalholm/brotli v1.1.0
 	github.com/candid82/liner v1.4.0
+	github.com/fsnotify/fsnotify v1.9.0
+	github.com/go-sql-driver/mysql v1.6.0
 	github.com/jcburley/go-spew v1.3.0
+	github.com/klauspost/compress v1.18.0
+	github.com/lib/pq v1.10.2
 	github.com/pkg/profile v1.2.1
 	github.com/yuin/goldmark v1.3.2
 	go.etcd.io/bbolt v1.3.3
-	golang.org/x/sys v0.1.0 // indirect
+	golang.org/x/text v0.3.6
 	gopkg.in/yaml.v2 v2.4.0
+	modernc.org/sqlite v1.10.8
+)
+
+require (
+	github.com/dustin/go-humanize v1.0.0 // indirect
+	github.com/google/go-cmp v0.5.3 // indirect
+	github.com/kballard/go-shellquote v0.0.0-20180428030007-95032a82bc51 // indirect
+	github.com/mattn/go-isatty v0.0.12 // indirect
+	github.com/mattn/go-runewidth v0.0.3 // indirect
+	github.com/mattn/go-sqlite3 v1.14.6 // indirect
+	github.com/remyoudompheng/bigfft v0.0.0-20200410134404-eec4a21b6bb0 // indirect
+	golang.org/x/crypto v0.0.0-20210513164829-c07d793c2f9a // indirect
+	golang.org/x/mod v0.3.0 // indirect
+	golang.org/x/net v0.0.0-20210226172049-e18ecbb05110 // indirect
+	golang.org/x/sync v0.0.0-20201020160332-67f06af15bc9 // indirect
+	golang.org/x/sys v0.13.0 // indirect
+	golang.org/x/term v0.0.0-20201126162022-7de9c90e9dd1 // indirect
+	golang.org/x/tools v0.0.0-20201124115921-2c860bdd6e78 // indirect
+	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
+	gopkg.in/check.v1 v0.0.0-20161208181325-20d25e280405 // indirect
+	modernc.org/cc/v3 v3.33.5 // indirect
+	modernc.org/ccgo/v3 v3.9.4 // indirect
+	modernc.org/httpfs v1.0.6 // indirect
+	modernc.org/libc v1.9.5 // indirect
+	modernc.org/mathutil v1.2.2 // indirect
+	modernc.org/memory v1.0.4 // indirect
+	modernc.org/opt v0.1.1 // indirect
+	modernc.org/strutil v1.1.0 // indirect
+	modernc.org/tcl v1.5.2 // indirect
+	modernc.org/token v1.0.0 // indirect
+	modernc.org/z v1.0.1 // indirect
 )
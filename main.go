@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -15,22 +16,35 @@ import (
 	"strings"
 
 	. "github.com/candid82/joker/core"
+	_ "github.com/candid82/joker/std/ansi"
 	_ "github.com/candid82/joker/std/base64"
+	_ "github.com/candid82/joker/std/bits"
 	_ "github.com/candid82/joker/std/bolt"
+	_ "github.com/candid82/joker/std/compress"
 	_ "github.com/candid82/joker/std/crypto"
 	_ "github.com/candid82/joker/std/csv"
+	_ "github.com/candid82/joker/std/diff"
 	_ "github.com/candid82/joker/std/filepath"
+	_ "github.com/candid82/joker/std/hash"
 	_ "github.com/candid82/joker/std/hex"
 	_ "github.com/candid82/joker/std/html"
 	_ "github.com/candid82/joker/std/http"
 	_ "github.com/candid82/joker/std/io"
 	_ "github.com/candid82/joker/std/json"
+	_ "github.com/candid82/joker/std/kv"
+	_ "github.com/candid82/joker/std/log"
 	_ "github.com/candid82/joker/std/markdown"
 	_ "github.com/candid82/joker/std/math"
+	_ "github.com/candid82/joker/std/numfmt"
 	_ "github.com/candid82/joker/std/os"
+	_ "github.com/candid82/joker/std/progress"
+	_ "github.com/candid82/joker/std/rand"
+	_ "github.com/candid82/joker/std/readline"
 	_ "github.com/candid82/joker/std/runtime"
+	_ "github.com/candid82/joker/std/sql"
 	_ "github.com/candid82/joker/std/strconv"
 	_ "github.com/candid82/joker/std/string"
+	_ "github.com/candid82/joker/std/term"
 	_ "github.com/candid82/joker/std/time"
 	_ "github.com/candid82/joker/std/url"
 	_ "github.com/candid82/joker/std/uuid"
@@ -130,9 +144,26 @@ func processFile(filename string, phase Phase) error {
 	if saveForRepl {
 		reader = NewReader(&replayable{reader}, "<replay>")
 	}
+	if snapshotFilename != "" && phase == EVAL {
+		return WriteSnapshot(reader, filename, snapshotFilename)
+	}
 	return ProcessReader(reader, filename, phase)
 }
 
+// dumpAPI prints the special forms and the public vars of the namespaces
+// that come with Joker (see joker.repl/api) as EDN or JSON, for editor
+// tooling.
+func dumpAPI(format string) {
+	expr := "(prn (joker.repl/api))"
+	if format == "json" {
+		expr = "(println (joker.json/write-string (joker.repl/api)))"
+	}
+	reader := NewReader(strings.NewReader("(require 'joker.repl 'joker.json) "+expr), "<dump-api>")
+	if err := ProcessReader(reader, "", EVAL); err != nil {
+		ExitJoker(1)
+	}
+}
+
 func skipRestOfLine(reader *Reader) {
 	for {
 		switch reader.Get() {
@@ -143,19 +174,19 @@ func skipRestOfLine(reader *Reader) {
 }
 
 func processReplCommand(reader *Reader, phase Phase, parseContext *ParseContext, replContext *ReplContext) (exit bool) {
-
+	s := RT.SaveCallState()
 	defer func() {
 		if r := recover(); r != nil {
-			switch r := r.(type) {
+			switch r := RT.Recovered(r, s).(type) {
 			case *ParseError:
 				replContext.PushException(r)
-				fmt.Fprintln(Stderr, r)
+				PrintError(GLOBAL_ENV.Err(), r)
 			case *EvalError:
 				replContext.PushException(r)
-				fmt.Fprintln(Stderr, r)
+				PrintError(GLOBAL_ENV.Err(), r)
 			case Error:
 				replContext.PushException(r)
-				fmt.Fprintln(Stderr, r)
+				fmt.Fprintln(GLOBAL_ENV.Err(), r)
 				// case *runtime.TypeAssertionError:
 				// 	fmt.Fprintln(Stderr, r)
 			default:
@@ -169,7 +200,7 @@ func processReplCommand(reader *Reader, phase Phase, parseContext *ParseContext,
 		return true
 	}
 	if err != nil {
-		fmt.Fprintln(Stderr, err)
+		fmt.Fprintln(GLOBAL_ENV.Err(), err)
 		skipRestOfLine(reader)
 		return
 	}
@@ -187,8 +218,9 @@ func processReplCommand(reader *Reader, phase Phase, parseContext *ParseContext,
 
 	res := Eval(expr, nil)
 	replContext.PushValue(res)
-	PrintObject(res, Stdout)
-	fmt.Fprintln(Stdout, "")
+	out := GLOBAL_ENV.Out()
+	PrintObject(res, out)
+	fmt.Fprintln(out, "")
 	return false
 }
 
@@ -264,18 +296,32 @@ func makeDialectKeyword(dialect Dialect) Keyword {
 	}
 }
 
-func configureLinterMode(dialect Dialect, filename string, workingDir string) {
+func configureLinterMode(dialect Dialect, custom *CustomDialect, filename string, workingDir string) {
+	if custom != nil {
+		custom.Apply()
+	}
 	ProcessLinterData(dialect)
 	ProcessLinterFiles(dialect, filename, workingDir)
 	if dialect != JOKER {
 		RemoveJokerNamespaces()
 	}
+	if custom != nil {
+		custom.DeclareKnownSymbols()
+	}
 	GLOBAL_ENV.CoreNamespace.Resolve("*loaded-libs*").Value = EmptySet()
 	LINTER_MODE = true
 	DIALECT = dialect
 	lm, _ := GLOBAL_ENV.Resolve(MakeSymbol("joker.core/*linter-mode*"))
 	lm.Value = Boolean{B: true}
-	GLOBAL_ENV.Features = GLOBAL_ENV.Features.Disjoin(MakeKeyword("joker")).Conj(makeDialectKeyword(dialect)).(Set)
+	features := GLOBAL_ENV.Features.Disjoin(MakeKeyword("joker"))
+	if custom != nil && len(custom.Features) > 0 {
+		for _, f := range custom.Features {
+			features = features.Conj(f).(Set)
+		}
+	} else {
+		features = features.Conj(makeDialectKeyword(dialect)).(Set)
+	}
+	GLOBAL_ENV.Features = features
 	EnableIdentValidation()
 }
 
@@ -291,20 +337,60 @@ func detectDialect(filename string) Dialect {
 	return CLJ
 }
 
-func lintFile(filename string, dialect Dialect, workingDir string) {
-	phase := PARSE
-	if dialect == EDN {
-		phase = READ
+// resolveDialect returns the custom dialect named by --dialect or, if
+// none was given, the one filename's extension is registered for in
+// .joker (if any), together with the builtin dialect to lint as. It
+// must be called after ReadConfig.
+func resolveDialect(dialect Dialect, filename string) (Dialect, *CustomDialect) {
+	var custom *CustomDialect
+	switch {
+	case customDialect != "":
+		custom = CUSTOM_DIALECTS[customDialect]
+		if custom == nil {
+			fmt.Fprintf(Stderr, "Error: Unknown dialect '%s'.\n", customDialect)
+			ExitJoker(19)
+		}
+	case dialect == UNKNOWN:
+		custom = CustomDialectForFile(filename)
 	}
+	if custom != nil {
+		return custom.Base, custom
+	}
+	if dialect == UNKNOWN {
+		dialect = detectDialect(filename)
+	}
+	return dialect, nil
+}
+
+// fileLinter configures the linter for filename, returning the function
+// that lints it.
+func fileLinter(filename string, dialect Dialect, workingDir string) func() {
 	ReadConfig(filename, workingDir)
-	configureLinterMode(dialect, filename, workingDir)
-	if processFile(filename, phase) == nil {
-		WarnOnUnusedNamespaces()
-		WarnOnUnusedVars()
+	dialect, custom := resolveDialect(dialect, filename)
+	configureLinterMode(dialect, custom, filename, workingDir)
+	return filePass(filename, dialect)
+}
+
+// filePass returns the function that lints filename as dialect, once the
+// linter is configured.
+func filePass(filename string, dialect Dialect) func() {
+	phase := lintPhase(dialect)
+	return func() {
+		CachedLint(filename, func() error {
+			err := processFile(filename, phase)
+			if err == nil {
+				WarnOnUnusedNamespaces()
+				WarnOnUnusedVars()
+			}
+			return err
+		})
 	}
 }
 
-func matchesDialect(path string, dialect Dialect) bool {
+func matchesDialect(path string, dialect Dialect, custom *CustomDialect) bool {
+	if custom != nil {
+		return strings.HasSuffix(path, custom.Extension)
+	}
 	ext := ".clj"
 	switch dialect {
 	case CLJS:
@@ -329,38 +415,124 @@ func isIgnored(path string) bool {
 	return false
 }
 
-func lintDir(dirname string, dialect Dialect, reportGloballyUnused bool) {
-	var processErr error
-	phase := PARSE
+var errProblemLimit = errors.New("problem limit reached")
+
+func lintPhase(dialect Dialect) Phase {
 	if dialect == EDN {
-		phase = READ
+		return READ
 	}
-	ns := GLOBAL_ENV.CurrentNamespace()
+	return PARSE
+}
+
+// dirLinter configures the linter for the directory dirname, returning
+// the function that lints the files of its dialect under it.
+func dirLinter(dirname string, dialect Dialect, reportGloballyUnused bool) func() {
 	ReadConfig("", dirname)
-	configureLinterMode(dialect, "", dirname)
-	filepath.Walk(dirname, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			fmt.Fprintln(Stderr, "Error: ", err)
+	dialect, custom := resolveDialect(dialect, "")
+	configureLinterMode(dialect, custom, "", dirname)
+	return dirPass(dirname, dialect, custom, reportGloballyUnused)
+}
+
+// dirPass returns the function that lints the files of dialect (or
+// custom) under dirname, once the linter is configured.
+func dirPass(dirname string, dialect Dialect, custom *CustomDialect, reportGloballyUnused bool) func() {
+	ns := GLOBAL_ENV.CurrentNamespace()
+	phase := lintPhase(dialect)
+	return func() {
+		var processErr error
+		root := filepath.Clean(dirname)
+		gitignores := map[string]*gitignore{}
+		filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if MAX_PROBLEMS > 0 && PROBLEM_COUNT >= MAX_PROBLEMS {
+				return errProblemLimit
+			}
+			if err != nil {
+				fmt.Fprintln(Stderr, "Error: ", err)
+				return nil
+			}
+			if info.IsDir() {
+				if path != root && (info.Name() == ".git" || isGitignored(gitignores, root, path, true)) {
+					return filepath.SkipDir
+				}
+				gitignores[path] = readGitignore(path)
+				return nil
+			}
+			if matchesDialect(path, dialect, custom) && !isIgnored(path) && !isGitignored(gitignores, root, path, false) {
+				GLOBAL_ENV.CoreNamespace.Resolve("*loaded-libs*").Value = EmptySet()
+				processErr = CachedLint(path, func() error {
+					err := processFile(path, phase)
+					if err == nil {
+						WarnOnUnusedNamespaces()
+						WarnOnUnusedVars()
+					}
+					return err
+				})
+				ResetUsage()
+				GLOBAL_ENV.SetCurrentNamespace(ns)
+			}
 			return nil
+		})
+		if processErr == nil && reportGloballyUnused {
+			WarnOnGloballyUnusedNamespaces()
+			WarnOnGloballyUnusedVars()
 		}
-		if !info.IsDir() && matchesDialect(path, dialect) && !isIgnored(path) {
-			GLOBAL_ENV.CoreNamespace.Resolve("*loaded-libs*").Value = EmptySet()
-			processErr = processFile(path, phase)
-			if processErr == nil {
-				WarnOnUnusedNamespaces()
-				WarnOnUnusedVars()
-			}
-			ResetUsage()
-			GLOBAL_ENV.SetCurrentNamespace(ns)
+	}
+}
+
+// lint lints the file or directory given on the command line (and, with
+// --watch, the ones following it), exiting with --internal-error-exit-code
+// if the linter itself fails.
+func lint() {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(Stderr, "Internal error: %v\n", r)
+			ExitJoker(internalErrorExitCode)
+		}
+	}()
+	var pass func()
+	if filename != "" {
+		pass = fileLinter(filename, dialect, workingDir)
+	} else {
+		pass = dirLinter(workingDir, dialect, reportGloballyUnusedFlag)
+	}
+	if watchFlag {
+		path := filename
+		if path == "" {
+			path = workingDir
+		}
+		watchLint(append([]string{path}, remainingArgs...), withExtraPaths(pass, remainingArgs))
+		return
+	}
+	pass()
+	if LINT_FORMAT != "text" {
+		if err := WriteLintProblems(Stdout); err != nil {
+			panic(err)
 		}
-		return nil
-	})
-	if processErr == nil && reportGloballyUnused {
-		WarnOnGloballyUnusedNamespaces()
-		WarnOnGloballyUnusedVars()
 	}
 }
 
+// lintExitCode returns the exit code reflecting the problems the linter
+// found: --errors-exit-code if there were errors (or any other failing
+// problems, with --warnings-as-errors), --warnings-exit-code if there
+// were only warnings (and infos, with --fail-on info), and 0 otherwise,
+// including when there were only warnings with --fail-on error.
+func lintExitCode() int {
+	failing := 0
+	switch failOn {
+	case "warning":
+		failing = PROBLEM_COUNT - ERROR_COUNT - INFO_COUNT
+	case "info":
+		failing = PROBLEM_COUNT - ERROR_COUNT
+	}
+	switch {
+	case ERROR_COUNT > 0 || (warningsAsErrors && failing > 0):
+		return errorsExitCode
+	case failing > 0:
+		return warningsExitCode
+	}
+	return 0
+}
+
 func dialectFromArg(arg string) Dialect {
 	switch strings.ToLower(arg) {
 	case "clj":
@@ -415,13 +587,52 @@ func usage(out io.Writer) {
 	fmt.Fprintln(out, "    Disable readline functionality in the repl. Useful when using rlwrap.")
 	fmt.Fprintln(out, "  --no-repl-history")
 	fmt.Fprintln(out, "    Do not read or save repl command history to a file.")
+	fmt.Fprintln(out, "  --dump-api <format>")
+	fmt.Fprintln(out, "    Print the special forms and the public vars of Joker's namespaces (with arglists,")
+	fmt.Fprintln(out, "    docstrings etc.) as \"edn\" or \"json\", for editors and completion engines.")
+	fmt.Fprintln(out, "  --lsp")
+	fmt.Fprintln(out, "    Run a Language Server Protocol server on stdin and stdout, for editors: it provides the")
+	fmt.Fprintln(out, "    linter's diagnostics, hover docs, go-to-definition and completion.")
+	fmt.Fprintln(out, "  --snapshot <file>")
+	fmt.Fprintln(out, "    While evaluating <filename>, save its parsed forms to <file>.")
+	fmt.Fprintln(out, "  --from-snapshot <file>")
+	fmt.Fprintln(out, "    Evaluate the forms saved by --snapshot, skipping reading and parsing")
+	fmt.Fprintln(out, "    (pass script args after --).")
+	fmt.Fprintln(out, "  --retain-source")
+	fmt.Fprintln(out, "    Keep the source of vars defined from files, for joker.repl/source.")
+	fmt.Fprintln(out, "  --ns-cache")
+	fmt.Fprintln(out, "    Cache the parsed forms of loaded libs in ~/.joker-cache, to load them faster next time.")
+	fmt.Fprintln(out, "  --no-compile")
+	fmt.Fprintln(out, "    Evaluate fn bodies with the tree-walking evaluator instead of compiling them (for debugging).")
 	fmt.Fprintln(out, "  --working-dir <directory>")
 	fmt.Fprintln(out, "    Specify directory to lint or working directory for lint configuration if linting single file (requires --lint).")
 	fmt.Fprintln(out, "  --report-globally-unused")
-	fmt.Fprintln(out, "    Report globally unused namespaces and public vars when linting directories (requires --lint and --working-dir).")
+	fmt.Fprintln(out, "    Report globally unused namespaces and public vars when linting directories (requires --lint and --working-dir;")
+	fmt.Fprintln(out, "    implied by passing the directory itself, as in joker --lint <dirname>).")
+	fmt.Fprintln(out, "  --format=<format>")
+	fmt.Fprintln(out, "    Print lint problems to stderr as \"text\" (the default), or to stdout as \"sarif\" (2.1.0),")
+	fmt.Fprintln(out, "    \"json\" or \"edn\" (requires --lint).")
+	fmt.Fprintln(out, "  --lint-cache")
+	fmt.Fprintln(out, "    Cache what the linter finds in each file in ~/.joker-cache/lint, to skip unchanged files next time (requires --lint).")
+	fmt.Fprintln(out, "  --watch")
+	fmt.Fprintln(out, "    Lint again whenever the linted file, or a file under the linted directory, changes, printing")
+	fmt.Fprintln(out, "    only the problems that are new (prefixed with \"+ \") or gone (\"- \"); requires --lint.")
+	fmt.Fprintln(out, "    More files and directories to lint and watch may follow the first one.")
+	fmt.Fprintln(out, "  --max-problems <n>")
+	fmt.Fprintln(out, "    Stop linting after <n> problems have been reported (default 0, no limit).")
+	fmt.Fprintln(out, "  --warnings-as-errors")
+	fmt.Fprintln(out, "    Exit with the errors exit code even if the linter found only warnings.")
+	fmt.Fprintln(out, "  --fail-on <severity>")
+	fmt.Fprintln(out, "    Lowest severity of problems (\"error\", \"warning\" or \"info\") the linter exits non-zero for (default \"warning\").")
+	fmt.Fprintln(out, "  --warnings-exit-code <rc>")
+	fmt.Fprintln(out, "    Exit code when the linter found warnings but no errors (default 1).")
+	fmt.Fprintln(out, "  --errors-exit-code <rc>")
+	fmt.Fprintln(out, "    Exit code when the linter found errors (default 1).")
+	fmt.Fprintln(out, "  --internal-error-exit-code <rc>")
+	fmt.Fprintln(out, "    Exit code when the linter itself fails (default 2).")
 	fmt.Fprintln(out, "  --dialect <dialect>")
-	fmt.Fprintln(out, "    Set input dialect (\"clj\", \"cljs\", \"joker\", \"edn\") for linting;")
-	fmt.Fprintln(out, "    default is inferred from <filename> suffix, if any.")
+	fmt.Fprintln(out, "    Set input dialect (\"clj\", \"cljs\", \"joker\", \"edn\", or one registered in")
+	fmt.Fprintln(out, "    :dialects in .joker) for linting; default is inferred from <filename> suffix, if any.")
 	fmt.Fprintln(out, "  --hashmap-threshold <n>")
 	fmt.Fprintln(out, "    Set HASHMAP_THRESHOLD accordingly (internal magic of some sort).")
 	fmt.Fprintln(out, "  --profiler <type>")
@@ -463,6 +674,17 @@ var (
 	exitToRepl               bool
 	errorToRepl              bool
 	writeFlag                bool
+	warningsAsErrors         bool
+	failOn                       = "warning"
+	warningsExitCode         int = 1
+	errorsExitCode           int = 1
+	internalErrorExitCode    int = 2
+	dumpAPIFormat            string
+	lspFlag                  bool
+	watchFlag                bool
+	customDialect            string
+	snapshotFilename         string
+	fromSnapshotFilename     string
 )
 
 func isNumber(s string) bool {
@@ -535,6 +757,8 @@ func parseArgs(args []string) {
 			versionFlag = true
 		case "--format":
 			phase = FORMAT
+		case "--format=text", "--format=sarif", "--format=json", "--format=edn":
+			LINT_FORMAT = strings.TrimPrefix(args[i], "--format=")
 		case "--write":
 			writeFlag = true
 		case "--read":
@@ -570,6 +794,97 @@ func parseArgs(args []string) {
 			if i < length-1 && notOption(args[i+1]) {
 				i += 1 // shift
 				dialect = dialectFromArg(args[i])
+				if dialect == UNKNOWN {
+					customDialect = args[i]
+				}
+			} else {
+				missing = true
+			}
+		case "--dump-api":
+			if i < length-1 && notOption(args[i+1]) {
+				i += 1 // shift
+				dumpAPIFormat = strings.ToLower(args[i])
+				if dumpAPIFormat != "edn" && dumpAPIFormat != "json" {
+					fmt.Fprintf(Stderr, "Error: Unsupported --dump-api format '%s'; use 'edn' or 'json'.\n", args[i])
+					ExitJoker(18)
+				}
+			} else {
+				missing = true
+			}
+		case "--lsp":
+			lspFlag = true
+		case "--snapshot", "--from-snapshot":
+			if i < length-1 && notOption(args[i+1]) {
+				i += 1 // shift
+				if args[i-1] == "--snapshot" {
+					snapshotFilename = args[i]
+				} else {
+					fromSnapshotFilename = args[i]
+				}
+			} else {
+				missing = true
+			}
+		case "--retain-source":
+			RETAIN_SOURCE = true
+		case "--no-compile":
+			COMPILE = false
+		case "--ns-cache":
+			dir, err := DefaultNSCacheDir()
+			if err != nil {
+				fmt.Fprintf(Stderr, "Error: cannot locate the namespace cache: %s\n", err)
+				ExitJoker(21)
+			}
+			NS_CACHE_DIR = dir
+		case "--lint-cache":
+			dir, err := DefaultLintCacheDir()
+			if err != nil {
+				fmt.Fprintf(Stderr, "Error: cannot locate the lint cache: %s\n", err)
+				ExitJoker(21)
+			}
+			LINT_CACHE_DIR = dir
+		case "--watch":
+			watchFlag = true
+		case "--max-problems":
+			if i < length-1 && notOption(args[i+1]) {
+				i += 1 // shift
+				n, err := strconv.Atoi(args[i])
+				if err != nil {
+					fmt.Fprintln(Stderr, "Error: ", err)
+					return
+				}
+				MAX_PROBLEMS = n
+			} else {
+				missing = true
+			}
+		case "--warnings-as-errors":
+			warningsAsErrors = true
+		case "--fail-on":
+			if i < length-1 && notOption(args[i+1]) {
+				i += 1 // shift
+				failOn = args[i]
+				if failOn != "error" && failOn != "warning" && failOn != "info" {
+					fmt.Fprintf(Stderr, "Error: --fail-on must be error, warning or info, got %s.\n", failOn)
+					return
+				}
+			} else {
+				missing = true
+			}
+		case "--warnings-exit-code", "--errors-exit-code", "--internal-error-exit-code":
+			if i < length-1 && notOption(args[i+1]) {
+				i += 1 // shift
+				rc, err := strconv.Atoi(args[i])
+				if err != nil {
+					fmt.Fprintln(Stderr, "Error: ", err)
+					return
+				}
+				switch args[i-1] {
+				case "--warnings-exit-code":
+					warningsExitCode = rc
+				case "--errors-exit-code":
+					errorsExitCode = rc
+				default:
+					internalErrorExitCode = rc
+				}
 			} else {
 				missing = true
 			}
@@ -724,7 +1039,8 @@ func main() {
 
 	saveForRepl = saveForRepl && (exitToRepl || errorToRepl) // don't bother saving stuff if no repl
 
-	RT.GIL.Lock()
+	RT.GIL.Lock(nil)
+	defer Shutdown()
 	ProcessCoreData()
 
 	GLOBAL_ENV.ReferCoreToUser()
@@ -737,6 +1053,10 @@ func main() {
 		fmt.Fprintf(debugOut, "versionFlag=%v\n", versionFlag)
 		fmt.Fprintf(debugOut, "phase=%v\n", phase)
 		fmt.Fprintf(debugOut, "lintFlag=%v\n", lintFlag)
+		fmt.Fprintf(debugOut, "LINT_FORMAT=%v\n", LINT_FORMAT)
+		fmt.Fprintf(debugOut, "LINT_CACHE_DIR=%v\n", LINT_CACHE_DIR)
+		fmt.Fprintf(debugOut, "lspFlag=%v\n", lspFlag)
+		fmt.Fprintf(debugOut, "watchFlag=%v\n", watchFlag)
 		fmt.Fprintf(debugOut, "reportGloballyUnusedFlag=%v\n", reportGloballyUnusedFlag)
 		fmt.Fprintf(debugOut, "dialect=%v\n", dialect)
 		fmt.Fprintf(debugOut, "workingDir=%v\n", workingDir)
@@ -764,8 +1084,25 @@ func main() {
 		return
 	}
 
+	if dumpAPIFormat != "" {
+		dumpAPI(dumpAPIFormat)
+		return
+	}
+
+	if lspFlag {
+		if lintFlag || filename != "" {
+			fmt.Fprintf(Stderr, "Error: Cannot combine --lsp and --lint or a <filename> argument.\n")
+			ExitJoker(25)
+		}
+		if err := LSP(Stdin, Stdout); err != nil {
+			fmt.Fprintf(Stderr, "Error: %s\n", err)
+			ExitJoker(1)
+		}
+		return
+	}
+
 	if len(remainingArgs) > 0 {
-		if lintFlag {
+		if lintFlag && !watchFlag {
 			fmt.Fprintf(Stderr, "Error: Cannot provide arguments to code while linting it.\n")
 			ExitJoker(4)
 		}
@@ -807,6 +1144,10 @@ func main() {
 		defer finish()
 	}
 
+	if !lintFlag {
+		LoadClassPathDataReaders(filepath.Dir(filename))
+	}
+
 	if eval != "" {
 		if lintFlag {
 			fmt.Fprintf(Stderr, "Error: Cannot combine --eval/-e and --lint.\n")
@@ -856,19 +1197,36 @@ func main() {
 			fmt.Fprintf(Stderr, "Error: Cannot combine --lint and --error-to-repl.\n")
 			ExitJoker(15)
 		}
-		if dialect == UNKNOWN {
-			dialect = detectDialect(filename)
-		}
-		if filename != "" {
-			lintFile(filename, dialect, workingDir)
-		} else if workingDir != "" {
-			lintDir(workingDir, dialect, reportGloballyUnusedFlag)
-		} else {
+		if filename == "" && workingDir == "" {
 			fmt.Fprintf(Stderr, "Error: Missing --file or --working-dir argument.\n")
 			ExitJoker(16)
 		}
-		if PROBLEM_COUNT > 0 {
-			ExitJoker(1)
+		if info, err := os.Stat(filename); err == nil && info.IsDir() {
+			// Project-wide mode: lint the whole directory, reporting
+			// what none of its files use.
+			if workingDir != "" {
+				fmt.Fprintf(Stderr, "Error: Cannot combine --working-dir and a directory argument.\n")
+				ExitJoker(23)
+			}
+			workingDir = filename
+			filename = ""
+			reportGloballyUnusedFlag = true
+		}
+		if watchFlag {
+			for _, path := range append([]string{filename}, remainingArgs...) {
+				if path == "-" {
+					fmt.Fprintf(Stderr, "Error: Cannot watch stdin.\n")
+					ExitJoker(27)
+				}
+			}
+		}
+		if watchFlag && LINT_FORMAT != "text" {
+			fmt.Fprintf(Stderr, "Error: Cannot combine --watch and --format=%s.\n", LINT_FORMAT)
+			ExitJoker(27)
+		}
+		lint()
+		if rc := lintExitCode(); rc != 0 {
+			ExitJoker(rc)
 		}
 		return
 	}
@@ -878,6 +1236,32 @@ func main() {
 		ExitJoker(11)
 	}
 
+	if LINT_FORMAT != "text" {
+		fmt.Fprintf(Stderr, "Error: Cannot specify --format=%s option when not linting.\n", LINT_FORMAT)
+		ExitJoker(22)
+	}
+
+	if LINT_CACHE_DIR != "" {
+		fmt.Fprintf(Stderr, "Error: Cannot specify --lint-cache option when not linting.\n")
+		ExitJoker(24)
+	}
+
+	if watchFlag {
+		fmt.Fprintf(Stderr, "Error: Cannot specify --watch option when not linting.\n")
+		ExitJoker(26)
+	}
+
+	if fromSnapshotFilename != "" {
+		if filename != "" {
+			fmt.Fprintf(Stderr, "Error: Cannot combine --from-snapshot and a <filename> argument.\n")
+			ExitJoker(20)
+		}
+		if err := RunSnapshot(fromSnapshotFilename); err != nil {
+			ExitJoker(1)
+		}
+		return
+	}
+
 	if filename != "" {
 		if err := processFile(filename, phase); err != nil {
 			if !errorToRepl {
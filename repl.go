@@ -45,7 +45,7 @@ func completer(line string, pos int) (head string, c []string, tail string) {
 		}
 	}
 	if addNamespaces {
-		for k, _ := range GLOBAL_ENV.Namespaces {
+		for k, _ := range GLOBAL_ENV.AllNamespaces() {
 			if strings.HasPrefix(*k, prefix) {
 				c = append(c, *k)
 			}
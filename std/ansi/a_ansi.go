@@ -0,0 +1,224 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+package ansi
+
+import (
+	. "github.com/candid82/joker/core"
+)
+
+var __bg__P ProcFn = __bg_
+var bg_ Proc = Proc{Fn: __bg__P, Name: "bg_", Package: "std/ansi"}
+
+func __bg_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		s := ExtractStringable(_args, 0)
+		color := ExtractObject(_args, 1)
+		_res := bg(s, color)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __bold__P ProcFn = __bold_
+var bold_ Proc = Proc{Fn: __bold__P, Name: "bold_", Package: "std/ansi"}
+
+func __bold_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		s := ExtractStringable(_args, 0)
+		_res := wrap(s, []string{attributes["bold"]})
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __dim__P ProcFn = __dim_
+var dim_ Proc = Proc{Fn: __dim__P, Name: "dim_", Package: "std/ansi"}
+
+func __dim_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		s := ExtractStringable(_args, 0)
+		_res := wrap(s, []string{attributes["dim"]})
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __isenabled__P ProcFn = __isenabled_
+var isenabled_ Proc = Proc{Fn: __isenabled__P, Name: "isenabled_", Package: "std/ansi"}
+
+func __isenabled_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := isEnabled()
+		return MakeBoolean(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __fg__P ProcFn = __fg_
+var fg_ Proc = Proc{Fn: __fg__P, Name: "fg_", Package: "std/ansi"}
+
+func __fg_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		s := ExtractStringable(_args, 0)
+		color := ExtractObject(_args, 1)
+		_res := fg(s, color)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __inverse__P ProcFn = __inverse_
+var inverse_ Proc = Proc{Fn: __inverse__P, Name: "inverse_", Package: "std/ansi"}
+
+func __inverse_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		s := ExtractStringable(_args, 0)
+		_res := wrap(s, []string{attributes["inverse"]})
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __italic__P ProcFn = __italic_
+var italic_ Proc = Proc{Fn: __italic__P, Name: "italic_", Package: "std/ansi"}
+
+func __italic_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		s := ExtractStringable(_args, 0)
+		_res := wrap(s, []string{attributes["italic"]})
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __set_enabled_bang__P ProcFn = __set_enabled_bang_
+var set_enabled_bang_ Proc = Proc{Fn: __set_enabled_bang__P, Name: "set_enabled_bang_", Package: "std/ansi"}
+
+func __set_enabled_bang_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		enabled := ExtractObject(_args, 0)
+		_res := setEnabled(enabled)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __strikethrough__P ProcFn = __strikethrough_
+var strikethrough_ Proc = Proc{Fn: __strikethrough__P, Name: "strikethrough_", Package: "std/ansi"}
+
+func __strikethrough_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		s := ExtractStringable(_args, 0)
+		_res := wrap(s, []string{attributes["strikethrough"]})
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __strip_ansi__P ProcFn = __strip_ansi_
+var strip_ansi_ Proc = Proc{Fn: __strip_ansi__P, Name: "strip_ansi_", Package: "std/ansi"}
+
+func __strip_ansi_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		s := ExtractString(_args, 0)
+		_res := stripAnsi(s)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __style__P ProcFn = __style_
+var style_ Proc = Proc{Fn: __style__P, Name: "style_", Package: "std/ansi"}
+
+func __style_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		s := ExtractStringable(_args, 0)
+		styles := ExtractObject(_args, 1)
+		_res := style(s, styles)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __underline__P ProcFn = __underline_
+var underline_ Proc = Proc{Fn: __underline__P, Name: "underline_", Package: "std/ansi"}
+
+func __underline_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		s := ExtractStringable(_args, 0)
+		_res := wrap(s, []string{attributes["underline"]})
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+func Init() {
+
+	InternsOrThunks()
+}
+
+var ansiNamespace = GLOBAL_ENV.EnsureSymbolIsLib(MakeSymbol("joker.ansi"))
+
+func init() {
+	ansiNamespace.Lazy = Init
+}
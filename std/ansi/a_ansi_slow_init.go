@@ -0,0 +1,122 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+package ansi
+
+import (
+	"fmt"
+	. "github.com/candid82/joker/core"
+	"os"
+)
+
+func InternsOrThunks() {
+	if VerbosityLevel > 0 {
+		fmt.Fprintln(os.Stderr, "Lazily running slow version of ansi.InternsOrThunks().")
+	}
+	ansiNamespace.ResetMeta(MakeMeta(nil, `Styles text for ANSI terminals: colors, bold, underline etc.
+
+         Styling is enabled automatically only when it is likely to be rendered:
+         it is disabled if the NO_COLOR environment variable is set, if TERM is
+         "dumb" or if standard output is not a terminal, and enabled regardless
+         of the terminal if FORCE_COLOR is set. When disabled, the styling functions
+         return their argument unchanged. Use set-enabled! to override detection.
+
+         Colors are specified as one of the keywords :black, :red, :green, :yellow,
+         :blue, :magenta, :cyan, :white, optionally prefixed with bright- (e.g. :bright-red),
+         as an Int from the 256-color palette, as a "#rrggbb" string or as
+         a vector [r g b] of 24-bit color components.
+
+         Example:
+
+         user=> (joker.ansi/style "Error:" [:bold :red])
+         "\u001b[1;31mError:\u001b[0m"`, "1.0"))
+
+	ansiNamespace.InternVarPacked("bg", bg_, &VarMeta{
+		Arglists: "([s color])",
+		Doc:      `Returns s with the background color set to color.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	ansiNamespace.InternVarPacked("bold", bold_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Returns s in bold.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	ansiNamespace.InternVarPacked("dim", dim_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Returns s dimmed.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	ansiNamespace.InternVarPacked("enabled?", isenabled_, &VarMeta{
+		Arglists: "([])",
+		Doc:      `Returns true if styling is enabled.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Boolean\"}",
+	})
+
+	ansiNamespace.InternVarPacked("fg", fg_, &VarMeta{
+		Arglists: "([s color])",
+		Doc:      `Returns s with the foreground color set to color.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	ansiNamespace.InternVarPacked("inverse", inverse_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Returns s with foreground and background colors swapped.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	ansiNamespace.InternVarPacked("italic", italic_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Returns s in italics.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	ansiNamespace.InternVarPacked("set-enabled!", set_enabled_bang_, &VarMeta{
+		Arglists: "([enabled])",
+		Doc: `Enables (true) or disables (false) styling regardless of the environment,
+  or restores automatic detection (nil).`,
+		Added: "1.2",
+	})
+
+	ansiNamespace.InternVarPacked("strikethrough", strikethrough_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Returns s crossed out.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	ansiNamespace.InternVarPacked("strip-ansi", strip_ansi_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Returns s with all ANSI escape sequences removed.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	ansiNamespace.InternVarPacked("style", style_, &VarMeta{
+		Arglists: "([s styles])",
+		Doc: `Returns s styled with styles, a keyword or a collection of keywords and maps.
+  Keywords are attributes (:bold, :dim, :italic, :underline, :blink, :inverse,
+  :hidden, :strikethrough), foreground colors (e.g. :red, :bright-blue) or
+  background colors prefixed with bg- (e.g. :bg-red, :bg-bright-blue).
+  Maps may have the keys :fg and :bg with colors of any kind as values,
+  and a "#rrggbb" string sets the foreground color.`,
+		Added: "1.2",
+		Extra: "{:tag \"String\"}",
+	})
+
+	ansiNamespace.InternVarPacked("underline", underline_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Returns s underlined.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+}
@@ -0,0 +1,179 @@
+package ansi
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	. "github.com/candid82/joker/core"
+)
+
+var attributes = map[string]string{
+	"bold":          "1",
+	"dim":           "2",
+	"italic":        "3",
+	"underline":     "4",
+	"blink":         "5",
+	"inverse":       "7",
+	"hidden":        "8",
+	"strikethrough": "9",
+}
+
+var colors = map[string]int{
+	"black":   0,
+	"red":     1,
+	"green":   2,
+	"yellow":  3,
+	"blue":    4,
+	"magenta": 5,
+	"cyan":    6,
+	"white":   7,
+}
+
+// Matches CSI sequences (colors, cursor movement etc.) and OSC sequences
+// (window titles, hyperlinks) terminated by BEL or ST.
+var escapeRe = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)`)
+
+// enabledOverride is nil unless set-enabled! was called with a boolean.
+var enabledOverride *bool
+
+func stdoutIsTerminal() bool {
+	fi, err := os.Stdout.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+func isEnabled() bool {
+	if enabledOverride != nil {
+		return *enabledOverride
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if os.Getenv("FORCE_COLOR") != "" {
+		return true
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return stdoutIsTerminal()
+}
+
+func setEnabled(enabled Object) Object {
+	switch enabled := enabled.(type) {
+	case Nil:
+		enabledOverride = nil
+	case Boolean:
+		b := enabled.B
+		enabledOverride = &b
+	default:
+		panic(RT.NewError("set-enabled! expects true, false or nil, got " + enabled.ToString(true)))
+	}
+	return NIL
+}
+
+func wrap(s string, codes []string) string {
+	if len(codes) == 0 || !isEnabled() {
+		return s
+	}
+	return "\x1b[" + strings.Join(codes, ";") + "m" + s + "\x1b[0m"
+}
+
+func colorComponent(obj Object) string {
+	n := EnsureObjectIsInt(obj, "Color component must be an Int, got %s").I
+	if n < 0 || n > 255 {
+		panic(RT.NewError("Color component must be between 0 and 255, got " + strconv.Itoa(n)))
+	}
+	return strconv.Itoa(n)
+}
+
+func hexColor(s string) []string {
+	if len(s) != 7 || s[0] != '#' {
+		panic(RT.NewError("Invalid color: " + s + ", expected #rrggbb"))
+	}
+	res := []string{"2"}
+	for i := 1; i < 7; i += 2 {
+		n, err := strconv.ParseUint(s[i:i+2], 16, 8)
+		if err != nil {
+			panic(RT.NewError("Invalid color: " + s + ", expected #rrggbb"))
+		}
+		res = append(res, strconv.Itoa(int(n)))
+	}
+	return res
+}
+
+// colorCodes returns the SGR codes setting the foreground (base 30)
+// or background (base 40) color to color, which is a color keyword,
+// an Int from the 256-color palette, a "#rrggbb" string or a vector [r g b].
+func colorCodes(color Object, base int) []string {
+	switch c := color.(type) {
+	case Keyword:
+		name := c.Name()
+		offset := 0
+		if strings.HasPrefix(name, "bright-") {
+			name = name[len("bright-"):]
+			offset = 60
+		}
+		if n, ok := colors[name]; ok {
+			return []string{strconv.Itoa(base + offset + n)}
+		}
+	case Int:
+		return []string{strconv.Itoa(base + 8), "5", colorComponent(c)}
+	case String:
+		return append([]string{strconv.Itoa(base + 8)}, hexColor(c.S)...)
+	case *Vector:
+		if c.Count() == 3 {
+			return []string{strconv.Itoa(base + 8), "2",
+				colorComponent(c.Nth(0)), colorComponent(c.Nth(1)), colorComponent(c.Nth(2))}
+		}
+	}
+	panic(RT.NewError("Invalid color: " + color.ToString(true)))
+}
+
+func styleCodes(style Object) []string {
+	switch st := style.(type) {
+	case Keyword:
+		name := st.Name()
+		if code, ok := attributes[name]; ok {
+			return []string{code}
+		}
+		if strings.HasPrefix(name, "bg-") {
+			return colorCodes(MakeKeyword(name[len("bg-"):]), 40)
+		}
+		return colorCodes(st, 30)
+	case String:
+		return colorCodes(st, 30)
+	case Map:
+		var res []string
+		if ok, fg := st.Get(MakeKeyword("fg")); ok {
+			res = append(res, colorCodes(fg, 30)...)
+		}
+		if ok, bg := st.Get(MakeKeyword("bg")); ok {
+			res = append(res, colorCodes(bg, 40)...)
+		}
+		return res
+	case Seqable:
+		var res []string
+		for s := st.Seq(); !s.IsEmpty(); s = s.Rest() {
+			res = append(res, styleCodes(s.First())...)
+		}
+		return res
+	}
+	panic(RT.NewError("Invalid style: " + style.ToString(true)))
+}
+
+func style(s string, styles Object) string {
+	return wrap(s, styleCodes(styles))
+}
+
+func fg(s string, color Object) string {
+	return wrap(s, colorCodes(color, 30))
+}
+
+func bg(s string, color Object) string {
+	return wrap(s, colorCodes(color, 40))
+}
+
+func stripAnsi(s string) string {
+	return escapeRe.ReplaceAllString(s, "")
+}
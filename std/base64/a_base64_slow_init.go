@@ -14,14 +14,18 @@ func InternsOrThunks() {
 	}
 	base64Namespace.ResetMeta(MakeMeta(nil, `Implements base64 encoding as specified by RFC 4648.`, "1.0"))
 
-	base64Namespace.InternVar("decode-string", decode_string_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Returns the bytes represented by the base64 string s.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
+	base64Namespace.InternVarPacked("decode-string", decode_string_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Returns the bytes represented by the base64 string s.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
 
-	base64Namespace.InternVar("encode-string", encode_string_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Returns the base64 encoding of s.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
+	base64Namespace.InternVarPacked("encode-string", encode_string_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Returns the base64 encoding of s.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
 
 }
@@ -0,0 +1,250 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+package bits
+
+import (
+	. "github.com/candid82/joker/core"
+)
+
+var __bit_length__P ProcFn = __bit_length_
+var bit_length_ Proc = Proc{Fn: __bit_length__P, Name: "bit_length_", Package: "std/bits"}
+
+func __bit_length_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		x := ExtractInt(_args, 0)
+		_res := bitLength(x)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __bytes_to_int__P ProcFn = __bytes_to_int_
+var bytes_to_int_ Proc = Proc{Fn: __bytes_to_int__P, Name: "bytes_to_int_", Package: "std/bits"}
+
+func __bytes_to_int_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		bytes := ExtractSeqable(_args, 0)
+		_res := bytesToInt(bytes, ":big", false)
+		return MakeInt(_res)
+
+	case _c == 2:
+		bytes := ExtractSeqable(_args, 0)
+		order := ExtractKeyword(_args, 1)
+		_res := bytesToInt(bytes, order, false)
+		return MakeInt(_res)
+
+	case _c == 3:
+		bytes := ExtractSeqable(_args, 0)
+		order := ExtractKeyword(_args, 1)
+		signed := ExtractBoolean(_args, 2)
+		_res := bytesToInt(bytes, order, signed)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __extract_bits__P ProcFn = __extract_bits_
+var extract_bits_ Proc = Proc{Fn: __extract_bits__P, Name: "extract_bits_", Package: "std/bits"}
+
+func __extract_bits_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 3:
+		x := ExtractInt(_args, 0)
+		offset := ExtractInt(_args, 1)
+		width := ExtractInt(_args, 2)
+		_res := extractBits(x, offset, width)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __insert_bits__P ProcFn = __insert_bits_
+var insert_bits_ Proc = Proc{Fn: __insert_bits__P, Name: "insert_bits_", Package: "std/bits"}
+
+func __insert_bits_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 4:
+		x := ExtractInt(_args, 0)
+		offset := ExtractInt(_args, 1)
+		width := ExtractInt(_args, 2)
+		v := ExtractInt(_args, 3)
+		_res := insertBits(x, offset, width, v)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __int_to_bytes__P ProcFn = __int_to_bytes_
+var int_to_bytes_ Proc = Proc{Fn: __int_to_bytes__P, Name: "int_to_bytes_", Package: "std/bits"}
+
+func __int_to_bytes_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		x := ExtractInt(_args, 0)
+		size := ExtractInt(_args, 1)
+		_res := intToBytes(x, size, ":big")
+		return _res
+
+	case _c == 3:
+		x := ExtractInt(_args, 0)
+		size := ExtractInt(_args, 1)
+		order := ExtractKeyword(_args, 2)
+		_res := intToBytes(x, size, order)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __leading_zeros__P ProcFn = __leading_zeros_
+var leading_zeros_ Proc = Proc{Fn: __leading_zeros__P, Name: "leading_zeros_", Package: "std/bits"}
+
+func __leading_zeros_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		x := ExtractInt(_args, 0)
+		_res := leadingZeros(x)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __popcount__P ProcFn = __popcount_
+var popcount_ Proc = Proc{Fn: __popcount__P, Name: "popcount_", Package: "std/bits"}
+
+func __popcount_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		x := ExtractInt(_args, 0)
+		_res := popcount(x)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __reverse_bits__P ProcFn = __reverse_bits_
+var reverse_bits_ Proc = Proc{Fn: __reverse_bits__P, Name: "reverse_bits_", Package: "std/bits"}
+
+func __reverse_bits_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		x := ExtractInt(_args, 0)
+		_res := reverseBits(x)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __reverse_bytes__P ProcFn = __reverse_bytes_
+var reverse_bytes_ Proc = Proc{Fn: __reverse_bytes__P, Name: "reverse_bytes_", Package: "std/bits"}
+
+func __reverse_bytes_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		x := ExtractInt(_args, 0)
+		_res := reverseBytes(x)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __rotate_left__P ProcFn = __rotate_left_
+var rotate_left_ Proc = Proc{Fn: __rotate_left__P, Name: "rotate_left_", Package: "std/bits"}
+
+func __rotate_left_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		x := ExtractInt(_args, 0)
+		k := ExtractInt(_args, 1)
+		_res := rotateLeft(x, k)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __rotate_right__P ProcFn = __rotate_right_
+var rotate_right_ Proc = Proc{Fn: __rotate_right__P, Name: "rotate_right_", Package: "std/bits"}
+
+func __rotate_right_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		x := ExtractInt(_args, 0)
+		k := ExtractInt(_args, 1)
+		_res := rotateLeft(x, -k)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __trailing_zeros__P ProcFn = __trailing_zeros_
+var trailing_zeros_ Proc = Proc{Fn: __trailing_zeros__P, Name: "trailing_zeros_", Package: "std/bits"}
+
+func __trailing_zeros_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		x := ExtractInt(_args, 0)
+		_res := trailingZeros(x)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+func Init() {
+
+	InternsOrThunks()
+}
+
+var bitsNamespace = GLOBAL_ENV.EnsureSymbolIsLib(MakeSymbol("joker.bits"))
+
+func init() {
+	bitsNamespace.Lazy = Init
+}
@@ -0,0 +1,105 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+package bits
+
+import (
+	"fmt"
+	. "github.com/candid82/joker/core"
+	"os"
+)
+
+func InternsOrThunks() {
+	if VerbosityLevel > 0 {
+		fmt.Fprintln(os.Stderr, "Lazily running slow version of bits.InternsOrThunks().")
+	}
+	bitsNamespace.ResetMeta(MakeMeta(nil, `Provides bit manipulation functions on 64-bit Ints.
+
+         Ints are treated as 64-bit two's complement values, so e.g. -1 has all bits set.`, "1.0"))
+
+	bitsNamespace.InternVarPacked("bit-length", bit_length_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns the minimum number of bits required to represent x; 0 if x is 0.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+	bitsNamespace.InternVarPacked("bytes->int", bytes_to_int_, &VarMeta{
+		Arglists: "([bytes] [bytes order] [bytes order signed])",
+		Doc: `Returns the Int represented by bytes, a Seqable of 1 to 8 Ints from 0 to 255.
+  order is :big (the default, most significant byte first) or :little.
+  If signed? is true, the value is sign-extended from its most significant bit.`,
+		Added: "1.2",
+		Extra: "{:tag \"Int\"}",
+	})
+
+	bitsNamespace.InternVarPacked("extract-bits", extract_bits_, &VarMeta{
+		Arglists: "([x offset width])",
+		Doc:      `Returns the width bits of x starting at bit offset (0 being the least significant bit).`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+	bitsNamespace.InternVarPacked("insert-bits", insert_bits_, &VarMeta{
+		Arglists: "([x offset width v])",
+		Doc:      `Returns x with the width bits starting at bit offset replaced by the low width bits of v.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+	bitsNamespace.InternVarPacked("int->bytes", int_to_bytes_, &VarMeta{
+		Arglists: "([x size] [x size order])",
+		Doc: `Returns a vector of the size (1 to 8) low bytes of x, as Ints from 0 to 255.
+  order is :big (the default, most significant byte first) or :little.`,
+		Added: "1.2",
+	})
+
+	bitsNamespace.InternVarPacked("leading-zeros", leading_zeros_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns the number of leading zero bits in x; 64 if x is 0.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+	bitsNamespace.InternVarPacked("popcount", popcount_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns the number of one bits in x.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+	bitsNamespace.InternVarPacked("reverse-bits", reverse_bits_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns x with its bits in reversed order.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+	bitsNamespace.InternVarPacked("reverse-bytes", reverse_bytes_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns x with its bytes in reversed order.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+	bitsNamespace.InternVarPacked("rotate-left", rotate_left_, &VarMeta{
+		Arglists: "([x k])",
+		Doc:      `Returns x rotated left by k bits. k may be negative to rotate right.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+	bitsNamespace.InternVarPacked("rotate-right", rotate_right_, &VarMeta{
+		Arglists: "([x k])",
+		Doc:      `Returns x rotated right by k bits. k may be negative to rotate left.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+	bitsNamespace.InternVarPacked("trailing-zeros", trailing_zeros_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns the number of trailing zero bits in x; 64 if x is 0.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+}
@@ -0,0 +1,122 @@
+package bits
+
+import (
+	"fmt"
+	"math/bits"
+
+	. "github.com/candid82/joker/core"
+)
+
+func popcount(x int) int {
+	return bits.OnesCount64(uint64(x))
+}
+
+func leadingZeros(x int) int {
+	return bits.LeadingZeros64(uint64(x))
+}
+
+func trailingZeros(x int) int {
+	return bits.TrailingZeros64(uint64(x))
+}
+
+func bitLength(x int) int {
+	return bits.Len64(uint64(x))
+}
+
+func rotateLeft(x, k int) int {
+	return int(bits.RotateLeft64(uint64(x), k))
+}
+
+func reverseBits(x int) int {
+	return int(bits.Reverse64(uint64(x)))
+}
+
+func reverseBytes(x int) int {
+	return int(bits.ReverseBytes64(uint64(x)))
+}
+
+func checkField(offset, width int) {
+	if offset < 0 || width < 0 || offset+width > 64 {
+		panic(RT.NewError("Bit field must be within 64 bits"))
+	}
+}
+
+func fieldMask(width int) uint64 {
+	if width == 64 {
+		return ^uint64(0)
+	}
+	return (uint64(1) << uint(width)) - 1
+}
+
+func extractBits(x, offset, width int) int {
+	checkField(offset, width)
+	return int((uint64(x) >> uint(offset)) & fieldMask(width))
+}
+
+func insertBits(x, offset, width, v int) int {
+	checkField(offset, width)
+	mask := fieldMask(width) << uint(offset)
+	return int((uint64(x) &^ mask) | ((uint64(v) << uint(offset)) & mask))
+}
+
+func bigEndian(order string) bool {
+	switch order {
+	case ":big":
+		return true
+	case ":little":
+		return false
+	default:
+		panic(RT.NewError("Unsupported byte order " + order + ". Supported byte orders are: :big, :little"))
+	}
+}
+
+func checkSize(size int) {
+	if size < 1 || size > 8 {
+		panic(RT.NewError("Size must be between 1 and 8 bytes"))
+	}
+}
+
+func intToBytes(x, size int, order string) *Vector {
+	checkSize(size)
+	big := bigEndian(order)
+	res := make([]Object, size)
+	u := uint64(x)
+	for i := 0; i < size; i++ {
+		b := MakeInt(int(byte(u >> uint(8*i))))
+		if big {
+			res[size-1-i] = b
+		} else {
+			res[i] = b
+		}
+	}
+	return NewVectorFrom(res...)
+}
+
+func bytesToInt(bs Seqable, order string, signed bool) int {
+	var bytes []byte
+	for s := bs.Seq(); !s.IsEmpty(); s = s.Rest() {
+		b := EnsureObjectIsInt(s.First(), "byte: %s").I
+		if b < 0 || b > 255 {
+			panic(RT.NewError(fmt.Sprintf("Byte must be between 0 and 255, got %d", b)))
+		}
+		bytes = append(bytes, byte(b))
+	}
+	size := len(bytes)
+	checkSize(size)
+	var u uint64
+	if bigEndian(order) {
+		for _, b := range bytes {
+			u = u<<8 | uint64(b)
+		}
+	} else {
+		for i := size - 1; i >= 0; i-- {
+			u = u<<8 | uint64(bytes[i])
+		}
+	}
+	if signed && size < 8 {
+		// Sign-extend from the most significant bit of the value.
+		shift := uint(64 - 8*size)
+		return int(int64(u<<shift) >> shift)
+	}
+	return int(u)
+}
@@ -29,67 +29,78 @@ func InternsOrThunks() {
          user=> (joker.json/read-string (joker.bolt/get db "users" (str id)))
          {"id" 1, "name" "Joe Black"}`, "1.0"))
 
-	boltNamespace.InternVar("by-prefix", by_prefix_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("db"), MakeSymbol("bucket"), MakeSymbol("prefix"))),
-			`Retrives key/value pairs for all keys in bucket
+	boltNamespace.InternVarPacked("by-prefix", by_prefix_, &VarMeta{
+		Arglists: "([db bucket prefix])",
+		Doc: `Retrives key/value pairs for all keys in bucket
   that start with prefix.
   Returns a vector of [key value] tuples. Passing empty prefix
-  will return all key/values in bucket.`, "1.0"))
+  will return all key/values in bucket.`,
+		Added: "1.0",
+	})
 
-	boltNamespace.InternVar("close", close_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("db"))),
-			`Releases all database resources.
+	boltNamespace.InternVarPacked("close", close_, &VarMeta{
+		Arglists: "([db])",
+		Doc: `Releases all database resources.
   It will block waiting for any open transactions to finish
-  before closing the database and returning.`, "1.0"))
-
-	boltNamespace.InternVar("create-bucket", create_bucket_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("db"), MakeSymbol("name"))),
-			`Creates a new bucket. Throws an error if the bucket already exists,
-  if the bucket name is blank, or if the bucket name is too long.`, "1.0"))
-
-	boltNamespace.InternVar("create-bucket-if-not-exists", create_bucket_if_not_exists_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("db"), MakeSymbol("name"))),
-			`Creates a new bucket if it doesn't already exist.
-   Throws an error if the bucket name is blank, or if the bucket name is too long.`, "1.0"))
-
-	boltNamespace.InternVar("delete", delete_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("db"), MakeSymbol("bucket"), MakeSymbol("key"))),
-			`Removes a key from the bucket if it exists.`, "1.0"))
-
-	boltNamespace.InternVar("delete-bucket", delete_bucket_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("db"), MakeSymbol("name"))),
-			`Deletes a bucket. Throws an error if the bucket doesn't exist.`, "1.0"))
-
-	boltNamespace.InternVar("get", get_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("db"), MakeSymbol("bucket"), MakeSymbol("key"))),
-			`Retrieves the value for a key in the bucket.
-  Returns nil if the key does not exist.`, "1.0"))
-
-	boltNamespace.InternVar("next-sequence", next_sequence_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("db"), MakeSymbol("bucket"))),
-			`Returns an autoincrementing integer for the bucket.`, "1.0"))
-
-	boltNamespace.InternVar("open", open_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("filename"), MakeSymbol("mode"))),
-			`Creates and opens a database at the given path.
+  before closing the database and returning.`,
+		Added: "1.0",
+	})
+
+	boltNamespace.InternVarPacked("create-bucket", create_bucket_, &VarMeta{
+		Arglists: "([db name])",
+		Doc: `Creates a new bucket. Throws an error if the bucket already exists,
+  if the bucket name is blank, or if the bucket name is too long.`,
+		Added: "1.0",
+	})
+
+	boltNamespace.InternVarPacked("create-bucket-if-not-exists", create_bucket_if_not_exists_, &VarMeta{
+		Arglists: "([db name])",
+		Doc: `Creates a new bucket if it doesn't already exist.
+   Throws an error if the bucket name is blank, or if the bucket name is too long.`,
+		Added: "1.0",
+	})
+
+	boltNamespace.InternVarPacked("delete", delete_, &VarMeta{
+		Arglists: "([db bucket key])",
+		Doc:      `Removes a key from the bucket if it exists.`,
+		Added:    "1.0",
+	})
+
+	boltNamespace.InternVarPacked("delete-bucket", delete_bucket_, &VarMeta{
+		Arglists: "([db name])",
+		Doc:      `Deletes a bucket. Throws an error if the bucket doesn't exist.`,
+		Added:    "1.0",
+	})
+
+	boltNamespace.InternVarPacked("get", get_, &VarMeta{
+		Arglists: "([db bucket key])",
+		Doc: `Retrieves the value for a key in the bucket.
+  Returns nil if the key does not exist.`,
+		Added: "1.0",
+	})
+
+	boltNamespace.InternVarPacked("next-sequence", next_sequence_, &VarMeta{
+		Arglists: "([db bucket])",
+		Doc:      `Returns an autoincrementing integer for the bucket.`,
+		Added:    "1.0",
+	})
+
+	boltNamespace.InternVarPacked("open", open_, &VarMeta{
+		Arglists: "([filename mode])",
+		Doc: `Creates and opens a database at the given path.
   If the file does not exist then it will be created automatically
   with mode perm (before umask).
-  mode is normally passed as an octal literal, e.g. 0600`, "1.0").Plus(MakeKeyword("tag"), String{S: "BoltDB"}))
-
-	boltNamespace.InternVar("put", put_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("db"), MakeSymbol("bucket"), MakeSymbol("key"), MakeSymbol("value"))),
-			`Sets the value for a key in the bucket.
+  mode is normally passed as an octal literal, e.g. 0600`,
+		Added: "1.0",
+		Extra: "{:tag \"BoltDB\"}",
+	})
+
+	boltNamespace.InternVarPacked("put", put_, &VarMeta{
+		Arglists: "([db bucket key value])",
+		Doc: `Sets the value for a key in the bucket.
   If the key exist then its previous value will be overwritten.
-  Throws an error if the key is blank, if the key is too large, or if the value is too large.`, "1.0"))
+  Throws an error if the key is blank, if the key is too large, or if the value is too large.`,
+		Added: "1.0",
+	})
 
 }
@@ -0,0 +1,110 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+package compress
+
+import (
+	. "github.com/candid82/joker/core"
+)
+
+var __compress__P ProcFn = __compress_
+var compress_ Proc = Proc{Fn: __compress__P, Name: "compress_", Package: "std/compress"}
+
+func __compress_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		data := ExtractString(_args, 0)
+		_res := compress(data, EmptyArrayMap())
+		return MakeString(_res)
+
+	case _c == 2:
+		data := ExtractString(_args, 0)
+		opts := ExtractMap(_args, 1)
+		_res := compress(data, opts)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __decompress__P ProcFn = __decompress_
+var decompress_ Proc = Proc{Fn: __decompress__P, Name: "decompress_", Package: "std/compress"}
+
+func __decompress_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		data := ExtractString(_args, 0)
+		_res := decompress(data, EmptyArrayMap())
+		return MakeString(_res)
+
+	case _c == 2:
+		data := ExtractString(_args, 0)
+		opts := ExtractMap(_args, 1)
+		_res := decompress(data, opts)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __reader__P ProcFn = __reader_
+var reader_ Proc = Proc{Fn: __reader__P, Name: "reader_", Package: "std/compress"}
+
+func __reader_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		r := ExtractIOReader(_args, 0)
+		_res := reader(r, EmptyArrayMap())
+		return MakeIOReader(_res)
+
+	case _c == 2:
+		r := ExtractIOReader(_args, 0)
+		opts := ExtractMap(_args, 1)
+		_res := reader(r, opts)
+		return MakeIOReader(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __writer__P ProcFn = __writer_
+var writer_ Proc = Proc{Fn: __writer__P, Name: "writer_", Package: "std/compress"}
+
+func __writer_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		w := ExtractIOWriter(_args, 0)
+		_res := writer(w, EmptyArrayMap())
+		return MakeIOWriter(_res)
+
+	case _c == 2:
+		w := ExtractIOWriter(_args, 0)
+		opts := ExtractMap(_args, 1)
+		_res := writer(w, opts)
+		return MakeIOWriter(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+func Init() {
+
+	InternsOrThunks()
+}
+
+var compressNamespace = GLOBAL_ENV.EnsureSymbolIsLib(MakeSymbol("joker.compress"))
+
+func init() {
+	compressNamespace.Lazy = Init
+}
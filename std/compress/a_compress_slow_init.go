@@ -0,0 +1,58 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+package compress
+
+import (
+	"fmt"
+	. "github.com/candid82/joker/core"
+	"os"
+)
+
+func InternsOrThunks() {
+	if VerbosityLevel > 0 {
+		fmt.Fprintln(os.Stderr, "Lazily running slow version of compress.InternsOrThunks().")
+	}
+	compressNamespace.ResetMeta(MakeMeta(nil, `Implements compression and decompression of data.
+
+         Functions accept an optional map of options with the following keys:
+
+         :format - one of :gzip (the default), :zlib, :deflate, :zstd, :brotli.
+
+         :level - compression level, either one of :none, :fastest, :default,
+         :best or an Int: from 0 (no compression) to 9 (best compression)
+         for :gzip, :zlib and :deflate, from 1 to 22 for :zstd and from
+         0 to 11 for :brotli. :zstd has no :none level, and :none is the
+         same as :fastest for :brotli. Only used when compressing.`, "1.0"))
+
+	compressNamespace.InternVarPacked("compress", compress_, &VarMeta{
+		Arglists: "([data] [data opts])",
+		Doc:      `Returns data compressed according to opts.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	compressNamespace.InternVarPacked("decompress", decompress_, &VarMeta{
+		Arglists: "([data] [data opts])",
+		Doc: `Returns data decompressed according to opts.
+  Throws an exception if data is not valid compressed data.`,
+		Added: "1.2",
+		Extra: "{:tag \"String\"}",
+	})
+
+	compressNamespace.InternVarPacked("reader", reader_, &VarMeta{
+		Arglists: "([r] [r opts])",
+		Doc:      `Returns an IOReader that decompresses data read from r according to opts.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"IOReader\"}",
+	})
+
+	compressNamespace.InternVarPacked("writer", writer_, &VarMeta{
+		Arglists: "([w] [w opts])",
+		Doc: `Returns an IOWriter that compresses everything written to it according to opts
+  and writes the result to w. The returned writer must be closed
+  (see joker.io/close) to flush all pending data; w itself is not closed.`,
+		Added: "1.2",
+		Extra: "{:tag \"IOWriter\"}",
+	})
+
+}
@@ -0,0 +1,171 @@
+package compress
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/andybalholm/brotli"
+	. "github.com/candid82/joker/core"
+	"github.com/klauspost/compress/zstd"
+)
+
+const formats = ":gzip, :zlib, :deflate, :zstd, :brotli"
+
+type options struct {
+	format string
+	// level is either one of the level keywords or, if it is empty,
+	// levelN is the level as given.
+	level  string
+	levelN int
+}
+
+func parseOptions(opts Map) options {
+	res := options{format: ":gzip", level: ":default"}
+	if ok, f := opts.Get(MakeKeyword("format")); ok {
+		res.format = EnsureObjectIsKeyword(f, "format: %s").ToString(false)
+	}
+	if ok, l := opts.Get(MakeKeyword("level")); ok {
+		switch l := l.(type) {
+		case Keyword:
+			switch l.ToString(false) {
+			case ":none", ":fastest", ":default", ":best":
+				res.level = l.ToString(false)
+			default:
+				panic(RT.NewError("Unsupported compression level " + l.ToString(false) +
+					". Supported levels are: :none, :fastest, :default, :best or an Int"))
+			}
+		default:
+			res.level = ""
+			res.levelN = EnsureObjectIsInt(l, "level: %s").I
+		}
+	}
+	return res
+}
+
+// compressionLevel returns the format's level for opts, given what the
+// level keywords stand for in the format and the range of its Int levels.
+func (opts options) compressionLevel(levels map[string]int, min, max int) int {
+	if opts.level == "" {
+		if opts.levelN < min || opts.levelN > max {
+			panic(RT.NewError(fmt.Sprintf("Compression level for %s must be between %d and %d, got %d",
+				opts.format, min, max, opts.levelN)))
+		}
+		return opts.levelN
+	}
+	res, ok := levels[opts.level]
+	if !ok {
+		panic(RT.NewError("Unsupported compression level " + opts.level + " for " + opts.format))
+	}
+	return res
+}
+
+var flateLevels = map[string]int{
+	":none":    flate.NoCompression,
+	":fastest": flate.BestSpeed,
+	":default": flate.DefaultCompression,
+	":best":    flate.BestCompression,
+}
+
+// zstd has no uncompressed level; its Int levels are those of the
+// reference implementation, mapped onto the few the encoder has.
+var zstdLevels = map[string]int{
+	":fastest": 1,
+	":default": 3,
+	":best":    22,
+}
+
+var brotliLevels = map[string]int{
+	":none":    brotli.BestSpeed,
+	":fastest": brotli.BestSpeed,
+	":default": brotli.DefaultCompression,
+	":best":    brotli.BestCompression,
+}
+
+// zstdReader makes a zstd.Decoder an io.ReadCloser.
+type zstdReader struct {
+	*zstd.Decoder
+}
+
+func (r zstdReader) Close() error {
+	r.Decoder.Close()
+	return nil
+}
+
+func unsupportedFormat(format string) *EvalError {
+	return RT.NewError("Unsupported compression format " + format + ". Supported formats are: " + formats)
+}
+
+func newWriter(w io.Writer, opts options) io.WriteCloser {
+	var res io.WriteCloser
+	var err error
+	switch opts.format {
+	case ":gzip":
+		res, err = gzip.NewWriterLevel(w, opts.compressionLevel(flateLevels, 0, 9))
+	case ":zlib":
+		res, err = zlib.NewWriterLevel(w, opts.compressionLevel(flateLevels, 0, 9))
+	case ":deflate":
+		res, err = flate.NewWriter(w, opts.compressionLevel(flateLevels, 0, 9))
+	case ":zstd":
+		level := zstd.EncoderLevelFromZstd(opts.compressionLevel(zstdLevels, 1, 22))
+		res, err = zstd.NewWriter(w, zstd.WithEncoderLevel(level))
+	case ":brotli":
+		res = brotli.NewWriterLevel(w, opts.compressionLevel(brotliLevels, brotli.BestSpeed, brotli.BestCompression))
+	default:
+		panic(unsupportedFormat(opts.format))
+	}
+	PanicOnErr(err)
+	return res
+}
+
+func newReader(r io.Reader, opts options) io.ReadCloser {
+	var res io.ReadCloser
+	var err error
+	switch opts.format {
+	case ":gzip":
+		res, err = gzip.NewReader(r)
+	case ":zlib":
+		res, err = zlib.NewReader(r)
+	case ":deflate":
+		res = flate.NewReader(r)
+	case ":zstd":
+		var d *zstd.Decoder
+		d, err = zstd.NewReader(r)
+		res = zstdReader{d}
+	case ":brotli":
+		res = ioutil.NopCloser(brotli.NewReader(r))
+	default:
+		panic(unsupportedFormat(opts.format))
+	}
+	PanicOnErr(err)
+	return res
+}
+
+func compress(data string, opts Map) string {
+	var buf bytes.Buffer
+	w := newWriter(&buf, parseOptions(opts))
+	_, err := io.WriteString(w, data)
+	PanicOnErr(err)
+	PanicOnErr(w.Close())
+	return buf.String()
+}
+
+func decompress(data string, opts Map) string {
+	r := newReader(bytes.NewReader([]byte(data)), parseOptions(opts))
+	res, err := ioutil.ReadAll(r)
+	PanicOnErr(err)
+	PanicOnErr(r.Close())
+	return string(res)
+}
+
+func writer(w io.Writer, opts Map) io.Writer {
+	return newWriter(w, parseOptions(opts))
+}
+
+func reader(r io.Reader, opts Map) io.Reader {
+	return newReader(r, parseOptions(opts))
+}
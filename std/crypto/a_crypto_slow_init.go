@@ -14,50 +14,68 @@ func InternsOrThunks() {
 	}
 	cryptoNamespace.ResetMeta(MakeMeta(nil, `Implements common cryptographic and hash functions.`, "1.0"))
 
-	cryptoNamespace.InternVar("hmac", hmac_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("algorithm"), MakeSymbol("message"), MakeSymbol("key"))),
-			`Returns HMAC signature for message and key using specified algorithm.
-  Algorithm is one of the following: :sha1, :sha224, :sha256, :sha384, :sha512.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	cryptoNamespace.InternVar("md5", md5_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("data"))),
-			`Returns the MD5 checksum of the data.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	cryptoNamespace.InternVar("sha1", sha1_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("data"))),
-			`Returns the SHA1 checksum of the data.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	cryptoNamespace.InternVar("sha224", sha224_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("data"))),
-			`Returns the SHA224 checksum of the data.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	cryptoNamespace.InternVar("sha256", sha256_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("data"))),
-			`Returns the SHA256 checksum of the data.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	cryptoNamespace.InternVar("sha384", sha384_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("data"))),
-			`Returns the SHA384 checksum of the data.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	cryptoNamespace.InternVar("sha512", sha512_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("data"))),
-			`Returns the SHA512 checksum of the data.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	cryptoNamespace.InternVar("sha512-224", sha512_224_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("data"))),
-			`Returns the SHA512/224 checksum of the data.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	cryptoNamespace.InternVar("sha512-256", sha512_256_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("data"))),
-			`Returns the SHA512/256 checksum of the data.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
+	cryptoNamespace.InternVarPacked("hmac", hmac_, &VarMeta{
+		Arglists: "([algorithm message key])",
+		Doc: `Returns HMAC signature for message and key using specified algorithm.
+  Algorithm is one of the following: :sha1, :sha224, :sha256, :sha384, :sha512.`,
+		Added: "1.0",
+		Extra: "{:tag \"String\"}",
+	})
+
+	cryptoNamespace.InternVarPacked("md5", md5_, &VarMeta{
+		Arglists: "([data])",
+		Doc:      `Returns the MD5 checksum of the data.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	cryptoNamespace.InternVarPacked("sha1", sha1_, &VarMeta{
+		Arglists: "([data])",
+		Doc:      `Returns the SHA1 checksum of the data.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	cryptoNamespace.InternVarPacked("sha224", sha224_, &VarMeta{
+		Arglists: "([data])",
+		Doc:      `Returns the SHA224 checksum of the data.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	cryptoNamespace.InternVarPacked("sha256", sha256_, &VarMeta{
+		Arglists: "([data])",
+		Doc:      `Returns the SHA256 checksum of the data.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	cryptoNamespace.InternVarPacked("sha384", sha384_, &VarMeta{
+		Arglists: "([data])",
+		Doc:      `Returns the SHA384 checksum of the data.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	cryptoNamespace.InternVarPacked("sha512", sha512_, &VarMeta{
+		Arglists: "([data])",
+		Doc:      `Returns the SHA512 checksum of the data.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	cryptoNamespace.InternVarPacked("sha512-224", sha512_224_, &VarMeta{
+		Arglists: "([data])",
+		Doc:      `Returns the SHA512/224 checksum of the data.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	cryptoNamespace.InternVarPacked("sha512-256", sha512_256_, &VarMeta{
+		Arglists: "([data])",
+		Doc:      `Returns the SHA512/256 checksum of the data.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
 
 }
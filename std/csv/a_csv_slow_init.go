@@ -14,10 +14,9 @@ func InternsOrThunks() {
 	}
 	csvNamespace.ResetMeta(MakeMeta(nil, `Reads and writes comma-separated values (CSV) files as defined in RFC 4180.`, "1.0"))
 
-	csvNamespace.InternVar("csv-seq", csv_seq_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("rdr")), NewVectorFrom(MakeSymbol("rdr"), MakeSymbol("opts"))),
-			`Returns the csv records from rdr as a lazy sequence.
+	csvNamespace.InternVarPacked("csv-seq", csv_seq_, &VarMeta{
+		Arglists: "([rdr] [rdr opts])",
+		Doc: `Returns the csv records from rdr as a lazy sequence.
   rdr must be a string or implement io.Reader.
   opts may have the following keys:
 
@@ -45,25 +44,30 @@ func InternsOrThunks() {
 
   :trim-leading-space - if true, leading white space in a field is ignored.
   This is done even if the field delimiter, comma, is white space.
-  Default value is false.`, "1.0"))
+  Default value is false.`,
+		Added: "1.0",
+	})
 
-	csvNamespace.InternVar("write", write_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("f"), MakeSymbol("data")), NewVectorFrom(MakeSymbol("f"), MakeSymbol("data"), MakeSymbol("opts"))),
-			`Writes records to a CSV encoded file.
+	csvNamespace.InternVarPacked("write", write_, &VarMeta{
+		Arglists: "([f data] [f data opts])",
+		Doc: `Writes records to a CSV encoded file.
   f must be io.Writer (for example, as returned by joker.os/create).
   data must be Seqable, each element of which must be Seqable as well.
-  opts is as in joker.csv/write-string.`, "1.0"))
+  opts is as in joker.csv/write-string.`,
+		Added: "1.0",
+	})
 
-	csvNamespace.InternVar("write-string", write_string_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("data")), NewVectorFrom(MakeSymbol("data"), MakeSymbol("opts"))),
-			`Writes records to a string in CSV format and returns the string.
+	csvNamespace.InternVarPacked("write-string", write_string_, &VarMeta{
+		Arglists: "([data] [data opts])",
+		Doc: `Writes records to a string in CSV format and returns the string.
   data must be Seqable, each element of which must be Seqable as well.
   opts may have the following keys:
 
   :comma - field delimiter (defaults to ',')
 
-  :use-crlf - if true, uses \r\n as the line terminator. Default value is false.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
+  :use-crlf - if true, uses \r\n as the line terminator. Default value is false.`,
+		Added: "1.0",
+		Extra: "{:tag \"String\"}",
+	})
 
 }
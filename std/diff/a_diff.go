@@ -0,0 +1,97 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+package diff
+
+import (
+	. "github.com/candid82/joker/core"
+)
+
+var __diff_lines__P ProcFn = __diff_lines_
+var diff_lines_ Proc = Proc{Fn: __diff_lines__P, Name: "diff_lines_", Package: "std/diff"}
+
+func __diff_lines_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		a := ExtractString(_args, 0)
+		b := ExtractString(_args, 1)
+		_res := diffLines(a, b)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __diff_words__P ProcFn = __diff_words_
+var diff_words_ Proc = Proc{Fn: __diff_words__P, Name: "diff_words_", Package: "std/diff"}
+
+func __diff_words_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		a := ExtractString(_args, 0)
+		b := ExtractString(_args, 1)
+		_res := diffWords(a, b)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __patch__P ProcFn = __patch_
+var patch_ Proc = Proc{Fn: __patch__P, Name: "patch_", Package: "std/diff"}
+
+func __patch_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		s := ExtractString(_args, 0)
+		patch := ExtractString(_args, 1)
+		_res := applyPatch(s, patch)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __unified__P ProcFn = __unified_
+var unified_ Proc = Proc{Fn: __unified__P, Name: "unified_", Package: "std/diff"}
+
+func __unified_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		a := ExtractString(_args, 0)
+		b := ExtractString(_args, 1)
+		_res := unified(a, b, EmptyArrayMap())
+		return MakeString(_res)
+
+	case _c == 3:
+		a := ExtractString(_args, 0)
+		b := ExtractString(_args, 1)
+		opts := ExtractMap(_args, 2)
+		_res := unified(a, b, opts)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+func Init() {
+
+	InternsOrThunks()
+}
+
+var diffNamespace = GLOBAL_ENV.EnsureSymbolIsLib(MakeSymbol("joker.diff"))
+
+func init() {
+	diffNamespace.Lazy = Init
+}
@@ -0,0 +1,22 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+//go:build !gen_code
+// +build !gen_code
+
+package diff
+
+import (
+	"fmt"
+	. "github.com/candid82/joker/core"
+	"os"
+)
+
+func InternsOrThunks() {
+	if VerbosityLevel > 0 {
+		fmt.Fprintln(os.Stderr, "Lazily running fast version of diff.InternsOrThunks().")
+	}
+	STD_thunk_diff_diff_lines__var = __diff_lines_
+	STD_thunk_diff_diff_words__var = __diff_words_
+	STD_thunk_diff_patch__var = __patch_
+	STD_thunk_diff_unified__var = __unified_
+}
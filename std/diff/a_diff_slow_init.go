@@ -0,0 +1,58 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+//go:build gen_code
+// +build gen_code
+
+package diff
+
+import (
+	"fmt"
+	. "github.com/candid82/joker/core"
+	"os"
+)
+
+func InternsOrThunks() {
+	if VerbosityLevel > 0 {
+		fmt.Fprintln(os.Stderr, "Lazily running slow version of diff.InternsOrThunks().")
+	}
+	diffNamespace.ResetMeta(MakeMeta(nil, `Computes differences between texts, formats them as unified diffs
+         and applies such diffs as patches.
+
+         diff-lines and diff-words return vectors of edits of the form [op text],
+         where op is one of :equal, :delete (text only in a) or :insert (text only in b).`, "1.0"))
+
+	diffNamespace.InternVarPacked("diff-lines", diff_lines_, &VarMeta{
+		Arglists: "([a b])",
+		Doc: `Returns the line-by-line differences between strings a and b as a vector
+  of edits, one per line. Line terminators are not included in the edits.`,
+		Added: "1.2",
+	})
+
+	diffNamespace.InternVarPacked("diff-words", diff_words_, &VarMeta{
+		Arglists: "([a b])",
+		Doc: `Returns the word-by-word differences between strings a and b as a vector
+  of edits. Words, runs of whitespace and punctuation characters are compared
+  separately; consecutive edits of the same kind are merged.`,
+		Added: "1.2",
+	})
+
+	diffNamespace.InternVarPacked("patch", patch_, &VarMeta{
+		Arglists: "([s patch])",
+		Doc: `Applies patch, a diff in unified format (such as returned by unified), to s.
+  Throws an exception if the patch doesn't apply exactly.`,
+		Added: "1.2",
+		Extra: "{:tag \"String\"}",
+	})
+
+	diffNamespace.InternVarPacked("unified", unified_, &VarMeta{
+		Arglists: "([a b] [a b opts])",
+		Doc: `Returns the differences between strings a and b in unified diff format,
+  or an empty string if they are equal. opts may have the keys
+  :context - number of unchanged lines around changes (3 by default),
+  :from-file - name of a in the header ("a" by default),
+  :to-file - name of b in the header ("b" by default).`,
+		Added: "1.2",
+		Extra: "{:tag \"String\"}",
+	})
+
+}
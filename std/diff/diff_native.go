@@ -0,0 +1,313 @@
+package diff
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	. "github.com/candid82/joker/core"
+)
+
+type edit struct {
+	op   byte // ' ', '-' or '+'
+	text string
+}
+
+var (
+	opEqual  = MakeKeyword("equal")
+	opDelete = MakeKeyword("delete")
+	opInsert = MakeKeyword("insert")
+)
+
+// myers returns the shortest edit script turning a into b, using
+// Myers' O(ND) algorithm.
+func myers(a, b []string) []edit {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	var trace [][]int
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, a, b, offset)
+			}
+		}
+	}
+	return nil
+}
+
+func backtrack(trace [][]int, a, b []string, offset int) []edit {
+	x, y := len(a), len(b)
+	var edits []edit
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			edits = append(edits, edit{' ', a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				edits = append(edits, edit{'+', b[y-1]})
+			} else {
+				edits = append(edits, edit{'-', a[x-1]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// splitLines splits s into lines, keeping line terminators.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func splitWords(s string) []string {
+	var words []string
+	class := func(r rune) int {
+		switch {
+		case unicode.IsSpace(r):
+			return 1
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			return 2
+		default:
+			return 3
+		}
+	}
+	start, prev := 0, 0
+	for i, r := range s {
+		c := class(r)
+		if i > 0 && (c != prev || c == 3) {
+			words = append(words, s[start:i])
+			start = i
+		}
+		prev = c
+	}
+	if start < len(s) {
+		words = append(words, s[start:])
+	}
+	return words
+}
+
+func editsToVector(edits []edit, merge bool) *Vector {
+	res := EmptyVector()
+	for i := 0; i < len(edits); i++ {
+		text := edits[i].text
+		if merge {
+			for i+1 < len(edits) && edits[i+1].op == edits[i].op {
+				i++
+				text += edits[i].text
+			}
+		}
+		var op Keyword
+		switch edits[i].op {
+		case ' ':
+			op = opEqual
+		case '-':
+			op = opDelete
+		default:
+			op = opInsert
+		}
+		res = res.Conjoin(NewVectorFrom(op, MakeString(text)))
+	}
+	return res
+}
+
+func trimLines(lines []string) []string {
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+	}
+	return lines
+}
+
+func diffLines(a, b string) *Vector {
+	return editsToVector(myers(trimLines(splitLines(a)), trimLines(splitLines(b))), false)
+}
+
+func diffWords(a, b string) *Vector {
+	return editsToVector(myers(splitWords(a), splitWords(b)), true)
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return strconv.Itoa(start) + ",0"
+	}
+	if count == 1 {
+		return strconv.Itoa(start + 1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+func writeLine(b *strings.Builder, op byte, line string) {
+	b.WriteByte(op)
+	b.WriteString(line)
+	if !strings.HasSuffix(line, "\n") {
+		b.WriteString("\n\\ No newline at end of file\n")
+	}
+}
+
+func unified(a, b string, opts Map) string {
+	context := 3
+	if ok, v := opts.Get(MakeKeyword("context")); ok {
+		context = EnsureObjectIsInt(v, "context: %s").I
+	}
+	fromFile, toFile := "a", "b"
+	if ok, v := opts.Get(MakeKeyword("from-file")); ok {
+		fromFile = EnsureObjectIsString(v, "from-file: %s").S
+	}
+	if ok, v := opts.Get(MakeKeyword("to-file")); ok {
+		toFile = EnsureObjectIsString(v, "to-file: %s").S
+	}
+	edits := myers(splitLines(a), splitLines(b))
+	var res strings.Builder
+	// aLine and bLine are the (0-based) line numbers before each edit.
+	aLine := make([]int, len(edits)+1)
+	bLine := make([]int, len(edits)+1)
+	for i, e := range edits {
+		aLine[i+1], bLine[i+1] = aLine[i], bLine[i]
+		if e.op != '+' {
+			aLine[i+1]++
+		}
+		if e.op != '-' {
+			bLine[i+1]++
+		}
+	}
+	for i := 0; i < len(edits); {
+		if edits[i].op == ' ' {
+			i++
+			continue
+		}
+		// Extend the hunk while the gap between changes is small enough
+		// for their contexts to overlap.
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for j := i; j < len(edits) && j <= end+2*context; j++ {
+			if edits[j].op != ' ' {
+				end = j
+			}
+		}
+		stop := end + 1 + context
+		if stop > len(edits) {
+			stop = len(edits)
+		}
+		if res.Len() == 0 {
+			res.WriteString("--- " + fromFile + "\n+++ " + toFile + "\n")
+		}
+		fmt.Fprintf(&res, "@@ -%s +%s @@\n",
+			hunkRange(aLine[start], aLine[stop]-aLine[start]),
+			hunkRange(bLine[start], bLine[stop]-bLine[start]))
+		for _, e := range edits[start:stop] {
+			writeLine(&res, e.op, e.text)
+		}
+		i = stop
+	}
+	return res.String()
+}
+
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+func hunkCount(s string) int {
+	if s == "" {
+		return 1
+	}
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func applyPatch(s, patch string) string {
+	src := splitLines(s)
+	var res []string
+	pos := 0
+	lines := splitLines(patch)
+	hunk := 0
+	for i := 0; i < len(lines); i++ {
+		m := hunkHeader.FindStringSubmatch(lines[i])
+		if m == nil {
+			continue
+		}
+		hunk++
+		fail := func(msg string) {
+			panic(RT.NewError(fmt.Sprintf("Patch does not apply: hunk %d %s", hunk, msg)))
+		}
+		start, _ := strconv.Atoi(m[1])
+		oldCount, newCount := hunkCount(m[2]), hunkCount(m[4])
+		if oldCount > 0 {
+			start--
+		}
+		if start < pos || start > len(src) {
+			fail("is out of range")
+		}
+		res = append(res, src[pos:start]...)
+		pos = start
+		for oldCount > 0 || newCount > 0 {
+			i++
+			if i >= len(lines) || len(lines[i]) == 0 {
+				fail("is truncated")
+			}
+			op, text := lines[i][0], lines[i][1:]
+			// "\ No newline at end of file" refers to the line before it.
+			noNewline := i+1 < len(lines) && strings.HasPrefix(lines[i+1], "\\")
+			if noNewline {
+				text = strings.TrimSuffix(text, "\n")
+				i++
+			}
+			switch op {
+			case ' ', '-':
+				if pos >= len(src) || src[pos] != text {
+					fail(fmt.Sprintf("doesn't match line %d", pos+1))
+				}
+				pos++
+				oldCount--
+				if op == ' ' {
+					res = append(res, text)
+					newCount--
+				}
+			case '+':
+				res = append(res, text)
+				newCount--
+			default:
+				fail(fmt.Sprintf("has an invalid line: %q", lines[i]))
+			}
+		}
+	}
+	res = append(res, src[pos:]...)
+	return strings.Join(res, "")
+}
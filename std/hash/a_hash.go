@@ -0,0 +1,258 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+package hash
+
+import (
+	. "github.com/candid82/joker/core"
+)
+
+var __adler32__P ProcFn = __adler32_
+var adler32_ Proc = Proc{Fn: __adler32__P, Name: "adler32_", Package: "std/hash"}
+
+func __adler32_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		data := ExtractObject(_args, 0)
+		_res := digest(":adler32", data, 0)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __crc32__P ProcFn = __crc32_
+var crc32_ Proc = Proc{Fn: __crc32__P, Name: "crc32_", Package: "std/hash"}
+
+func __crc32_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		data := ExtractObject(_args, 0)
+		_res := digest(":crc32", data, 0)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __crc64__P ProcFn = __crc64_
+var crc64_ Proc = Proc{Fn: __crc64__P, Name: "crc64_", Package: "std/hash"}
+
+func __crc64_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		data := ExtractObject(_args, 0)
+		_res := digest(":crc64", data, 0)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __digest__P ProcFn = __digest_
+var digest_ Proc = Proc{Fn: __digest__P, Name: "digest_", Package: "std/hash"}
+
+func __digest_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		h := ExtractHasher(_args, 0)
+		_res := sum(h.h)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __fnv32__P ProcFn = __fnv32_
+var fnv32_ Proc = Proc{Fn: __fnv32__P, Name: "fnv32_", Package: "std/hash"}
+
+func __fnv32_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		data := ExtractObject(_args, 0)
+		_res := digest(":fnv32", data, 0)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __fnv32a__P ProcFn = __fnv32a_
+var fnv32a_ Proc = Proc{Fn: __fnv32a__P, Name: "fnv32a_", Package: "std/hash"}
+
+func __fnv32a_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		data := ExtractObject(_args, 0)
+		_res := digest(":fnv32a", data, 0)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __fnv64__P ProcFn = __fnv64_
+var fnv64_ Proc = Proc{Fn: __fnv64__P, Name: "fnv64_", Package: "std/hash"}
+
+func __fnv64_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		data := ExtractObject(_args, 0)
+		_res := digest(":fnv64", data, 0)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __fnv64a__P ProcFn = __fnv64a_
+var fnv64a_ Proc = Proc{Fn: __fnv64a__P, Name: "fnv64a_", Package: "std/hash"}
+
+func __fnv64a_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		data := ExtractObject(_args, 0)
+		_res := digest(":fnv64a", data, 0)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __hasher__P ProcFn = __hasher_
+var hasher_ Proc = Proc{Fn: __hasher__P, Name: "hasher_", Package: "std/hash"}
+
+func __hasher_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		algorithm := ExtractKeyword(_args, 0)
+		_res := hasher(algorithm, 0)
+		return _res
+
+	case _c == 2:
+		algorithm := ExtractKeyword(_args, 0)
+		seed := ExtractInt(_args, 1)
+		_res := hasher(algorithm, seed)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __murmur3__P ProcFn = __murmur3_
+var murmur3_ Proc = Proc{Fn: __murmur3__P, Name: "murmur3_", Package: "std/hash"}
+
+func __murmur3_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		data := ExtractObject(_args, 0)
+		_res := digest(":murmur3", data, 0)
+		return MakeInt(_res)
+
+	case _c == 2:
+		data := ExtractObject(_args, 0)
+		seed := ExtractInt(_args, 1)
+		_res := digest(":murmur3", data, seed)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __reset__P ProcFn = __reset_
+var reset_ Proc = Proc{Fn: __reset__P, Name: "reset_", Package: "std/hash"}
+
+func __reset_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		h := ExtractHasher(_args, 0)
+		_res := reset(h)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __update__P ProcFn = __update_
+var update_ Proc = Proc{Fn: __update__P, Name: "update_", Package: "std/hash"}
+
+func __update_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		h := ExtractHasher(_args, 0)
+		data := ExtractObject(_args, 1)
+		_res := update(h, data)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __xxhash64__P ProcFn = __xxhash64_
+var xxhash64_ Proc = Proc{Fn: __xxhash64__P, Name: "xxhash64_", Package: "std/hash"}
+
+func __xxhash64_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		data := ExtractObject(_args, 0)
+		_res := digest(":xxhash64", data, 0)
+		return MakeInt(_res)
+
+	case _c == 2:
+		data := ExtractObject(_args, 0)
+		seed := ExtractInt(_args, 1)
+		_res := digest(":xxhash64", data, seed)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+func Init() {
+
+	InternsOrThunks()
+}
+
+var hashNamespace = GLOBAL_ENV.EnsureSymbolIsLib(MakeSymbol("joker.hash"))
+
+func init() {
+	hashNamespace.Lazy = Init
+}
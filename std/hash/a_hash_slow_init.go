@@ -0,0 +1,115 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+package hash
+
+import (
+	"fmt"
+	. "github.com/candid82/joker/core"
+	"os"
+)
+
+func InternsOrThunks() {
+	if VerbosityLevel > 0 {
+		fmt.Fprintln(os.Stderr, "Lazily running slow version of hash.InternsOrThunks().")
+	}
+	hashNamespace.ResetMeta(MakeMeta(nil, `Provides fast non-cryptographic hash functions and checksums.
+
+         data may be a String, an IOReader (read until EOF), or a Seqable of Ints
+         representing bytes. Hashes are returned as Ints; 64-bit hashes are
+         reinterpreted as signed, so they may be negative.
+
+         Use hasher, update and digest to hash data incrementally.`, "1.0"))
+
+	hashNamespace.InternVarPacked("adler32", adler32_, &VarMeta{
+		Arglists: "([data])",
+		Doc:      `Returns the Adler-32 checksum of data.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+	hashNamespace.InternVarPacked("crc32", crc32_, &VarMeta{
+		Arglists: "([data])",
+		Doc:      `Returns the CRC-32 (IEEE) checksum of data.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+	hashNamespace.InternVarPacked("crc64", crc64_, &VarMeta{
+		Arglists: "([data])",
+		Doc:      `Returns the CRC-64 (ECMA) checksum of data.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+	hashNamespace.InternVarPacked("digest", digest_, &VarMeta{
+		Arglists: "([h])",
+		Doc: `Returns the hash of all data fed to Hasher h so far.
+  Does not change the state of h.`,
+		Added: "1.2",
+		Extra: "{:tag \"Int\"}",
+	})
+
+	hashNamespace.InternVarPacked("fnv32", fnv32_, &VarMeta{
+		Arglists: "([data])",
+		Doc:      `Returns the 32-bit FNV-1 hash of data.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+	hashNamespace.InternVarPacked("fnv32a", fnv32a_, &VarMeta{
+		Arglists: "([data])",
+		Doc:      `Returns the 32-bit FNV-1a hash of data.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+	hashNamespace.InternVarPacked("fnv64", fnv64_, &VarMeta{
+		Arglists: "([data])",
+		Doc:      `Returns the 64-bit FNV-1 hash of data.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+	hashNamespace.InternVarPacked("fnv64a", fnv64a_, &VarMeta{
+		Arglists: "([data])",
+		Doc:      `Returns the 64-bit FNV-1a hash of data.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+	hashNamespace.InternVarPacked("hasher", hasher_, &VarMeta{
+		Arglists: "([algorithm] [algorithm seed])",
+		Doc: `Returns a new Hasher that computes the given hash incrementally.
+  algorithm is one of :crc32, :crc32c, :crc64, :crc64-iso, :adler32,
+  :fnv32, :fnv32a, :fnv64, :fnv64a, :xxhash64, :murmur3.
+  seed is only used by :xxhash64 and :murmur3.`,
+		Added: "1.2",
+	})
+
+	hashNamespace.InternVarPacked("murmur3", murmur3_, &VarMeta{
+		Arglists: "([data] [data seed])",
+		Doc:      `Returns the 32-bit MurmurHash3 (x86_32) of data, using seed (0 by default).`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+	hashNamespace.InternVarPacked("reset", reset_, &VarMeta{
+		Arglists: "([h])",
+		Doc:      `Resets Hasher h to its initial state. Returns h.`,
+		Added:    "1.2",
+	})
+
+	hashNamespace.InternVarPacked("update", update_, &VarMeta{
+		Arglists: "([h data])",
+		Doc:      `Feeds data to Hasher h. Returns h.`,
+		Added:    "1.2",
+	})
+
+	hashNamespace.InternVarPacked("xxhash64", xxhash64_, &VarMeta{
+		Arglists: "([data] [data seed])",
+		Doc:      `Returns the 64-bit xxHash (XXH64) of data, using seed (0 by default).`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+}
@@ -0,0 +1,360 @@
+package hash
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/adler32"
+	"hash/crc32"
+	"hash/crc64"
+	"hash/fnv"
+	"io"
+	"math/bits"
+	"unsafe"
+
+	. "github.com/candid82/joker/core"
+)
+
+type (
+	Hasher struct {
+		h         hash.Hash
+		algorithm string
+		hash      uint32
+	}
+)
+
+var hasherType *Type
+
+func MakeHasher(h hash.Hash, algorithm string) *Hasher {
+	res := &Hasher{h, algorithm, 0}
+	res.hash = HashPtr(uintptr(unsafe.Pointer(res)))
+	return res
+}
+
+func (h *Hasher) ToString(escape bool) string {
+	return "#object[Hasher " + h.algorithm + "]"
+}
+
+func (h *Hasher) Equals(other interface{}) bool {
+	return h == other
+}
+
+func (h *Hasher) GetInfo() *ObjectInfo {
+	return nil
+}
+
+func (h *Hasher) GetType() *Type {
+	return hasherType
+}
+
+func (h *Hasher) Hash() uint32 {
+	return h.hash
+}
+
+func (h *Hasher) WithInfo(info *ObjectInfo) Object {
+	return h
+}
+
+func EnsureArgIsHasher(args []Object, index int) *Hasher {
+	obj := args[index]
+	if c, yes := obj.(*Hasher); yes {
+		return c
+	}
+	panic(FailArg(obj, "Hasher", index))
+}
+
+func ExtractHasher(args []Object, index int) *Hasher {
+	return EnsureArgIsHasher(args, index)
+}
+
+var crc64ISO = crc64.MakeTable(crc64.ISO)
+var crc64ECMA = crc64.MakeTable(crc64.ECMA)
+
+func newHash(algorithm string, seed uint64) hash.Hash {
+	switch algorithm {
+	case ":crc32":
+		return crc32.NewIEEE()
+	case ":crc32c":
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	case ":crc64":
+		return crc64.New(crc64ECMA)
+	case ":crc64-iso":
+		return crc64.New(crc64ISO)
+	case ":adler32":
+		return adler32.New()
+	case ":fnv32":
+		return fnv.New32()
+	case ":fnv32a":
+		return fnv.New32a()
+	case ":fnv64":
+		return fnv.New64()
+	case ":fnv64a":
+		return fnv.New64a()
+	case ":xxhash64":
+		return newXXHash64(seed)
+	case ":murmur3":
+		return newMurmur3(uint32(seed))
+	default:
+		panic(RT.NewError("Unsupported algorithm " + algorithm +
+			". Supported algorithms are: :crc32, :crc32c, :crc64, :crc64-iso, :adler32, :fnv32, :fnv32a, :fnv64, :fnv64a, :xxhash64, :murmur3"))
+	}
+}
+
+// write feeds data to h. data may be a String, an io.Reader
+// or a Seqable of Ints (bytes).
+func write(h hash.Hash, data Object) {
+	switch d := data.(type) {
+	case String:
+		io.WriteString(h, d.S)
+	case io.Reader:
+		_, err := io.Copy(h, d)
+		PanicOnErr(err)
+	case Seqable:
+		var buf []byte
+		for s := d.Seq(); !s.IsEmpty(); s = s.Rest() {
+			buf = append(buf, byte(EnsureObjectIsInt(s.First(), "byte: %s").I))
+		}
+		h.Write(buf)
+	default:
+		panic(RT.NewError("Expected String, IOReader or Seqable of Ints, got " + data.GetType().ToString(false)))
+	}
+}
+
+func sum(h hash.Hash) int {
+	switch h := h.(type) {
+	case hash.Hash64:
+		return int(h.Sum64())
+	case hash.Hash32:
+		return int(h.Sum32())
+	default:
+		panic(RT.NewError("Hash is neither 32 nor 64 bits"))
+	}
+}
+
+func digest(algorithm string, data Object, seed int) int {
+	h := newHash(algorithm, uint64(seed))
+	write(h, data)
+	return sum(h)
+}
+
+func hasher(algorithm string, seed int) *Hasher {
+	return MakeHasher(newHash(algorithm, uint64(seed)), algorithm)
+}
+
+func update(h *Hasher, data Object) *Hasher {
+	write(h.h, data)
+	return h
+}
+
+func reset(h *Hasher) *Hasher {
+	h.h.Reset()
+	return h
+}
+
+// xxHash64, see https://github.com/Cyan4973/xxHash/blob/dev/doc/xxhash_spec.md
+
+const (
+	xxPrime1 uint64 = 11400714785074694791
+	xxPrime2 uint64 = 14029467366897019727
+	xxPrime3 uint64 = 1609587929392839161
+	xxPrime4 uint64 = 9650029242287828579
+	xxPrime5 uint64 = 2870177450012600261
+)
+
+type xxHash64 struct {
+	seed           uint64
+	v1, v2, v3, v4 uint64
+	total          uint64
+	mem            [32]byte
+	n              int
+}
+
+func newXXHash64(seed uint64) *xxHash64 {
+	d := &xxHash64{seed: seed}
+	d.Reset()
+	return d
+}
+
+func xxRound(acc, input uint64) uint64 {
+	acc += input * xxPrime2
+	acc = bits.RotateLeft64(acc, 31)
+	return acc * xxPrime1
+}
+
+func xxMergeRound(acc, val uint64) uint64 {
+	acc ^= xxRound(0, val)
+	return acc*xxPrime1 + xxPrime4
+}
+
+func (d *xxHash64) Reset() {
+	d.v1 = d.seed + xxPrime1 + xxPrime2
+	d.v2 = d.seed + xxPrime2
+	d.v3 = d.seed
+	d.v4 = d.seed - xxPrime1
+	d.total = 0
+	d.n = 0
+}
+
+func (d *xxHash64) Size() int      { return 8 }
+func (d *xxHash64) BlockSize() int { return 32 }
+
+func (d *xxHash64) Write(b []byte) (int, error) {
+	n := len(b)
+	d.total += uint64(n)
+	if d.n+n < 32 {
+		d.n += copy(d.mem[d.n:], b)
+		return n, nil
+	}
+	if d.n > 0 {
+		c := copy(d.mem[d.n:], b)
+		d.v1 = xxRound(d.v1, binary.LittleEndian.Uint64(d.mem[0:8]))
+		d.v2 = xxRound(d.v2, binary.LittleEndian.Uint64(d.mem[8:16]))
+		d.v3 = xxRound(d.v3, binary.LittleEndian.Uint64(d.mem[16:24]))
+		d.v4 = xxRound(d.v4, binary.LittleEndian.Uint64(d.mem[24:32]))
+		b = b[c:]
+		d.n = 0
+	}
+	for ; len(b) >= 32; b = b[32:] {
+		d.v1 = xxRound(d.v1, binary.LittleEndian.Uint64(b[0:8]))
+		d.v2 = xxRound(d.v2, binary.LittleEndian.Uint64(b[8:16]))
+		d.v3 = xxRound(d.v3, binary.LittleEndian.Uint64(b[16:24]))
+		d.v4 = xxRound(d.v4, binary.LittleEndian.Uint64(b[24:32]))
+	}
+	d.n = copy(d.mem[:], b)
+	return n, nil
+}
+
+func (d *xxHash64) Sum64() uint64 {
+	var h uint64
+	if d.total >= 32 {
+		h = bits.RotateLeft64(d.v1, 1) + bits.RotateLeft64(d.v2, 7) +
+			bits.RotateLeft64(d.v3, 12) + bits.RotateLeft64(d.v4, 18)
+		h = xxMergeRound(h, d.v1)
+		h = xxMergeRound(h, d.v2)
+		h = xxMergeRound(h, d.v3)
+		h = xxMergeRound(h, d.v4)
+	} else {
+		h = d.seed + xxPrime5
+	}
+	h += d.total
+
+	b := d.mem[:d.n]
+	for ; len(b) >= 8; b = b[8:] {
+		h ^= xxRound(0, binary.LittleEndian.Uint64(b))
+		h = bits.RotateLeft64(h, 27)*xxPrime1 + xxPrime4
+	}
+	if len(b) >= 4 {
+		h ^= uint64(binary.LittleEndian.Uint32(b)) * xxPrime1
+		h = bits.RotateLeft64(h, 23)*xxPrime2 + xxPrime3
+		b = b[4:]
+	}
+	for _, c := range b {
+		h ^= uint64(c) * xxPrime5
+		h = bits.RotateLeft64(h, 11) * xxPrime1
+	}
+
+	h ^= h >> 33
+	h *= xxPrime2
+	h ^= h >> 29
+	h *= xxPrime3
+	h ^= h >> 32
+	return h
+}
+
+func (d *xxHash64) Sum(b []byte) []byte {
+	var s [8]byte
+	binary.BigEndian.PutUint64(s[:], d.Sum64())
+	return append(b, s[:]...)
+}
+
+// MurmurHash3 (x86, 32-bit), see https://github.com/aappleby/smhasher
+
+const (
+	murmurC1 uint32 = 0xcc9e2d51
+	murmurC2 uint32 = 0x1b873593
+)
+
+type murmur3 struct {
+	seed  uint32
+	h     uint32
+	total int
+	tail  [4]byte
+	n     int
+}
+
+func newMurmur3(seed uint32) *murmur3 {
+	return &murmur3{seed: seed, h: seed}
+}
+
+func (d *murmur3) Reset() {
+	d.h = d.seed
+	d.total = 0
+	d.n = 0
+}
+
+func (d *murmur3) Size() int      { return 4 }
+func (d *murmur3) BlockSize() int { return 4 }
+
+func (d *murmur3) block(k uint32) {
+	k *= murmurC1
+	k = bits.RotateLeft32(k, 15)
+	k *= murmurC2
+	d.h ^= k
+	d.h = bits.RotateLeft32(d.h, 13)
+	d.h = d.h*5 + 0xe6546b64
+}
+
+func (d *murmur3) Write(b []byte) (int, error) {
+	n := len(b)
+	d.total += n
+	if d.n > 0 {
+		c := copy(d.tail[d.n:], b)
+		d.n += c
+		b = b[c:]
+		if d.n < 4 {
+			return n, nil
+		}
+		d.block(binary.LittleEndian.Uint32(d.tail[:]))
+		d.n = 0
+	}
+	for ; len(b) >= 4; b = b[4:] {
+		d.block(binary.LittleEndian.Uint32(b))
+	}
+	d.n = copy(d.tail[:], b)
+	return n, nil
+}
+
+func (d *murmur3) Sum32() uint32 {
+	h := d.h
+	var k uint32
+	switch d.n {
+	case 3:
+		k ^= uint32(d.tail[2]) << 16
+		fallthrough
+	case 2:
+		k ^= uint32(d.tail[1]) << 8
+		fallthrough
+	case 1:
+		k ^= uint32(d.tail[0])
+		k *= murmurC1
+		k = bits.RotateLeft32(k, 15)
+		k *= murmurC2
+		h ^= k
+	}
+	h ^= uint32(d.total)
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
+}
+
+func (d *murmur3) Sum(b []byte) []byte {
+	var s [4]byte
+	binary.BigEndian.PutUint32(s[:], d.Sum32())
+	return append(b, s[:]...)
+}
+
+func init() {
+	hasherType = RegType("Hasher", (*Hasher)(nil), "Wraps a streaming hash function")
+}
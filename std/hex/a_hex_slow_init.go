@@ -14,14 +14,18 @@ func InternsOrThunks() {
 	}
 	hexNamespace.ResetMeta(MakeMeta(nil, `Implements hexadecimal encoding and decoding.`, "1.0"))
 
-	hexNamespace.InternVar("decode-string", decode_string_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Returns the bytes represented by the hexadecimal string s.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
+	hexNamespace.InternVarPacked("decode-string", decode_string_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Returns the bytes represented by the hexadecimal string s.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
 
-	hexNamespace.InternVar("encode-string", encode_string_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Returns the hexadecimal encoding of s.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
+	hexNamespace.InternVarPacked("encode-string", encode_string_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Returns the hexadecimal encoding of s.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
 
 }
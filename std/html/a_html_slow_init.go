@@ -17,14 +17,18 @@ func InternsOrThunks() {
 	}
 	htmlNamespace.ResetMeta(MakeMeta(nil, `Provides functions for escaping and unescaping HTML text.`, "1.0"))
 
-	htmlNamespace.InternVar("escape", escape_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Escapes special characters like < to become &lt;. It escapes only five such characters: <, >, &, ' and ".`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
+	htmlNamespace.InternVarPacked("escape", escape_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Escapes special characters like < to become &lt;. It escapes only five such characters: <, >, &, ' and ".`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
 
-	htmlNamespace.InternVar("unescape", unescape_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Unescapes entities like &lt; to become <.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
+	htmlNamespace.InternVarPacked("unescape", unescape_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Unescapes entities like &lt; to become <.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
 
 }
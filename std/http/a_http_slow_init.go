@@ -14,10 +14,9 @@ func InternsOrThunks() {
 	}
 	httpNamespace.ResetMeta(MakeMeta(nil, `Provides HTTP client and server implementations.`, "1.0"))
 
-	httpNamespace.InternVar("send", send_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("request"))),
-			`Sends an HTTP request and returns an HTTP response.
+	httpNamespace.InternVarPacked("send", send_, &VarMeta{
+		Arglists: "([request])",
+		Doc: `Sends an HTTP request and returns an HTTP response.
   request is a map with the following keys:
   - url (string)
   - method (string, keyword or symbol, defaults to :get)
@@ -29,17 +28,21 @@ func InternsOrThunks() {
   - status (int)
   - body (string)
   - headers (map)
-  - content-length (int)`, "1.0"))
+  - content-length (int)`,
+		Added: "1.0",
+	})
 
-	httpNamespace.InternVar("start-file-server", start_file_server_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("addr"), MakeSymbol("root"))),
-			`Starts HTTP server on the TCP network address addr that
-  serves HTTP requests with the contents of the file system rooted at root.`, "1.0"))
+	httpNamespace.InternVarPacked("start-file-server", start_file_server_, &VarMeta{
+		Arglists: "([addr root])",
+		Doc: `Starts HTTP server on the TCP network address addr that
+  serves HTTP requests with the contents of the file system rooted at root.`,
+		Added: "1.0",
+	})
 
-	httpNamespace.InternVar("start-server", start_server_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("addr"), MakeSymbol("handler"))),
-			`Starts HTTP server on the TCP network address addr.`, "1.0"))
+	httpNamespace.InternVarPacked("start-server", start_server_, &VarMeta{
+		Arglists: "([addr handler])",
+		Doc:      `Starts HTTP server on the TCP network address addr.`,
+		Added:    "1.0",
+	})
 
 }
@@ -73,9 +73,9 @@ func reqToMap(host String, port String, req *http.Request) Map {
 	res.Add(MakeKeyword("protocol"), MakeString(req.Proto))
 	res.Add(MakeKeyword("scheme"), MakeKeyword("http"))
 	res.Add(MakeKeyword("host"), MakeString(req.Host))
-	headers := EmptyArrayMap()
+	var headers Associative = EmptyArrayMap()
 	for k, v := range req.Header {
-		headers.Add(MakeString(strings.ToLower(k)), MakeString(strings.Join(v, ",")))
+		headers = headers.Assoc(MakeString(strings.ToLower(k)), MakeString(strings.Join(v, ",")))
 	}
 	res.Add(MakeKeyword("headers"), headers)
 	return res
@@ -88,9 +88,9 @@ func respToMap(resp *http.Response) Map {
 	PanicOnErr(err)
 	res.Add(MakeKeyword("body"), MakeString(string(body)))
 	res.Add(MakeKeyword("status"), MakeInt(resp.StatusCode))
-	respHeaders := EmptyArrayMap()
+	var respHeaders Associative = EmptyArrayMap()
 	for k, v := range resp.Header {
-		respHeaders.Add(MakeString(k), MakeStringVector(v))
+		respHeaders = respHeaders.Assoc(MakeString(k), MakeStringVector(v))
 	}
 	res.Add(MakeKeyword("headers"), respHeaders)
 	// TODO: 32-bit issue
@@ -135,9 +135,9 @@ func mapToResp(response Map, w http.ResponseWriter) {
 
 func sendRequest(request Map) Map {
 	req := mapToReq(request)
-	RT.GIL.Unlock()
+	gs := RT.GIL.Unlock()
 	resp, err := client.Do(req)
-	RT.GIL.Lock()
+	RT.GIL.Lock(gs)
 	PanicOnErr(err)
 	return respToMap(resp)
 }
@@ -149,13 +149,15 @@ func startServer(addr string, handler Callable) Object {
 		host = MakeString(addr[:i])
 		port = MakeString(addr[i+1:])
 	}
-	RT.GIL.Unlock()
-	defer RT.GIL.Lock()
+	gs := RT.GIL.Unlock()
+	defer RT.GIL.Lock(gs)
 	err := http.ListenAndServe(addr, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		RT.GIL.Lock()
+		RT.GIL.Lock(nil)
+		s := RT.SaveCallState()
 		defer func() {
+			r := RT.Recovered(recover(), s)
 			RT.GIL.Unlock()
-			if r := recover(); r != nil {
+			if r != nil {
 				w.WriteHeader(500)
 				io.WriteString(w, "Internal server error")
 				fmt.Fprintln(os.Stderr, r)
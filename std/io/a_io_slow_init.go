@@ -14,24 +14,28 @@ func InternsOrThunks() {
 	}
 	ioNamespace.ResetMeta(MakeMeta(nil, `Provides basic interfaces to I/O primitives.`, "1.0"))
 
-	ioNamespace.InternVar("close", close_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("f"))),
-			`Closes f (IOWriter, IOReader, or File) if possible. Otherwise throws an error.`, "1.0"))
+	ioNamespace.InternVarPacked("close", close_, &VarMeta{
+		Arglists: "([f])",
+		Doc:      `Closes f (IOWriter, IOReader, or File) if possible. Otherwise throws an error.`,
+		Added:    "1.0",
+	})
 
-	ioNamespace.InternVar("copy", copy_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("dst"), MakeSymbol("src"))),
-			`Copies from src to dst until either EOF is reached on src or an error occurs.
+	ioNamespace.InternVarPacked("copy", copy_, &VarMeta{
+		Arglists: "([dst src])",
+		Doc: `Copies from src to dst until either EOF is reached on src or an error occurs.
   Returns the number of bytes copied or throws an error.
   src must be IOReader, e.g. as returned by joker.os/open.
-  dst must be IOWriter, e.g. as returned by joker.os/create.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Int"}))
+  dst must be IOWriter, e.g. as returned by joker.os/create.`,
+		Added: "1.0",
+		Extra: "{:tag \"Int\"}",
+	})
 
-	ioNamespace.InternVar("pipe", pipe_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom()),
-			`Pipe creates a synchronous in-memory pipe. It can be used to connect code expecting an IOReader
+	ioNamespace.InternVarPacked("pipe", pipe_, &VarMeta{
+		Arglists: "([])",
+		Doc: `Pipe creates a synchronous in-memory pipe. It can be used to connect code expecting an IOReader
   with code expecting an IOWriter.
-  Returns a vector [reader, writer].`, "1.0"))
+  Returns a vector [reader, writer].`,
+		Added: "1.0",
+	})
 
 }
@@ -14,24 +14,27 @@ func InternsOrThunks() {
 	}
 	jsonNamespace.ResetMeta(MakeMeta(nil, `Implements encoding and decoding of JSON as defined in RFC 4627.`, "1.0"))
 
-	jsonNamespace.InternVar("json-seq", json_seq_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("rdr")), NewVectorFrom(MakeSymbol("rdr"), MakeSymbol("opts"))),
-			`Returns the json records from rdr as a lazy sequence.
+	jsonNamespace.InternVarPacked("json-seq", json_seq_, &VarMeta{
+		Arglists: "([rdr] [rdr opts])",
+		Doc: `Returns the json records from rdr as a lazy sequence.
   rdr must be a string or implement io.Reader.
   Optional opts map may have the following keys:
-  :keywords? - if true, JSON keys will be converted from strings to keywords.`, "1.0"))
+  :keywords? - if true, JSON keys will be converted from strings to keywords.`,
+		Added: "1.0",
+	})
 
-	jsonNamespace.InternVar("read-string", read_string_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s")), NewVectorFrom(MakeSymbol("s"), MakeSymbol("opts"))),
-			`Parses the JSON-encoded data and return the result as a Joker value.
+	jsonNamespace.InternVarPacked("read-string", read_string_, &VarMeta{
+		Arglists: "([s] [s opts])",
+		Doc: `Parses the JSON-encoded data and return the result as a Joker value.
   Optional opts map may have the following keys:
-  :keywords? - if true, JSON keys will be converted from strings to keywords.`, "1.0"))
+  :keywords? - if true, JSON keys will be converted from strings to keywords.`,
+		Added: "1.0",
+	})
 
-	jsonNamespace.InternVar("write-string", write_string_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("v"))),
-			`Returns the JSON encoding of v.`, "1.0"))
+	jsonNamespace.InternVarPacked("write-string", write_string_, &VarMeta{
+		Arglists: "([v])",
+		Doc:      `Returns the JSON encoding of v.`,
+		Added:    "1.0",
+	})
 
 }
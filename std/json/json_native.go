@@ -67,7 +67,7 @@ func toObject(v interface{}, keywordize bool) Object {
 		}
 		return res
 	case map[string]interface{}:
-		res := EmptyArrayMap()
+		var res Associative = EmptyArrayMap()
 		for k, v := range v {
 			var key Object
 			if keywordize {
@@ -75,7 +75,7 @@ func toObject(v interface{}, keywordize bool) Object {
 			} else {
 				key = MakeString(k)
 			}
-			res.Add(key, toObject(v, keywordize))
+			res = res.Assoc(key, toObject(v, keywordize))
 		}
 		return res
 	default:
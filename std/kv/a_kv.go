@@ -0,0 +1,168 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+package kv
+
+import (
+	. "github.com/candid82/joker/core"
+)
+
+var __close__P ProcFn = __close_
+var close_ Proc = Proc{Fn: __close__P, Name: "close_", Package: "std/kv"}
+
+func __close_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		store := ExtractKVStore(_args, 0)
+		_res := closeStore(store)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __delete_bang__P ProcFn = __delete_bang_
+var delete_bang_ Proc = Proc{Fn: __delete_bang__P, Name: "delete_bang_", Package: "std/kv"}
+
+func __delete_bang_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		kv := ExtractKV(_args, 0)
+		key := ExtractString(_args, 1)
+		_res := deleteKey(kv, key)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __get__P ProcFn = __get_
+var get_ Proc = Proc{Fn: __get__P, Name: "get_", Package: "std/kv"}
+
+func __get_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		kv := ExtractKV(_args, 0)
+		key := ExtractString(_args, 1)
+		_res := get(kv, key, NIL)
+		return _res
+
+	case _c == 3:
+		kv := ExtractKV(_args, 0)
+		key := ExtractString(_args, 1)
+		fallback := ExtractObject(_args, 2)
+		_res := get(kv, key, fallback)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __open__P ProcFn = __open_
+var open_ Proc = Proc{Fn: __open__P, Name: "open_", Package: "std/kv"}
+
+func __open_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		path := ExtractString(_args, 0)
+		_res := open(path, EmptyArrayMap())
+		return MakeKVStore(_res)
+
+	case _c == 2:
+		path := ExtractString(_args, 0)
+		opts := ExtractMap(_args, 1)
+		_res := open(path, opts)
+		return MakeKVStore(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __put_bang__P ProcFn = __put_bang_
+var put_bang_ Proc = Proc{Fn: __put_bang__P, Name: "put_bang_", Package: "std/kv"}
+
+func __put_bang_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 3:
+		kv := ExtractKV(_args, 0)
+		key := ExtractString(_args, 1)
+		value := ExtractObject(_args, 2)
+		_res := put(kv, key, value)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __scan__P ProcFn = __scan_
+var scan_ Proc = Proc{Fn: __scan__P, Name: "scan_", Package: "std/kv"}
+
+func __scan_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		kv := ExtractKV(_args, 0)
+		_res := scan(kv, "")
+		return _res
+
+	case _c == 2:
+		kv := ExtractKV(_args, 0)
+		prefix := ExtractString(_args, 1)
+		_res := scan(kv, prefix)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __with_transaction__P ProcFn = __with_transaction_
+var with_transaction_ Proc = Proc{Fn: __with_transaction__P, Name: "with_transaction_", Package: "std/kv"}
+
+func __with_transaction_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		store := ExtractKVStore(_args, 0)
+		f := ExtractCallable(_args, 1)
+		_res := withTransaction(store, EmptyArrayMap(), f)
+		return _res
+
+	case _c == 3:
+		store := ExtractKVStore(_args, 0)
+		opts := ExtractMap(_args, 1)
+		f := ExtractCallable(_args, 2)
+		_res := withTransaction(store, opts, f)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+func Init() {
+
+	InternsOrThunks()
+}
+
+var kvNamespace = GLOBAL_ENV.EnsureSymbolIsLib(MakeSymbol("joker.kv"))
+
+func init() {
+	kvNamespace.Lazy = Init
+}
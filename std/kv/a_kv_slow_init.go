@@ -0,0 +1,108 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+package kv
+
+import (
+	"fmt"
+	. "github.com/candid82/joker/core"
+	"os"
+)
+
+func InternsOrThunks() {
+	if VerbosityLevel > 0 {
+		fmt.Fprintln(os.Stderr, "Lazily running slow version of kv.InternsOrThunks().")
+	}
+	kvNamespace.ResetMeta(MakeMeta(nil, `Provides a durable key-value store kept in a single file, backed by
+         the Bolt embedded database https://github.com/etcd-io/bbolt.
+
+         Keys are strings and values are arbitrary Joker data, which is stored
+         in its printed form (as by pr-str) and read back when retrieved.
+         So values must be readable: functions, atoms and other objects
+         printed as #object[...] can't be stored.
+
+         Every function reading or changing the store runs in a transaction of
+         its own, unless a transaction started by with-transaction is passed
+         instead of the store. Only one process can have a store open at a time.
+
+         Example:
+
+         user=> (def store (joker.kv/open "cache.db"))
+         #'user/store
+         user=> (joker.kv/put! store "user:1" {:name "Joe Black" :roles #{:admin}})
+         nil
+         user=> (joker.kv/get store "user:1")
+         {:name "Joe Black", :roles #{:admin}}
+         user=> (joker.kv/scan store "user:")
+         (["user:1" {:name "Joe Black", :roles #{:admin}}])`, "1.0"))
+
+	kvNamespace.InternVarPacked("close", close_, &VarMeta{
+		Arglists: "([store])",
+		Doc:      `Closes the store, waiting for pending transactions to finish.`,
+		Added:    "1.2",
+	})
+
+	kvNamespace.InternVarPacked("delete!", delete_bang_, &VarMeta{
+		Arglists: "([kv key])",
+		Doc: `Removes key and its value from kv (a store or a transaction).
+  Does nothing if there's no such key.`,
+		Added: "1.2",
+	})
+
+	kvNamespace.InternVarPacked("get", get_, &VarMeta{
+		Arglists: "([kv key] [kv key fallback])",
+		Doc: `Returns the value stored under key in kv (a store or a transaction),
+  or fallback (nil by default) if there's none.`,
+		Added: "1.2",
+	})
+
+	kvNamespace.InternVarPacked("open", open_, &VarMeta{
+		Arglists: "([path] [path opts])",
+		Doc: `Opens the store in the file at path, creating the file if it doesn't exist.
+  opts is a map with optional keys:
+  :bucket - the name of the Bolt bucket holding the store ("joker.kv" by default),
+  so that several stores can be kept in one file,
+  :mode - the permissions of the file if it's created (0600 by default),
+  :timeout - the time in nanoseconds to wait for the file to be closed by other
+  processes (by default, waits indefinitely),
+  :read-only - if true, the store is opened for reading only, which allows
+  other processes to open it for reading as well.`,
+		Added: "1.2",
+		Extra: "{:tag \"KVStore\"}",
+	})
+
+	kvNamespace.InternVarPacked("put!", put_bang_, &VarMeta{
+		Arglists: "([kv key value])",
+		Doc: `Stores value under key in kv (a store or a transaction).
+  key must not be empty.`,
+		Added: "1.2",
+	})
+
+	kvNamespace.InternVarPacked("scan", scan_, &VarMeta{
+		Arglists: "([kv] [kv prefix])",
+		Doc: `Returns a seq of [key value] pairs of kv (a store or a transaction) whose keys
+  start with prefix (all of them by default), ordered by key.
+  For a store, the seq is lazy: entries are read in chunks, each in a
+  transaction of its own, as the seq is consumed. For a transaction,
+  the seq is fully realized as it can't be used after the transaction ends.`,
+		Added: "1.2",
+	})
+
+	kvNamespace.InternVarPacked("with-transaction", with_transaction_, &VarMeta{
+		Arglists: "([store f] [store opts f])",
+		Doc: `Starts a transaction of store and calls f with it. Commits the transaction
+  and returns the result of f if f returns normally; rolls the transaction
+  back and rethrows if f throws. The transaction can be passed to get,
+  put!, delete! and scan instead of the store, but only until f returns.
+  Only one read-write transaction runs at a time, so changing the store
+  itself rather than the transaction within f blocks forever.
+  opts is a map with optional key
+  :read-only - if true, the transaction can only read from the store,
+  which allows it to run concurrently with other transactions.
+
+  (with-transaction store
+    (fn [tx]
+      (put! tx "counter" (inc (get tx "counter" 0)))))`,
+		Added: "1.2",
+	})
+
+}
@@ -0,0 +1,345 @@
+package kv
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"time"
+	"unsafe"
+
+	. "github.com/candid82/joker/core"
+	bolt "go.etcd.io/bbolt"
+)
+
+type (
+	// KVStore is a key-value store kept in a single bucket of a Bolt database.
+	KVStore struct {
+		*kvStore
+		hash uint32
+	}
+
+	kvStore struct {
+		db     *bolt.DB
+		bucket []byte
+	}
+
+	// KVTx is a transaction started by with-transaction. It's only valid
+	// until with-transaction returns.
+	KVTx struct {
+		*kvTx
+		hash uint32
+	}
+
+	kvTx struct {
+		tx     *bolt.Tx
+		bucket []byte
+		closed bool
+	}
+
+	// KV is either KVStore or KVTx.
+	KV interface {
+		Object
+		view(f func(b *bolt.Bucket))
+		update(f func(b *bolt.Bucket) error)
+	}
+)
+
+var kvStoreType *Type
+var kvTxType *Type
+
+const scanChunkSize = 256
+
+func MakeKVStore(s *kvStore) KVStore {
+	res := KVStore{s, 0}
+	res.hash = HashPtr(uintptr(unsafe.Pointer(s)))
+	return res
+}
+
+func (s KVStore) ToString(escape bool) string {
+	return "#object[KVStore]"
+}
+
+func (s KVStore) Equals(other interface{}) bool {
+	if otherS, ok := other.(KVStore); ok {
+		return s.kvStore == otherS.kvStore
+	}
+	return false
+}
+
+func (s KVStore) GetInfo() *ObjectInfo {
+	return nil
+}
+
+func (s KVStore) GetType() *Type {
+	return kvStoreType
+}
+
+func (s KVStore) Hash() uint32 {
+	return s.hash
+}
+
+func (s KVStore) WithInfo(info *ObjectInfo) Object {
+	return s
+}
+
+func EnsureArgIsKVStore(args []Object, index int) KVStore {
+	obj := args[index]
+	if c, yes := obj.(KVStore); yes {
+		return c
+	}
+	panic(FailArg(obj, "KVStore", index))
+}
+
+func ExtractKVStore(args []Object, index int) KVStore {
+	return EnsureArgIsKVStore(args, index)
+}
+
+func MakeKVTx(tx *kvTx) KVTx {
+	res := KVTx{tx, 0}
+	res.hash = HashPtr(uintptr(unsafe.Pointer(tx)))
+	return res
+}
+
+func (tx KVTx) ToString(escape bool) string {
+	return "#object[KVTx]"
+}
+
+func (tx KVTx) Equals(other interface{}) bool {
+	if otherTx, ok := other.(KVTx); ok {
+		return tx.kvTx == otherTx.kvTx
+	}
+	return false
+}
+
+func (tx KVTx) GetInfo() *ObjectInfo {
+	return nil
+}
+
+func (tx KVTx) GetType() *Type {
+	return kvTxType
+}
+
+func (tx KVTx) Hash() uint32 {
+	return tx.hash
+}
+
+func (tx KVTx) WithInfo(info *ObjectInfo) Object {
+	return tx
+}
+
+func EnsureArgIsKV(args []Object, index int) KV {
+	obj := args[index]
+	if c, yes := obj.(KV); yes {
+		return c
+	}
+	panic(FailArg(obj, "KVStore or KVTx", index))
+}
+
+func ExtractKV(args []Object, index int) KV {
+	return EnsureArgIsKV(args, index)
+}
+
+// begin starts a Bolt transaction, releasing the GIL while waiting
+// for other writers (possibly in other goroutines) to finish.
+func (s *kvStore) begin(writable bool) *bolt.Tx {
+	gs := RT.GIL.Unlock()
+	tx, err := s.db.Begin(writable)
+	RT.GIL.Lock(gs)
+	PanicOnErr(err)
+	return tx
+}
+
+func (s *kvStore) view(f func(b *bolt.Bucket)) {
+	tx := s.begin(false)
+	defer tx.Rollback()
+	f(tx.Bucket(s.bucket))
+}
+
+func (s *kvStore) update(f func(b *bolt.Bucket) error) {
+	tx := s.begin(true)
+	defer tx.Rollback()
+	PanicOnErr(f(tx.Bucket(s.bucket)))
+	gs := RT.GIL.Unlock()
+	err := tx.Commit()
+	RT.GIL.Lock(gs)
+	PanicOnErr(err)
+}
+
+func (tx *kvTx) bucketOf() *bolt.Bucket {
+	if tx.closed {
+		panic(RT.NewError("Transaction is closed"))
+	}
+	return tx.tx.Bucket(tx.bucket)
+}
+
+func (tx *kvTx) view(f func(b *bolt.Bucket)) {
+	f(tx.bucketOf())
+}
+
+func (tx *kvTx) update(f func(b *bolt.Bucket) error) {
+	PanicOnErr(f(tx.bucketOf()))
+}
+
+func encode(obj Object) []byte {
+	return []byte(obj.ToString(true))
+}
+
+func decode(key, value []byte) Object {
+	obj, err := TryRead(NewReader(strings.NewReader(string(value)), "<kv>"))
+	if err != nil {
+		panic(RT.NewError("Cannot read value of key " + string(key) + ": " + err.Error()))
+	}
+	return obj
+}
+
+func open(path string, opts Map) *kvStore {
+	boltOpts := &bolt.Options{}
+	if ok, v := opts.Get(MakeKeyword("timeout")); ok {
+		boltOpts.Timeout = time.Duration(EnsureObjectIsInt(v, "timeout: %s").I)
+	}
+	if ok, v := opts.Get(MakeKeyword("read-only")); ok {
+		boltOpts.ReadOnly = ToBool(v)
+	}
+	mode := os.FileMode(0600)
+	if ok, v := opts.Get(MakeKeyword("mode")); ok {
+		mode = os.FileMode(EnsureObjectIsInt(v, "mode: %s").I)
+	}
+	bucket := "joker.kv"
+	if ok, v := opts.Get(MakeKeyword("bucket")); ok {
+		bucket = EnsureObjectIsString(v, "bucket: %s").S
+	}
+	gs := RT.GIL.Unlock()
+	db, err := bolt.Open(path, mode, boltOpts)
+	RT.GIL.Lock(gs)
+	PanicOnErr(err)
+	s := &kvStore{db, []byte(bucket)}
+	if boltOpts.ReadOnly {
+		s.view(func(b *bolt.Bucket) {
+			if b == nil {
+				db.Close()
+				panic(RT.NewError("No store named " + bucket + " in " + path))
+			}
+		})
+		return s
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(s.bucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		PanicOnErr(err)
+	}
+	return s
+}
+
+func closeStore(s KVStore) Nil {
+	PanicOnErr(s.db.Close())
+	return NIL
+}
+
+func get(kv KV, key string, fallback Object) Object {
+	res := fallback
+	kv.view(func(b *bolt.Bucket) {
+		if v := b.Get([]byte(key)); v != nil {
+			res = decode([]byte(key), v)
+		}
+	})
+	return res
+}
+
+func put(kv KV, key string, value Object) Nil {
+	v := encode(value)
+	kv.update(func(b *bolt.Bucket) error {
+		return b.Put([]byte(key), v)
+	})
+	return NIL
+}
+
+func deleteKey(kv KV, key string) Nil {
+	kv.update(func(b *bolt.Bucket) error {
+		return b.Delete([]byte(key))
+	})
+	return NIL
+}
+
+// scanChunk returns up to n (or all if n is negative) entries starting
+// at the first key with prefix that is greater than after (or the first
+// such key if after is nil), and the last key returned.
+func scanChunk(b *bolt.Bucket, prefix, after []byte, n int) (res []Object, last []byte) {
+	c := b.Cursor()
+	start := prefix
+	if after != nil {
+		start = after
+	}
+	for k, v := c.Seek(start); k != nil && bytes.HasPrefix(k, prefix) && (n < 0 || len(res) < n); k, v = c.Next() {
+		if after != nil && bytes.Equal(k, after) {
+			continue
+		}
+		res = append(res, NewVectorFrom(MakeString(string(k)), decode(k, v)))
+		last = append([]byte(nil), k...)
+	}
+	return res, last
+}
+
+func storeScan(s *kvStore, prefix, after []byte) *LazySeq {
+	var c = func(args []Object) Object {
+		var entries []Object
+		var last []byte
+		s.view(func(b *bolt.Bucket) {
+			entries, last = scanChunk(b, prefix, after, scanChunkSize)
+		})
+		var res Seq = EmptyList
+		if len(entries) == scanChunkSize {
+			res = storeScan(s, prefix, last)
+		}
+		for i := len(entries) - 1; i >= 0; i-- {
+			res = NewConsSeq(entries[i], res)
+		}
+		return res
+	}
+	return NewLazySeq(Proc{Fn: c})
+}
+
+func scan(kv KV, prefix string) Seq {
+	if s, ok := kv.(KVStore); ok {
+		return storeScan(s.kvStore, []byte(prefix), nil)
+	}
+	var entries []Object
+	kv.view(func(b *bolt.Bucket) {
+		entries, _ = scanChunk(b, []byte(prefix), nil, -1)
+	})
+	return NewVectorFrom(entries...).Seq()
+}
+
+func withTransaction(s KVStore, opts Map, f Callable) Object {
+	writable := true
+	if ok, v := opts.Get(MakeKeyword("read-only")); ok {
+		writable = !ToBool(v)
+	}
+	tx := &kvTx{tx: s.begin(writable), bucket: s.bucket}
+	committed := false
+	defer func() {
+		tx.closed = true
+		if !committed {
+			tx.tx.Rollback()
+		}
+	}()
+	res := f.Call([]Object{MakeKVTx(tx)})
+	tx.closed = true
+	committed = true
+	if !writable {
+		PanicOnErr(tx.tx.Rollback())
+		return res
+	}
+	gs := RT.GIL.Unlock()
+	err := tx.tx.Commit()
+	RT.GIL.Lock(gs)
+	PanicOnErr(err)
+	return res
+}
+
+func init() {
+	kvStoreType = RegType("KVStore", (*KVStore)(nil), "A key-value store backed by a Bolt database")
+	kvTxType = RegType("KVTx", (*KVTx)(nil), "A key-value store transaction")
+}
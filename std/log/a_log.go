@@ -0,0 +1,290 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+package log
+
+import (
+	. "github.com/candid82/joker/core"
+)
+
+var __close_sink_bang__P ProcFn = __close_sink_bang_
+var close_sink_bang_ Proc = Proc{Fn: __close_sink_bang__P, Name: "close_sink_bang_", Package: "std/log"}
+
+func __close_sink_bang_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		sink := ExtractLogSink(_args, 0)
+		_res := closeSink(sink)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __debug__P ProcFn = __debug_
+var debug_ Proc = Proc{Fn: __debug__P, Name: "debug_", Package: "std/log"}
+
+func __debug_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		msg := ExtractString(_args, 0)
+		_res := log(":debug", msg, EmptyArrayMap())
+		return _res
+
+	case _c == 2:
+		msg := ExtractString(_args, 0)
+		fields := ExtractMap(_args, 1)
+		_res := log(":debug", msg, fields)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __isenabled__P ProcFn = __isenabled_
+var isenabled_ Proc = Proc{Fn: __isenabled__P, Name: "isenabled_", Package: "std/log"}
+
+func __isenabled_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		level := ExtractKeyword(_args, 0)
+		_res := isEnabled(level)
+		return MakeBoolean(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __error__P ProcFn = __error_
+var error_ Proc = Proc{Fn: __error__P, Name: "error_", Package: "std/log"}
+
+func __error_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		msg := ExtractString(_args, 0)
+		_res := log(":error", msg, EmptyArrayMap())
+		return _res
+
+	case _c == 2:
+		msg := ExtractString(_args, 0)
+		fields := ExtractMap(_args, 1)
+		_res := log(":error", msg, fields)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __eventlog_sink__P ProcFn = __eventlog_sink_
+var eventlog_sink_ Proc = Proc{Fn: __eventlog_sink__P, Name: "eventlog_sink_", Package: "std/log"}
+
+func __eventlog_sink_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		source := ExtractString(_args, 0)
+		_res := eventlogSink(source)
+		return MakeLogSink(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __file_sink__P ProcFn = __file_sink_
+var file_sink_ Proc = Proc{Fn: __file_sink__P, Name: "file_sink_", Package: "std/log"}
+
+func __file_sink_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		path := ExtractString(_args, 0)
+		_res := fileSink(path)
+		return MakeLogSink(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __info__P ProcFn = __info_
+var info_ Proc = Proc{Fn: __info__P, Name: "info_", Package: "std/log"}
+
+func __info_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		msg := ExtractString(_args, 0)
+		_res := log(":info", msg, EmptyArrayMap())
+		return _res
+
+	case _c == 2:
+		msg := ExtractString(_args, 0)
+		fields := ExtractMap(_args, 1)
+		_res := log(":info", msg, fields)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __log__P ProcFn = __log_
+var log_ Proc = Proc{Fn: __log__P, Name: "log_", Package: "std/log"}
+
+func __log_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		level := ExtractKeyword(_args, 0)
+		msg := ExtractString(_args, 1)
+		_res := log(level, msg, EmptyArrayMap())
+		return _res
+
+	case _c == 3:
+		level := ExtractKeyword(_args, 0)
+		msg := ExtractString(_args, 1)
+		fields := ExtractMap(_args, 2)
+		_res := log(level, msg, fields)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __set_level_bang__P ProcFn = __set_level_bang_
+var set_level_bang_ Proc = Proc{Fn: __set_level_bang__P, Name: "set_level_bang_", Package: "std/log"}
+
+func __set_level_bang_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		level := ExtractKeyword(_args, 0)
+		_res := setLevel(level)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __set_sinks_bang__P ProcFn = __set_sinks_bang_
+var set_sinks_bang_ Proc = Proc{Fn: __set_sinks_bang__P, Name: "set_sinks_bang_", Package: "std/log"}
+
+func __set_sinks_bang_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		sinks := ExtractObject(_args, 0)
+		_res := setSinks(sinks)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __sinks__P ProcFn = __sinks_
+var sinks_ Proc = Proc{Fn: __sinks__P, Name: "sinks_", Package: "std/log"}
+
+func __sinks_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := currentSinks()
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __stderr_sink__P ProcFn = __stderr_sink_
+var stderr_sink_ Proc = Proc{Fn: __stderr_sink__P, Name: "stderr_sink_", Package: "std/log"}
+
+func __stderr_sink_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := stderrSink()
+		return MakeLogSink(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __syslog_sink__P ProcFn = __syslog_sink_
+var syslog_sink_ Proc = Proc{Fn: __syslog_sink__P, Name: "syslog_sink_", Package: "std/log"}
+
+func __syslog_sink_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := syslogSink(EmptyArrayMap())
+		return MakeLogSink(_res)
+
+	case _c == 1:
+		opts := ExtractMap(_args, 0)
+		_res := syslogSink(opts)
+		return MakeLogSink(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __warn__P ProcFn = __warn_
+var warn_ Proc = Proc{Fn: __warn__P, Name: "warn_", Package: "std/log"}
+
+func __warn_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		msg := ExtractString(_args, 0)
+		_res := log(":warn", msg, EmptyArrayMap())
+		return _res
+
+	case _c == 2:
+		msg := ExtractString(_args, 0)
+		fields := ExtractMap(_args, 1)
+		_res := log(":warn", msg, fields)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+func Init() {
+
+	InternsOrThunks()
+}
+
+var logNamespace = GLOBAL_ENV.EnsureSymbolIsLib(MakeSymbol("joker.log"))
+
+func init() {
+	logNamespace.Lazy = Init
+}
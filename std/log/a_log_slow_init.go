@@ -0,0 +1,139 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+package log
+
+import (
+	"fmt"
+	. "github.com/candid82/joker/core"
+	"os"
+)
+
+func InternsOrThunks() {
+	if VerbosityLevel > 0 {
+		fmt.Fprintln(os.Stderr, "Lazily running slow version of log.InternsOrThunks().")
+	}
+	logNamespace.ResetMeta(MakeMeta(nil, `Provides leveled logging to configurable sinks: standard error, files,
+         syslog and the Windows event log.
+
+         Messages below the current level (:info by default) are discarded.
+         The others are written to every sink set with set-sinks!, or to *err*
+         if no sinks were set. Fields given as a map are appended to the message
+         as key=value pairs.
+
+         Example:
+
+         (joker.log/set-sinks! [(joker.log/stderr-sink)
+                                (joker.log/syslog-sink {:tag "myapp" :facility :daemon})])
+         (joker.log/info "Server started" {:port 8080})
+
+         writes
+
+         2026-10-16T12:00:00.000+02:00 INFO Server started port=8080
+
+         to standard error and sends "Server started port=8080" to the local syslog daemon
+         (and so to the systemd journal) with the informational priority.`, "1.0"))
+
+	logNamespace.InternVarPacked("close-sink!", close_sink_bang_, &VarMeta{
+		Arglists: "([sink])",
+		Doc:      `Closes the file or connection of sink.`,
+		Added:    "1.2",
+	})
+
+	logNamespace.InternVarPacked("debug", debug_, &VarMeta{
+		Arglists: "([msg] [msg fields])",
+		Doc:      `Logs msg at the :debug level, followed by fields.`,
+		Added:    "1.2",
+	})
+
+	logNamespace.InternVarPacked("enabled?", isenabled_, &VarMeta{
+		Arglists: "([level])",
+		Doc:      `Returns true if messages at level are logged.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Boolean\"}",
+	})
+
+	logNamespace.InternVarPacked("error", error_, &VarMeta{
+		Arglists: "([msg] [msg fields])",
+		Doc:      `Logs msg at the :error level, followed by fields.`,
+		Added:    "1.2",
+	})
+
+	logNamespace.InternVarPacked("eventlog-sink", eventlog_sink_, &VarMeta{
+		Arglists: "([source])",
+		Doc: `Returns a sink writing to the Windows event log as source, with the event type
+  corresponding to the level (information for :debug and :info).
+  The source should be registered (e.g. with New-EventLog) for messages
+  to be shown properly. Only supported on Windows.`,
+		Added: "1.2",
+		Extra: "{:tag \"LogSink\"}",
+	})
+
+	logNamespace.InternVarPacked("file-sink", file_sink_, &VarMeta{
+		Arglists: "([path])",
+		Doc: `Returns a sink appending lines with a timestamp, the level and the message
+  to the file at path, which is created if it doesn't exist.`,
+		Added: "1.2",
+		Extra: "{:tag \"LogSink\"}",
+	})
+
+	logNamespace.InternVarPacked("info", info_, &VarMeta{
+		Arglists: "([msg] [msg fields])",
+		Doc:      `Logs msg at the :info level, followed by fields.`,
+		Added:    "1.2",
+	})
+
+	logNamespace.InternVarPacked("log", log_, &VarMeta{
+		Arglists: "([level msg] [level msg fields])",
+		Doc:      `Logs msg at level (:debug, :info, :warn or :error), followed by fields.`,
+		Added:    "1.2",
+	})
+
+	logNamespace.InternVarPacked("set-level!", set_level_bang_, &VarMeta{
+		Arglists: "([level])",
+		Doc: `Sets the minimum level of logged messages: one of :debug, :info (the default),
+  :warn and :error.`,
+		Added: "1.2",
+	})
+
+	logNamespace.InternVarPacked("set-sinks!", set_sinks_bang_, &VarMeta{
+		Arglists: "([sinks])",
+		Doc:      `Sets the sinks messages are written to. Doesn't close the previous sinks.`,
+		Added:    "1.2",
+	})
+
+	logNamespace.InternVarPacked("sinks", sinks_, &VarMeta{
+		Arglists: "([])",
+		Doc:      `Returns a vector of the sinks messages are written to.`,
+		Added:    "1.2",
+	})
+
+	logNamespace.InternVarPacked("stderr-sink", stderr_sink_, &VarMeta{
+		Arglists: "([])",
+		Doc: `Returns a sink writing lines with a timestamp, the level and the message
+  to the value of *err* at the time of logging.`,
+		Added: "1.2",
+		Extra: "{:tag \"LogSink\"}",
+	})
+
+	logNamespace.InternVarPacked("syslog-sink", syslog_sink_, &VarMeta{
+		Arglists: "([] [opts])",
+		Doc: `Returns a sink sending messages to syslog, with priorities corresponding to the levels.
+  opts is a map with optional keys:
+  :network - "udp" or "tcp" to send messages to a remote syslog server;
+  if omitted, messages are sent to the local syslog daemon,
+  :address - the address of the remote server, e.g. "logs.example.com:514",
+  :tag - the tag of messages (the program name by default),
+  :facility - one of :kern, :user (the default), :mail, :daemon, :auth, :syslog,
+  :lpr, :news, :uucp, :cron, :authpriv, :ftp and :local0 to :local7.
+  Not supported on Windows and Plan 9.`,
+		Added: "1.2",
+		Extra: "{:tag \"LogSink\"}",
+	})
+
+	logNamespace.InternVarPacked("warn", warn_, &VarMeta{
+		Arglists: "([msg] [msg fields])",
+		Doc:      `Logs msg at the :warn level, followed by fields.`,
+		Added:    "1.2",
+	})
+
+}
@@ -0,0 +1,12 @@
+//go:build !windows
+// +build !windows
+
+package log
+
+import (
+	. "github.com/candid82/joker/core"
+)
+
+func eventlogSink(source string) *sink {
+	panic(RT.NewError("eventlog-sink is only supported on Windows"))
+}
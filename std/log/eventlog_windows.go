@@ -0,0 +1,64 @@
+package log
+
+import (
+	"syscall"
+	"unsafe"
+
+	. "github.com/candid82/joker/core"
+)
+
+var (
+	advapi32                  = syscall.NewLazyDLL("advapi32.dll")
+	procRegisterEventSourceW  = advapi32.NewProc("RegisterEventSourceW")
+	procDeregisterEventSource = advapi32.NewProc("DeregisterEventSource")
+	procReportEventW          = advapi32.NewProc("ReportEventW")
+)
+
+const (
+	eventlogErrorType       = 0x0001
+	eventlogWarningType     = 0x0002
+	eventlogInformationType = 0x0004
+)
+
+type eventlogWriter struct {
+	handle uintptr
+}
+
+func (w eventlogWriter) writeEntry(e *entry) error {
+	etype := eventlogInformationType
+	switch e.level {
+	case levelWarn:
+		etype = eventlogWarningType
+	case levelError:
+		etype = eventlogErrorType
+	}
+	s, err := syscall.UTF16PtrFromString(e.text)
+	if err != nil {
+		return err
+	}
+	strs := []*uint16{s}
+	r, _, err := procReportEventW.Call(w.handle, uintptr(etype), 0, 1, 0, 1, 0,
+		uintptr(unsafe.Pointer(&strs[0])), 0)
+	if r == 0 {
+		return err
+	}
+	return nil
+}
+
+func (w eventlogWriter) Close() error {
+	r, _, err := procDeregisterEventSource.Call(w.handle)
+	if r == 0 {
+		return err
+	}
+	return nil
+}
+
+func eventlogSink(source string) *sink {
+	s, err := syscall.UTF16PtrFromString(source)
+	PanicOnErr(err)
+	h, _, err := procRegisterEventSourceW.Call(0, uintptr(unsafe.Pointer(s)))
+	if h == 0 {
+		panic(RT.NewError("eventlog-sink: " + err.Error()))
+	}
+	return &sink{kind: "eventlog", out: eventlogWriter{h}}
+}
@@ -0,0 +1,252 @@
+package log
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+
+	. "github.com/candid82/joker/core"
+)
+
+const (
+	levelDebug = iota
+	levelInfo
+	levelWarn
+	levelError
+)
+
+var levelNames = []string{"DEBUG", "INFO", "WARN", "ERROR"}
+
+type (
+	// LogSink is a destination of log messages.
+	LogSink struct {
+		*sink
+		hash uint32
+	}
+
+	sink struct {
+		kind string
+		out  sinkWriter
+	}
+
+	sinkWriter interface {
+		writeEntry(e *entry) error
+		Close() error
+	}
+
+	entry struct {
+		time  time.Time
+		level int
+		// text is the message followed by the fields.
+		text string
+	}
+)
+
+var logSinkType *Type
+
+var (
+	sinks    []LogSink
+	minLevel = levelInfo
+)
+
+func MakeLogSink(s *sink) LogSink {
+	res := LogSink{s, 0}
+	res.hash = HashPtr(uintptr(unsafe.Pointer(s)))
+	return res
+}
+
+func (s LogSink) ToString(escape bool) string {
+	return "#object[LogSink " + s.kind + "]"
+}
+
+func (s LogSink) Equals(other interface{}) bool {
+	if otherS, ok := other.(LogSink); ok {
+		return s.sink == otherS.sink
+	}
+	return false
+}
+
+func (s LogSink) GetInfo() *ObjectInfo {
+	return nil
+}
+
+func (s LogSink) GetType() *Type {
+	return logSinkType
+}
+
+func (s LogSink) Hash() uint32 {
+	return s.hash
+}
+
+func (s LogSink) WithInfo(info *ObjectInfo) Object {
+	return s
+}
+
+func EnsureArgIsLogSink(args []Object, index int) LogSink {
+	obj := args[index]
+	if s, yes := obj.(LogSink); yes {
+		return s
+	}
+	panic(FailArg(obj, "LogSink", index))
+}
+
+func ExtractLogSink(args []Object, index int) LogSink {
+	return EnsureArgIsLogSink(args, index)
+}
+
+func parseLevel(level string) int {
+	switch level {
+	case ":debug":
+		return levelDebug
+	case ":info":
+		return levelInfo
+	case ":warn":
+		return levelWarn
+	case ":error":
+		return levelError
+	}
+	panic(RT.NewError("Unsupported log level " + level + ". Supported levels are: :debug, :info, :warn, :error"))
+}
+
+// formatLine returns e as a line with a timestamp and the level,
+// as written by the stream and file sinks.
+func formatLine(e *entry) string {
+	return e.time.Format("2006-01-02T15:04:05.000Z07:00") + " " + levelNames[e.level] + " " + e.text + "\n"
+}
+
+// formatValue returns v as shown in the key=value pairs appended to messages.
+// Strings are only quoted if they contain spaces, quotes or equal signs.
+func formatValue(v Object) string {
+	if s, ok := v.(String); ok {
+		if s.S == "" || strings.ContainsAny(s.S, " \t\n\"=") {
+			return strconv.Quote(s.S)
+		}
+		return s.S
+	}
+	return v.ToString(true)
+}
+
+func formatText(msg string, fields Map) string {
+	var pairs []string
+	for iter := fields.Iter(); iter.HasNext(); {
+		p := iter.Next()
+		key := p.Key.ToString(false)
+		if k, ok := p.Key.(Keyword); ok {
+			key = k.Name()
+		}
+		pairs = append(pairs, key+"="+formatValue(p.Value))
+	}
+	sort.Strings(pairs)
+	if len(pairs) == 0 {
+		return msg
+	}
+	return msg + " " + strings.Join(pairs, " ")
+}
+
+// streamWriter writes to the value of *err* at the time of logging,
+// so that log output follows rebinding of *err*.
+type streamWriter struct{}
+
+func (streamWriter) writeEntry(e *entry) error {
+	_, _, stderr := GLOBAL_ENV.StdIO()
+	_, err := io.WriteString(EnsureObjectIsio_Writer(stderr, "*err*: %s"), formatLine(e))
+	return err
+}
+
+func (streamWriter) Close() error {
+	return nil
+}
+
+type fileWriter struct {
+	*os.File
+}
+
+func (w fileWriter) writeEntry(e *entry) error {
+	_, err := w.WriteString(formatLine(e))
+	return err
+}
+
+func stderrSink() *sink {
+	return &sink{kind: "stderr", out: streamWriter{}}
+}
+
+func fileSink(path string) *sink {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	PanicOnErr(err)
+	return &sink{kind: "file", out: fileWriter{f}}
+}
+
+func optString(opts Map, key string, def string) string {
+	if ok, v := opts.Get(MakeKeyword(key)); ok {
+		return EnsureObjectIsString(v, key+": %s").S
+	}
+	return def
+}
+
+func setSinks(s Object) Object {
+	var res []LogSink
+	for seq := EnsureObjectIsSeqable(s, "sinks: %s").Seq(); !seq.IsEmpty(); seq = seq.Rest() {
+		sink, ok := seq.First().(LogSink)
+		if !ok {
+			panic(RT.NewError("Expected LogSink, got " + seq.First().GetType().ToString(false)))
+		}
+		res = append(res, sink)
+	}
+	sinks = res
+	return NIL
+}
+
+func currentSinks() Object {
+	if sinks == nil {
+		return NewVectorFrom(MakeLogSink(defaultSink))
+	}
+	res := EmptyVector()
+	for _, s := range sinks {
+		res = res.Conjoin(s)
+	}
+	return res
+}
+
+func setLevel(level string) Object {
+	minLevel = parseLevel(level)
+	return NIL
+}
+
+func isEnabled(level string) bool {
+	return parseLevel(level) >= minLevel
+}
+
+func closeSink(s LogSink) Object {
+	PanicOnErr(s.out.Close())
+	return NIL
+}
+
+var defaultSink = stderrSink()
+
+func log(level string, msg string, fields Map) Object {
+	l := parseLevel(level)
+	if l < minLevel {
+		return NIL
+	}
+	e := &entry{time: time.Now(), level: l, text: formatText(msg, fields)}
+	targets := sinks
+	if targets == nil {
+		targets = []LogSink{MakeLogSink(defaultSink)}
+	}
+	for _, s := range targets {
+		if err := s.out.writeEntry(e); err != nil {
+			// Don't let a failing sink prevent logging elsewhere.
+			fmt.Fprintf(os.Stderr, "joker.log: error writing to %s sink: %s\n", s.kind, err)
+		}
+	}
+	return NIL
+}
+
+func init() {
+	logSinkType = RegType("LogSink", (*LogSink)(nil), "A destination of log messages")
+}
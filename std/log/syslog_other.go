@@ -0,0 +1,12 @@
+//go:build windows || plan9
+// +build windows plan9
+
+package log
+
+import (
+	. "github.com/candid82/joker/core"
+)
+
+func syslogSink(opts Map) *sink {
+	panic(RT.NewError("syslog-sink is not supported on this platform"))
+}
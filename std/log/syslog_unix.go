@@ -0,0 +1,71 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package log
+
+import (
+	"log/syslog"
+	"os"
+	"path/filepath"
+
+	. "github.com/candid82/joker/core"
+)
+
+var facilities = map[string]syslog.Priority{
+	":kern":     syslog.LOG_KERN,
+	":user":     syslog.LOG_USER,
+	":mail":     syslog.LOG_MAIL,
+	":daemon":   syslog.LOG_DAEMON,
+	":auth":     syslog.LOG_AUTH,
+	":syslog":   syslog.LOG_SYSLOG,
+	":lpr":      syslog.LOG_LPR,
+	":news":     syslog.LOG_NEWS,
+	":uucp":     syslog.LOG_UUCP,
+	":cron":     syslog.LOG_CRON,
+	":authpriv": syslog.LOG_AUTHPRIV,
+	":ftp":      syslog.LOG_FTP,
+	":local0":   syslog.LOG_LOCAL0,
+	":local1":   syslog.LOG_LOCAL1,
+	":local2":   syslog.LOG_LOCAL2,
+	":local3":   syslog.LOG_LOCAL3,
+	":local4":   syslog.LOG_LOCAL4,
+	":local5":   syslog.LOG_LOCAL5,
+	":local6":   syslog.LOG_LOCAL6,
+	":local7":   syslog.LOG_LOCAL7,
+}
+
+type syslogWriter struct {
+	*syslog.Writer
+}
+
+func (w syslogWriter) writeEntry(e *entry) error {
+	switch e.level {
+	case levelDebug:
+		return w.Debug(e.text)
+	case levelInfo:
+		return w.Info(e.text)
+	case levelWarn:
+		return w.Warning(e.text)
+	default:
+		return w.Err(e.text)
+	}
+}
+
+func syslogSink(opts Map) *sink {
+	facility := syslog.LOG_USER
+	if ok, f := opts.Get(MakeKeyword("facility")); ok {
+		name := EnsureObjectIsKeyword(f, "facility: %s").ToString(false)
+		if facility, ok = facilities[name]; !ok {
+			panic(RT.NewError("Unsupported syslog facility " + name))
+		}
+	}
+	network := optString(opts, "network", "")
+	address := optString(opts, "address", "")
+	if network != "" && address == "" {
+		panic(RT.NewError("syslog-sink: :address is required when :network is given"))
+	}
+	tag := optString(opts, "tag", filepath.Base(os.Args[0]))
+	w, err := syslog.Dial(network, address, facility|syslog.LOG_INFO, tag)
+	PanicOnErr(err)
+	return &sink{kind: "syslog", out: syslogWriter{w}}
+}
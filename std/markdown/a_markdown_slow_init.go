@@ -14,9 +14,11 @@ func InternsOrThunks() {
 	}
 	markdownNamespace.ResetMeta(MakeMeta(nil, `Implements GitHub Flavored Markdown rendering.`, "1.0"))
 
-	markdownNamespace.InternVar("convert-string", convert_string_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Returns the HTML rendering of Markdown string s`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
+	markdownNamespace.InternVarPacked("convert-string", convert_string_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Returns the HTML rendering of Markdown string s`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
 
 }
@@ -54,6 +54,25 @@ func __ceil_(_args []Object) Object {
 	return NIL
 }
 
+var __clamp__P ProcFn = __clamp_
+var clamp_ Proc = Proc{Fn: __clamp__P, Name: "clamp_", Package: "std/math"}
+
+func __clamp_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 3:
+		x := ExtractNumber(_args, 0)
+		lo := ExtractNumber(_args, 1)
+		hi := ExtractNumber(_args, 2)
+		_res := clamp(x, lo, hi)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __copy_sign__P ProcFn = __copy_sign_
 var copy_sign_ Proc = Proc{Fn: __copy_sign__P, Name: "copy_sign_", Package: "std/math"}
 
@@ -124,6 +143,51 @@ func __dim_(_args []Object) Object {
 	return NIL
 }
 
+var __div_scale__P ProcFn = __div_scale_
+var div_scale_ Proc = Proc{Fn: __div_scale__P, Name: "div_scale_", Package: "std/math"}
+
+func __div_scale_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 3:
+		x := ExtractNumber(_args, 0)
+		y := ExtractNumber(_args, 1)
+		scale := ExtractInt(_args, 2)
+		_res := divScale(x, y, scale, ":half-even")
+		return MakeBigFloat(_res)
+
+	case _c == 4:
+		x := ExtractNumber(_args, 0)
+		y := ExtractNumber(_args, 1)
+		scale := ExtractInt(_args, 2)
+		mode := ExtractKeyword(_args, 3)
+		_res := divScale(x, y, scale, mode)
+		return MakeBigFloat(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __exact_pow__P ProcFn = __exact_pow_
+var exact_pow_ Proc = Proc{Fn: __exact_pow__P, Name: "exact_pow_", Package: "std/math"}
+
+func __exact_pow_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		x := ExtractNumber(_args, 0)
+		n := ExtractInt(_args, 1)
+		_res := exactPow(x, n)
+		return MakeBigInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __exp__P ProcFn = __exp_
 var exp_ Proc = Proc{Fn: __exp__P, Name: "exp_", Package: "std/math"}
 
@@ -192,6 +256,50 @@ func __floor_(_args []Object) Object {
 	return NIL
 }
 
+var __gcd__P ProcFn = __gcd_
+var gcd_ Proc = Proc{Fn: __gcd__P, Name: "gcd_", Package: "std/math"}
+
+func __gcd_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		x := ExtractNumber(_args, 0)
+		y := ExtractNumber(_args, 1)
+		_res := gcd(x, y)
+		return MakeBigInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __histogram__P ProcFn = __histogram_
+var histogram_ Proc = Proc{Fn: __histogram__P, Name: "histogram_", Package: "std/math"}
+
+func __histogram_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		xs := ExtractSeqable(_args, 0)
+		n := ExtractInt(_args, 1)
+		_res := histogram(xs, n, 0, 0, false)
+		return _res
+
+	case _c == 4:
+		xs := ExtractSeqable(_args, 0)
+		n := ExtractInt(_args, 1)
+		lo := ExtractNumber(_args, 2)
+		hi := ExtractNumber(_args, 3)
+		_res := histogram(xs, n, lo.Double().D, hi.Double().D, true)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __hypot__P ProcFn = __hypot_
 var hypot_ Proc = Proc{Fn: __hypot__P, Name: "hypot_", Package: "std/math"}
 
@@ -245,6 +353,60 @@ func __isinf_(_args []Object) Object {
 	return NIL
 }
 
+var __isqrt__P ProcFn = __isqrt_
+var isqrt_ Proc = Proc{Fn: __isqrt__P, Name: "isqrt_", Package: "std/math"}
+
+func __isqrt_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		x := ExtractNumber(_args, 0)
+		_res := isqrt(x)
+		return MakeBigInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __lcm__P ProcFn = __lcm_
+var lcm_ Proc = Proc{Fn: __lcm__P, Name: "lcm_", Package: "std/math"}
+
+func __lcm_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		x := ExtractNumber(_args, 0)
+		y := ExtractNumber(_args, 1)
+		_res := lcm(x, y)
+		return MakeBigInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __lerp__P ProcFn = __lerp_
+var lerp_ Proc = Proc{Fn: __lerp__P, Name: "lerp_", Package: "std/math"}
+
+func __lerp_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 3:
+		a := ExtractNumber(_args, 0)
+		b := ExtractNumber(_args, 1)
+		t := ExtractNumber(_args, 2)
+		_res := lerp(a.Double().D, b.Double().D, t.Double().D)
+		return MakeDouble(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __log__P ProcFn = __log_
 var log_ Proc = Proc{Fn: __log__P, Name: "log_", Package: "std/math"}
 
@@ -330,6 +492,77 @@ func __log_plus_1_(_args []Object) Object {
 	return NIL
 }
 
+var __mean__P ProcFn = __mean_
+var mean_ Proc = Proc{Fn: __mean__P, Name: "mean_", Package: "std/math"}
+
+func __mean_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		xs := ExtractSeqable(_args, 0)
+		_res := mean(xs)
+		return MakeDouble(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __median__P ProcFn = __median_
+var median_ Proc = Proc{Fn: __median__P, Name: "median_", Package: "std/math"}
+
+func __median_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		xs := ExtractSeqable(_args, 0)
+		_res := median(xs)
+		return MakeDouble(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __mod_inverse__P ProcFn = __mod_inverse_
+var mod_inverse_ Proc = Proc{Fn: __mod_inverse__P, Name: "mod_inverse_", Package: "std/math"}
+
+func __mod_inverse_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		a := ExtractNumber(_args, 0)
+		m := ExtractNumber(_args, 1)
+		_res := modInverse(a, m)
+		return MakeBigInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __mod_pow__P ProcFn = __mod_pow_
+var mod_pow_ Proc = Proc{Fn: __mod_pow__P, Name: "mod_pow_", Package: "std/math"}
+
+func __mod_pow_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 3:
+		b := ExtractNumber(_args, 0)
+		e := ExtractNumber(_args, 1)
+		m := ExtractNumber(_args, 2)
+		_res := modPow(b, e, m)
+		return MakeBigInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __modf__P ProcFn = __modf_
 var modf_ Proc = Proc{Fn: __modf__P, Name: "modf_", Package: "std/math"}
 
@@ -450,6 +683,65 @@ func __precision_(_args []Object) Object {
 	return NIL
 }
 
+var __isprobable_prime__P ProcFn = __isprobable_prime_
+var isprobable_prime_ Proc = Proc{Fn: __isprobable_prime__P, Name: "isprobable_prime_", Package: "std/math"}
+
+func __isprobable_prime_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		x := ExtractNumber(_args, 0)
+		_res := isProbablePrime(x, 20)
+		return MakeBoolean(_res)
+
+	case _c == 2:
+		x := ExtractNumber(_args, 0)
+		rounds := ExtractInt(_args, 1)
+		_res := isProbablePrime(x, rounds)
+		return MakeBoolean(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __quantile__P ProcFn = __quantile_
+var quantile_ Proc = Proc{Fn: __quantile__P, Name: "quantile_", Package: "std/math"}
+
+func __quantile_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		xs := ExtractSeqable(_args, 0)
+		q := ExtractNumber(_args, 1)
+		_res := quantile(xs, q)
+		return MakeDouble(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __quantiles__P ProcFn = __quantiles_
+var quantiles_ Proc = Proc{Fn: __quantiles__P, Name: "quantiles_", Package: "std/math"}
+
+func __quantiles_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		xs := ExtractSeqable(_args, 0)
+		qs := ExtractSeqable(_args, 1)
+		_res := quantiles(xs, qs)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __round__P ProcFn = __round_
 var round_ Proc = Proc{Fn: __round__P, Name: "round_", Package: "std/math"}
 
@@ -467,6 +759,31 @@ func __round_(_args []Object) Object {
 	return NIL
 }
 
+var __round_to__P ProcFn = __round_to_
+var round_to_ Proc = Proc{Fn: __round_to__P, Name: "round_to_", Package: "std/math"}
+
+func __round_to_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		x := ExtractNumber(_args, 0)
+		places := ExtractInt(_args, 1)
+		_res := roundTo(x.Double().D, places, ":half-up")
+		return MakeDouble(_res)
+
+	case _c == 3:
+		x := ExtractNumber(_args, 0)
+		places := ExtractInt(_args, 1)
+		mode := ExtractKeyword(_args, 2)
+		_res := roundTo(x.Double().D, places, mode)
+		return MakeDouble(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __round_to_even__P ProcFn = __round_to_even_
 var round_to_even_ Proc = Proc{Fn: __round_to_even__P, Name: "round_to_even_", Package: "std/math"}
 
@@ -553,6 +870,29 @@ func __sqrt_(_args []Object) Object {
 	return NIL
 }
 
+var __stddev__P ProcFn = __stddev_
+var stddev_ Proc = Proc{Fn: __stddev__P, Name: "stddev_", Package: "std/math"}
+
+func __stddev_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		xs := ExtractSeqable(_args, 0)
+		_res := stddev(xs, ":sample")
+		return MakeDouble(_res)
+
+	case _c == 2:
+		xs := ExtractSeqable(_args, 0)
+		kind := ExtractKeyword(_args, 1)
+		_res := stddev(xs, kind)
+		return MakeDouble(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __trunc__P ProcFn = __trunc_
 var trunc_ Proc = Proc{Fn: __trunc__P, Name: "trunc_", Package: "std/math"}
 
@@ -570,6 +910,29 @@ func __trunc_(_args []Object) Object {
 	return NIL
 }
 
+var __variance__P ProcFn = __variance_
+var variance_ Proc = Proc{Fn: __variance__P, Name: "variance_", Package: "std/math"}
+
+func __variance_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		xs := ExtractSeqable(_args, 0)
+		_res := variance(xs, ":sample")
+		return MakeDouble(_res)
+
+	case _c == 2:
+		xs := ExtractSeqable(_args, 0)
+		kind := ExtractKeyword(_args, 1)
+		_res := variance(xs, kind)
+		return MakeDouble(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 func Init() {
 	e_ = MakeDouble(math.E)
 	ln_of_10_ = MakeDouble(math.Ln10)
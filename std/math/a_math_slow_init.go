@@ -14,203 +14,355 @@ func InternsOrThunks() {
 	}
 	mathNamespace.ResetMeta(MakeMeta(nil, `Provides basic constants and mathematical functions.`, "1.0"))
 
-	mathNamespace.InternVar("e", e_,
-		MakeMeta(
-			nil,
-			`e`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("ln-of-10", ln_of_10_,
-		MakeMeta(
-			nil,
-			`Natural logarithm of 10`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("ln-of-2", ln_of_2_,
-		MakeMeta(
-			nil,
-			`Natural logarithm of 2`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("log-10-of-e", log_10_of_e_,
-		MakeMeta(
-			nil,
-			`Base-10 logarithm of e`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("log-2-of-e", log_2_of_e_,
-		MakeMeta(
-			nil,
-			`Base-2 logarithm of e`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("max-double", max_double_,
-		MakeMeta(
-			nil,
-			`Largest finite value representable by Double`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("phi", phi_,
-		MakeMeta(
-			nil,
-			`Phi`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("pi", pi_,
-		MakeMeta(
-			nil,
-			`pi`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("smallest-nonzero-double", smallest_nonzero_double_,
-		MakeMeta(
-			nil,
-			`Smallest positive, non-zero value representable by Double`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("sqrt-of-2", sqrt_of_2_,
-		MakeMeta(
-			nil,
-			`Square root of 2`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("sqrt-of-e", sqrt_of_e_,
-		MakeMeta(
-			nil,
-			`Square root of e`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("sqrt-of-phi", sqrt_of_phi_,
-		MakeMeta(
-			nil,
-			`Square root of phi`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("sqrt-of-pi", sqrt_of_pi_,
-		MakeMeta(
-			nil,
-			`Square root of pi`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("abs", abs_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"))),
-			`Returns the absolute value of x.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("ceil", ceil_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"))),
-			`Returns the least integer value greater than or equal to x.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("copy-sign", copy_sign_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"), MakeSymbol("y"))),
-			`Returns value with the magnitude of x and the sign of y.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("cos", cos_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"))),
-			`Returns the cosine of the radian argument x.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("cube-root", cube_root_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"))),
-			`Returns the cube root of x.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("dim", dim_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"), MakeSymbol("y"))),
-			`Returns the maximum of x-y and 0.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("exp", exp_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"))),
-			`Returns e**x, the base-e exponential of x.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("exp-2", exp_2_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"))),
-			`Returns 2**x, the base-2 exponential of x.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("exp-minus-1", exp_minus_1_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"))),
-			`Returns e**x - 1, the base-e exponential of x minus 1.
-
-  This is more accurate than (- (exp x) 1.) when x is near zero.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("floor", floor_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"))),
-			`Returns the greatest integer value greater than or equal to x.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("hypot", hypot_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("p"), MakeSymbol("q"))),
-			`Returns Sqrt(p*p + q*q), taking care to avoid unnecessary overflow and underflow.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("inf", inf_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("sign"))),
-			`Returns positive infinity if sign >= 0, negative infinity if sign < 0.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("inf?", isinf_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"), MakeSymbol("sign"))),
-			`Returns whether x is an infinity.
-
-  If sign > 0, returns whether x is positive infinity; if < 0, whether negative infinity; if == 0, whether either infinity.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Boolean"}))
-
-	mathNamespace.InternVar("log", log_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"))),
-			`Returns the natural logarithm of x.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("log-10", log_10_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"))),
-			`Returns the decimal logarithm of x.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("log-2", log_2_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"))),
-			`Returns the binary logarithm of x.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("log-binary", log_binary_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"))),
-			`Returns the binary exponent of x.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("log-plus-1", log_plus_1_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"))),
-			`Returns the natural logarithm of 1 plus x.
-
-  This is more accurate than (log (+ 1 x)) when x is near zero.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("modf", modf_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"))),
-			`Returns a vector with the integer and fractional floating-point numbers that sum to x.
-
-  Both values have the same sign as x.`, "1.0"))
-
-	mathNamespace.InternVar("nan", nan_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom()),
-			`Returns an IEEE 754 "not-a-number" value.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("nan?", isnan_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"))),
-			`Returns whether x is an IEEE 754 "not-a-number" value.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Boolean"}))
-
-	mathNamespace.InternVar("next-after", next_after_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"), MakeSymbol("y"))),
-			`Returns the next representable Double value after x towards y.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("pow", pow_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"), MakeSymbol("y"))),
-			`Returns x**y, the base-x exponential of y.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("pow-10", pow_10_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"))),
-			`Returns 10**x, the base-10 exponential of x.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("precision", precision_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("f"))),
-			`Returns the precision of a Number.
+	mathNamespace.InternVarPacked("e", e_, &VarMeta{
+		Doc:   `e`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("ln-of-10", ln_of_10_, &VarMeta{
+		Doc:   `Natural logarithm of 10`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("ln-of-2", ln_of_2_, &VarMeta{
+		Doc:   `Natural logarithm of 2`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("log-10-of-e", log_10_of_e_, &VarMeta{
+		Doc:   `Base-10 logarithm of e`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("log-2-of-e", log_2_of_e_, &VarMeta{
+		Doc:   `Base-2 logarithm of e`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("max-double", max_double_, &VarMeta{
+		Doc:   `Largest finite value representable by Double`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("phi", phi_, &VarMeta{
+		Doc:   `Phi`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("pi", pi_, &VarMeta{
+		Doc:   `pi`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("smallest-nonzero-double", smallest_nonzero_double_, &VarMeta{
+		Doc:   `Smallest positive, non-zero value representable by Double`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("sqrt-of-2", sqrt_of_2_, &VarMeta{
+		Doc:   `Square root of 2`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("sqrt-of-e", sqrt_of_e_, &VarMeta{
+		Doc:   `Square root of e`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("sqrt-of-phi", sqrt_of_phi_, &VarMeta{
+		Doc:   `Square root of phi`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("sqrt-of-pi", sqrt_of_pi_, &VarMeta{
+		Doc:   `Square root of pi`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("abs", abs_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns the absolute value of x.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("ceil", ceil_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns the least integer value greater than or equal to x.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("clamp", clamp_, &VarMeta{
+		Arglists: "([x lo hi])",
+		Doc:      `Returns x if it is between lo and hi (inclusive), lo if x is below lo, and hi if x is above hi.`,
+		Added:    "1.2",
+	})
+
+	mathNamespace.InternVarPacked("copy-sign", copy_sign_, &VarMeta{
+		Arglists: "([x y])",
+		Doc:      `Returns value with the magnitude of x and the sign of y.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("cos", cos_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns the cosine of the radian argument x.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("cube-root", cube_root_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns the cube root of x.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("dim", dim_, &VarMeta{
+		Arglists: "([x y])",
+		Doc:      `Returns the maximum of x-y and 0.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("div-scale", div_scale_, &VarMeta{
+		Arglists: "([x y scale] [x y scale mode])",
+		Doc: `Divides x by y exactly and rounds the quotient to scale decimal places.
+  mode is as in round-to and defaults to :half-even.`,
+		Added: "1.2",
+		Extra: "{:tag \"BigFloat\"}",
+	})
+
+	mathNamespace.InternVarPacked("exact-pow", exact_pow_, &VarMeta{
+		Arglists: "([x n])",
+		Doc: `Returns x raised to the power of n, computed exactly.
+  x must be Int or BigInt and n must be non-negative.`,
+		Added: "1.2",
+		Extra: "{:tag \"BigInt\"}",
+	})
+
+	mathNamespace.InternVarPacked("exp", exp_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns e**x, the base-e exponential of x.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("exp-2", exp_2_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns 2**x, the base-2 exponential of x.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("exp-minus-1", exp_minus_1_, &VarMeta{
+		Arglists: "([x])",
+		Doc: `Returns e**x - 1, the base-e exponential of x minus 1.
+
+  This is more accurate than (- (exp x) 1.) when x is near zero.`,
+		Added: "1.0",
+		Extra: "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("floor", floor_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns the greatest integer value greater than or equal to x.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("gcd", gcd_, &VarMeta{
+		Arglists: "([x y])",
+		Doc:      `Returns the greatest common divisor of Int or BigInt x and y. The result is always non-negative.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"BigInt\"}",
+	})
+
+	mathNamespace.InternVarPacked("histogram", histogram_, &VarMeta{
+		Arglists: "([xs n] [xs n lo hi])",
+		Doc: `Buckets the numbers in xs into n equal-width buckets spanning [lo, hi]
+  (the minimum and maximum of xs if not specified). Numbers outside
+  of [lo, hi], NaNs and infinities are ignored. Returns a vector of maps
+  with the keys :from, :to and :count, one per bucket.
+  Throws an exception if lo and hi are not finite or lo is not less than hi.`,
+		Added: "1.2",
+	})
+
+	mathNamespace.InternVarPacked("hypot", hypot_, &VarMeta{
+		Arglists: "([p q])",
+		Doc:      `Returns Sqrt(p*p + q*q), taking care to avoid unnecessary overflow and underflow.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("inf", inf_, &VarMeta{
+		Arglists: "([sign])",
+		Doc:      `Returns positive infinity if sign >= 0, negative infinity if sign < 0.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("inf?", isinf_, &VarMeta{
+		Arglists: "([x sign])",
+		Doc: `Returns whether x is an infinity.
+
+  If sign > 0, returns whether x is positive infinity; if < 0, whether negative infinity; if == 0, whether either infinity.`,
+		Added: "1.0",
+		Extra: "{:tag \"Boolean\"}",
+	})
+
+	mathNamespace.InternVarPacked("isqrt", isqrt_, &VarMeta{
+		Arglists: "([x])",
+		Doc: `Returns the integer square root of x, i.e. the largest integer whose square is <= x.
+  x must be a non-negative Int or BigInt.`,
+		Added: "1.2",
+		Extra: "{:tag \"BigInt\"}",
+	})
+
+	mathNamespace.InternVarPacked("lcm", lcm_, &VarMeta{
+		Arglists: "([x y])",
+		Doc:      `Returns the least common multiple of Int or BigInt x and y. The result is always non-negative.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"BigInt\"}",
+	})
+
+	mathNamespace.InternVarPacked("lerp", lerp_, &VarMeta{
+		Arglists: "([a b t])",
+		Doc:      `Linearly interpolates between a and b: returns a when t is 0 and b when t is 1.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("log", log_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns the natural logarithm of x.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("log-10", log_10_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns the decimal logarithm of x.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("log-2", log_2_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns the binary logarithm of x.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("log-binary", log_binary_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns the binary exponent of x.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("log-plus-1", log_plus_1_, &VarMeta{
+		Arglists: "([x])",
+		Doc: `Returns the natural logarithm of 1 plus x.
+
+  This is more accurate than (log (+ 1 x)) when x is near zero.`,
+		Added: "1.0",
+		Extra: "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("mean", mean_, &VarMeta{
+		Arglists: "([xs])",
+		Doc: `Returns the arithmetic mean of the numbers in xs.
+  Throws an exception if xs is empty.`,
+		Added: "1.2",
+		Extra: "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("median", median_, &VarMeta{
+		Arglists: "([xs])",
+		Doc: `Returns the median of the numbers in xs.
+  Throws an exception if xs is empty.`,
+		Added: "1.2",
+		Extra: "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("mod-inverse", mod_inverse_, &VarMeta{
+		Arglists: "([a m])",
+		Doc: `Returns the multiplicative inverse of a modulo m, i.e. x such that (a * x) mod m = 1.
+  Throws an exception if a and m are not relatively prime.`,
+		Added: "1.2",
+		Extra: "{:tag \"BigInt\"}",
+	})
+
+	mathNamespace.InternVarPacked("mod-pow", mod_pow_, &VarMeta{
+		Arglists: "([b e m])",
+		Doc: `Returns (b ** e) mod m. All arguments must be Int or BigInt and m must be positive.
+  A negative e requires b to be invertible modulo m.`,
+		Added: "1.2",
+		Extra: "{:tag \"BigInt\"}",
+	})
+
+	mathNamespace.InternVarPacked("modf", modf_, &VarMeta{
+		Arglists: "([x])",
+		Doc: `Returns a vector with the integer and fractional floating-point numbers that sum to x.
+
+  Both values have the same sign as x.`,
+		Added: "1.0",
+	})
+
+	mathNamespace.InternVarPacked("nan", nan_, &VarMeta{
+		Arglists: "([])",
+		Doc:      `Returns an IEEE 754 "not-a-number" value.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("nan?", isnan_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns whether x is an IEEE 754 "not-a-number" value.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Boolean\"}",
+	})
+
+	mathNamespace.InternVarPacked("next-after", next_after_, &VarMeta{
+		Arglists: "([x y])",
+		Doc:      `Returns the next representable Double value after x towards y.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("pow", pow_, &VarMeta{
+		Arglists: "([x y])",
+		Doc:      `Returns x**y, the base-x exponential of y.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("pow-10", pow_10_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns 10**x, the base-10 exponential of x.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("precision", precision_, &VarMeta{
+		Arglists: "([f])",
+		Doc: `Returns the precision of a Number.
 
   The precision excludes any sign or exponent. For a BigInt, it's the
   number of bits needed to represent the number; for a BigFloat, Int,
@@ -223,44 +375,113 @@ func InternsOrThunks() {
   reported.
 
   If f is not a supported Number type (such as Ratio), a panic
-  results.`, "1.0").Plus(MakeKeyword("tag"), String{S: "BigInt"}))
-
-	mathNamespace.InternVar("round", round_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"))),
-			`Returns the integer nearest to x, rounding half away from zero.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("round-to-even", round_to_even_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"))),
-			`Returns the integer nearest to x, rounding ties to the nearest even integer.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("set-precision", set_precision_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("prec"), MakeSymbol("f"))),
-			`Returns a copy of a BigFloat with the specified precision.
+  results.`,
+		Added: "1.0",
+		Extra: "{:tag \"BigInt\"}",
+	})
+
+	mathNamespace.InternVarPacked("probable-prime?", isprobable_prime_, &VarMeta{
+		Arglists: "([x] [x rounds])",
+		Doc: `Returns true if x is probably prime, applying the given number of
+  Miller-Rabin rounds (20 by default) plus a Baillie-PSW test.
+  Always returns the correct result for x < 2^64.`,
+		Added: "1.2",
+		Extra: "{:tag \"Boolean\"}",
+	})
+
+	mathNamespace.InternVarPacked("quantile", quantile_, &VarMeta{
+		Arglists: "([xs q])",
+		Doc: `Returns the q-th quantile (0 <= q <= 1) of the numbers in xs,
+  linearly interpolating between the closest ranks.
+  Throws an exception if xs is empty.`,
+		Added: "1.2",
+		Extra: "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("quantiles", quantiles_, &VarMeta{
+		Arglists: "([xs qs])",
+		Doc: `Returns a vector of the quantiles of the numbers in xs, one for each element of qs.
+  See quantile.`,
+		Added: "1.2",
+	})
+
+	mathNamespace.InternVarPacked("round", round_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns the integer nearest to x, rounding half away from zero.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("round-to", round_to_, &VarMeta{
+		Arglists: "([x places] [x places mode])",
+		Doc: `Rounds x to the given number of decimal places (negative places round
+  to tens, hundreds etc.). mode is one of :half-up (the default),
+  :half-down, :half-even (banker's rounding), :up, :down, :ceiling, :floor.
+  Rounding is done on the shortest decimal representation of x, so
+  (round-to 2.675 2) returns 2.68.`,
+		Added: "1.2",
+		Extra: "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("round-to-even", round_to_even_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns the integer nearest to x, rounding ties to the nearest even integer.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("set-precision", set_precision_, &VarMeta{
+		Arglists: "([prec f])",
+		Doc: `Returns a copy of a BigFloat with the specified precision.
 
   Calls Go's math/big.(*Float)SetPrec(prec) on a copy of f. prec must
-  evaluate to a non-negative integer. Returns the resulting BigFloat.`, "1.0").Plus(MakeKeyword("tag"), String{S: "BigFloat"}))
-
-	mathNamespace.InternVar("sign-bit", sign_bit_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"))),
-			`Returns whether x is negative or negative zero.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Boolean"}))
-
-	mathNamespace.InternVar("sin", sin_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"))),
-			`Returns the sine of the radian argument x.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("sqrt", sqrt_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"))),
-			`Returns the square root of x.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	mathNamespace.InternVar("trunc", trunc_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("x"))),
-			`Returns the integer value of x.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
+  evaluate to a non-negative integer. Returns the resulting BigFloat.`,
+		Added: "1.0",
+		Extra: "{:tag \"BigFloat\"}",
+	})
+
+	mathNamespace.InternVarPacked("sign-bit", sign_bit_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns whether x is negative or negative zero.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Boolean\"}",
+	})
+
+	mathNamespace.InternVarPacked("sin", sin_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns the sine of the radian argument x.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("sqrt", sqrt_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns the square root of x.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("stddev", stddev_, &VarMeta{
+		Arglists: "([xs] [xs kind])",
+		Doc: `Returns the standard deviation of the numbers in xs.
+  kind is as in variance.`,
+		Added: "1.2",
+		Extra: "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("trunc", trunc_, &VarMeta{
+		Arglists: "([x])",
+		Doc:      `Returns the integer value of x.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	mathNamespace.InternVarPacked("variance", variance_, &VarMeta{
+		Arglists: "([xs] [xs kind])",
+		Doc: `Returns the variance of the numbers in xs.
+  kind is either :sample (the default, divides by n-1) or :population (divides by n).`,
+		Added: "1.2",
+		Extra: "{:tag \"Double\"}",
+	})
 
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"sort"
 
 	. "github.com/candid82/joker/core"
 )
@@ -32,3 +33,277 @@ func setPrecision(prec Number, n *big.Float) *big.Float {
 	}
 	return big.NewFloat(0).Copy(n).SetPrec(uint(p))
 }
+
+func toFloats(xs Seqable) []float64 {
+	res := []float64{}
+	for s := xs.Seq(); !s.IsEmpty(); s = s.Rest() {
+		res = append(res, EnsureObjectIsNumber(s.First(), "").Double().D)
+	}
+	return res
+}
+
+func nonEmptyFloats(xs Seqable, fn string) []float64 {
+	res := toFloats(xs)
+	if len(res) == 0 {
+		panic(RT.NewError(fn + " of empty collection"))
+	}
+	return res
+}
+
+func sortedFloats(xs Seqable, fn string) []float64 {
+	res := nonEmptyFloats(xs, fn)
+	sort.Float64s(res)
+	return res
+}
+
+func sum(xs []float64) float64 {
+	// Kahan summation keeps the error from growing with the size of xs.
+	var s, c float64
+	for _, x := range xs {
+		y := x - c
+		t := s + y
+		c = (t - s) - y
+		s = t
+	}
+	return s
+}
+
+func mean(xs Seqable) float64 {
+	fs := nonEmptyFloats(xs, "mean")
+	return sum(fs) / float64(len(fs))
+}
+
+// quantileSorted uses linear interpolation between closest ranks
+// (type 7 in Hyndman & Fan, the default in R and NumPy).
+func quantileSorted(fs []float64, q float64) float64 {
+	// Written so that NaN is rejected too.
+	if !(q >= 0 && q <= 1) {
+		panic(RT.NewError(fmt.Sprintf("Quantile must be between 0 and 1, got %v", q)))
+	}
+	h := q * float64(len(fs)-1)
+	lo := math.Floor(h)
+	i := int(lo)
+	if i+1 >= len(fs) {
+		return fs[len(fs)-1]
+	}
+	return fs[i] + (h-lo)*(fs[i+1]-fs[i])
+}
+
+func median(xs Seqable) float64 {
+	return quantileSorted(sortedFloats(xs, "median"), 0.5)
+}
+
+func quantile(xs Seqable, q Number) float64 {
+	return quantileSorted(sortedFloats(xs, "quantile"), q.Double().D)
+}
+
+func quantiles(xs Seqable, qs Seqable) *Vector {
+	fs := sortedFloats(xs, "quantiles")
+	res := EmptyVector()
+	for _, q := range toFloats(qs) {
+		res = res.Conjoin(MakeDouble(quantileSorted(fs, q)))
+	}
+	return res
+}
+
+func variance(xs Seqable, kind string) float64 {
+	fs := nonEmptyFloats(xs, "variance")
+	n := float64(len(fs))
+	switch kind {
+	case ":sample":
+		if len(fs) < 2 {
+			panic(RT.NewError("Sample variance requires at least 2 elements"))
+		}
+		n--
+	case ":population":
+	default:
+		panic(RT.NewError("Unsupported variance kind " + kind + ". Supported kinds are: :sample, :population"))
+	}
+	m := sum(fs) / float64(len(fs))
+	sq := make([]float64, len(fs))
+	for i, x := range fs {
+		sq[i] = (x - m) * (x - m)
+	}
+	return sum(sq) / n
+}
+
+func stddev(xs Seqable, kind string) float64 {
+	return math.Sqrt(variance(xs, kind))
+}
+
+func histogram(xs Seqable, n int, lo, hi float64, bounded bool) *Vector {
+	if n <= 0 {
+		panic(RT.NewError(fmt.Sprintf("Number of buckets must be positive, got %d", n)))
+	}
+	if bounded {
+		if !isFinite(lo) || !isFinite(hi) {
+			panic(RT.NewError(fmt.Sprintf("Bounds must be finite, got %v and %v", lo, hi)))
+		}
+		if lo >= hi {
+			panic(RT.NewError(fmt.Sprintf("Lower bound %v must be less than upper bound %v", lo, hi)))
+		}
+	}
+	// NaNs and infinities have no bucket.
+	var fs []float64
+	for _, x := range toFloats(xs) {
+		if isFinite(x) {
+			fs = append(fs, x)
+		}
+	}
+	if !bounded && len(fs) > 0 {
+		lo, hi = fs[0], fs[0]
+		for _, x := range fs {
+			lo = math.Min(lo, x)
+			hi = math.Max(hi, x)
+		}
+	}
+	width := (hi - lo) / float64(n)
+	if math.IsInf(width, 0) {
+		panic(RT.NewError(fmt.Sprintf("Range from %v to %v is too large to bucket", lo, hi)))
+	}
+	counts := make([]int, n)
+	for _, x := range fs {
+		if x < lo || x > hi {
+			continue
+		}
+		i := n - 1
+		if width > 0 {
+			i = int((x - lo) / width)
+		}
+		if i >= n {
+			// The upper bound belongs to the last bucket.
+			i = n - 1
+		}
+		counts[i]++
+	}
+	res := EmptyVector()
+	for i, c := range counts {
+		bucket := EmptyArrayMap()
+		bucket.Add(MakeKeyword("from"), MakeDouble(lo+float64(i)*width))
+		bucket.Add(MakeKeyword("to"), MakeDouble(lo+float64(i+1)*width))
+		bucket.Add(MakeKeyword("count"), MakeInt(c))
+		res = res.Conjoin(bucket)
+	}
+	return res
+}
+
+func isFinite(x float64) bool {
+	return !math.IsNaN(x) && !math.IsInf(x, 0)
+}
+
+func clamp(x, lo, hi Number) Number {
+	if CompareNumbers(lo, hi) > 0 {
+		panic(RT.NewError(fmt.Sprintf("Lower bound %s is greater than upper bound %s", lo.ToString(true), hi.ToString(true))))
+	}
+	if CompareNumbers(x, lo) < 0 {
+		return lo
+	}
+	if CompareNumbers(x, hi) > 0 {
+		return hi
+	}
+	return x
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
+
+// roundTo rounds the shortest decimal representation of x rather than
+// its binary value, so that (round-to 2.675 2) is 2.68 as one would expect.
+func roundTo(x float64, places int, mode string) float64 {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return x
+	}
+	res, _ := RoundRat(DecimalRatio(MakeDouble(x)), places, mode).Float64()
+	return res
+}
+
+func integer(n Number, name string) *big.Int {
+	switch n := n.(type) {
+	case Int:
+		return big.NewInt(int64(n.I))
+	case *BigInt:
+		return n.BigInt()
+	default:
+		panic(RT.NewError(fmt.Sprintf("%s must be Int or BigInt, got %s", name, n.GetType().ToString(false))))
+	}
+}
+
+func exactPow(x Number, n int) *big.Int {
+	if n < 0 {
+		panic(RT.NewError(fmt.Sprintf("Exponent must be non-negative, got %d", n)))
+	}
+	return big.NewInt(0).Exp(integer(x, "Base"), big.NewInt(int64(n)), nil)
+}
+
+func isqrt(x Number) *big.Int {
+	n := integer(x, "Argument")
+	if n.Sign() < 0 {
+		panic(RT.NewError("Square root of negative number: " + n.String()))
+	}
+	return big.NewInt(0).Sqrt(n)
+}
+
+func gcd(x, y Number) *big.Int {
+	a := big.NewInt(0).Abs(integer(x, "Argument"))
+	b := big.NewInt(0).Abs(integer(y, "Argument"))
+	return big.NewInt(0).GCD(nil, nil, a, b)
+}
+
+func lcm(x, y Number) *big.Int {
+	a := big.NewInt(0).Abs(integer(x, "Argument"))
+	b := big.NewInt(0).Abs(integer(y, "Argument"))
+	if a.Sign() == 0 || b.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	g := big.NewInt(0).GCD(nil, nil, a, b)
+	return a.Mul(a.Quo(a, g), b)
+}
+
+func modulus(m Number) *big.Int {
+	res := integer(m, "Modulus")
+	if res.Sign() <= 0 {
+		panic(RT.NewError("Modulus must be positive, got " + res.String()))
+	}
+	return res
+}
+
+func modPow(b, e, m Number) *big.Int {
+	mod := modulus(m)
+	exp := integer(e, "Exponent")
+	base := integer(b, "Base")
+	if exp.Sign() < 0 {
+		base = modInverse(b, m)
+		exp = exp.Neg(exp)
+	}
+	return big.NewInt(0).Exp(base, exp, mod)
+}
+
+func modInverse(a, m Number) *big.Int {
+	mod := modulus(m)
+	res := big.NewInt(0).ModInverse(integer(a, "Argument"), mod)
+	if res == nil {
+		panic(RT.NewError(fmt.Sprintf("%s has no inverse modulo %s", a.ToString(false), mod.String())))
+	}
+	return res
+}
+
+func isProbablePrime(x Number, rounds int) bool {
+	if rounds < 0 {
+		panic(RT.NewError(fmt.Sprintf("Rounds must be non-negative, got %d", rounds)))
+	}
+	return integer(x, "Argument").ProbablyPrime(rounds)
+}
+
+func divScale(x, y Number, scale int, mode string) *big.Float {
+	if scale < 0 {
+		panic(RT.NewError(fmt.Sprintf("Scale must be non-negative, got %d", scale)))
+	}
+	d := y.Ratio()
+	if d.Sign() == 0 {
+		panic(RT.NewError("Divide by zero"))
+	}
+	q := RoundRat(big.NewRat(0, 1).Quo(x.Ratio(), d), scale, mode)
+	res, _ := MakeBigFloatWithOrig(q.FloatString(scale), "")
+	return res.BigFloat()
+}
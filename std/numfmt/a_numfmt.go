@@ -0,0 +1,89 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+package numfmt
+
+import (
+	. "github.com/candid82/joker/core"
+)
+
+var __format_currency__P ProcFn = __format_currency_
+var format_currency_ Proc = Proc{Fn: __format_currency__P, Name: "format_currency_", Package: "std/numfmt"}
+
+func __format_currency_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		x := ExtractNumber(_args, 0)
+		currency := ExtractObject(_args, 1)
+		_res := formatCurrency(x, currency.ToString(false), EmptyArrayMap())
+		return MakeString(_res)
+
+	case _c == 3:
+		x := ExtractNumber(_args, 0)
+		currency := ExtractObject(_args, 1)
+		opts := ExtractMap(_args, 2)
+		_res := formatCurrency(x, currency.ToString(false), opts)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __format_number__P ProcFn = __format_number_
+var format_number_ Proc = Proc{Fn: __format_number__P, Name: "format_number_", Package: "std/numfmt"}
+
+func __format_number_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		x := ExtractNumber(_args, 0)
+		_res := formatNumber(x, EmptyArrayMap())
+		return MakeString(_res)
+
+	case _c == 2:
+		x := ExtractNumber(_args, 0)
+		opts := ExtractMap(_args, 1)
+		_res := formatNumber(x, opts)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __parse_number__P ProcFn = __parse_number_
+var parse_number_ Proc = Proc{Fn: __parse_number__P, Name: "parse_number_", Package: "std/numfmt"}
+
+func __parse_number_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		s := ExtractString(_args, 0)
+		_res := parseNumber(s, EmptyArrayMap())
+		return MakeBigFloat(_res)
+
+	case _c == 2:
+		s := ExtractString(_args, 0)
+		opts := ExtractMap(_args, 1)
+		_res := parseNumber(s, opts)
+		return MakeBigFloat(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+func Init() {
+
+	InternsOrThunks()
+}
+
+var numfmtNamespace = GLOBAL_ENV.EnsureSymbolIsLib(MakeSymbol("joker.numfmt"))
+
+func init() {
+	numfmtNamespace.Lazy = Init
+}
@@ -0,0 +1,61 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+package numfmt
+
+import (
+	"fmt"
+	. "github.com/candid82/joker/core"
+	"os"
+)
+
+func InternsOrThunks() {
+	if VerbosityLevel > 0 {
+		fmt.Fprintln(os.Stderr, "Lazily running slow version of numfmt.InternsOrThunks().")
+	}
+	numfmtNamespace.ResetMeta(MakeMeta(nil, `Formats numbers and monetary amounts for human consumption and parses them back.
+
+         Functions accept an optional map of options with the following keys:
+
+         :locale - one of :en-US (the default), :en-GB, :en-IN, :de-DE, :de-CH, :fr-FR,
+         :es-ES, :it-IT, :nl-NL, :pt-BR, :ru-RU, :ja-JP, :zh-CN. Determines the decimal
+         and grouping separators and the placement of the currency symbol.
+
+         :decimals - number of decimal places to round to.
+
+         :rounding - rounding mode used with :decimals, one of :half-even (the default,
+         also known as banker's rounding), :half-up, :half-down, :up, :down, :ceiling, :floor.
+
+         :grouping - whether to separate groups of thousands (true by default).`, "1.0"))
+
+	numfmtNamespace.InternVarPacked("format-currency", format_currency_, &VarMeta{
+		Arglists: "([x currency] [x currency opts])",
+		Doc: `Returns x formatted as an amount of currency (an ISO 4217 code such as
+  "USD" or :eur) according to opts. :decimals defaults to the number of
+  minor units of the currency (e.g. 2 for USD, 0 for JPY).
+  In addition to the options above, opts may have the key
+  :display - :symbol (the default, e.g. $) or :code (e.g. USD).`,
+		Added: "1.2",
+		Extra: "{:tag \"String\"}",
+	})
+
+	numfmtNamespace.InternVarPacked("format-number", format_number_, &VarMeta{
+		Arglists: "([x] [x opts])",
+		Doc: `Returns x formatted according to opts. If :decimals is not specified,
+  x is formatted with as many decimal places as needed.`,
+		Added: "1.2",
+		Extra: "{:tag \"String\"}",
+	})
+
+	numfmtNamespace.InternVarPacked("parse-number", parse_number_, &VarMeta{
+		Arglists: "([s] [s opts])",
+		Doc: `Parses a number formatted according to the :locale in opts, such as
+  "1.234,56 €" for :de-DE, and returns it as a BigFloat. Grouping separators
+  are optional, but must be where the locale puts them. Whitespace and known
+  currency symbols and codes are ignored; a leading or trailing minus sign, or
+  enclosing parentheses, denote a negative number.
+  Throws an exception if s is not a valid number.`,
+		Added: "1.2",
+		Extra: "{:tag \"BigFloat\"}",
+	})
+
+}
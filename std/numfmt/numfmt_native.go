@@ -0,0 +1,262 @@
+package numfmt
+
+import (
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	. "github.com/candid82/joker/core"
+)
+
+type locale struct {
+	decimal  string
+	group    string
+	grouping [2]int // Sizes of the first and all further groups, counting from the decimal point.
+	// Currency pattern, where ¤ stands for the currency symbol and # for the number.
+	currency string
+}
+
+const nbsp = "\u00a0"
+const nnbsp = "\u202f"
+
+var locales = map[string]locale{
+	":en-US": {".", ",", [2]int{3, 3}, "¤#"},
+	":en-GB": {".", ",", [2]int{3, 3}, "¤#"},
+	":en-IN": {".", ",", [2]int{3, 2}, "¤#"},
+	":de-DE": {",", ".", [2]int{3, 3}, "#" + nbsp + "¤"},
+	":de-CH": {".", "’", [2]int{3, 3}, "¤" + nbsp + "#"},
+	":fr-FR": {",", nnbsp, [2]int{3, 3}, "#" + nbsp + "¤"},
+	":es-ES": {",", ".", [2]int{3, 3}, "#" + nbsp + "¤"},
+	":it-IT": {",", ".", [2]int{3, 3}, "#" + nbsp + "¤"},
+	":nl-NL": {",", ".", [2]int{3, 3}, "¤" + nbsp + "#"},
+	":pt-BR": {",", ".", [2]int{3, 3}, "¤" + nbsp + "#"},
+	":ru-RU": {",", nbsp, [2]int{3, 3}, "#" + nbsp + "¤"},
+	":ja-JP": {".", ",", [2]int{3, 3}, "¤#"},
+	":zh-CN": {".", ",", [2]int{3, 3}, "¤#"},
+}
+
+type currency struct {
+	symbol   string
+	decimals int
+}
+
+var currencies = map[string]currency{
+	"USD": {"$", 2},
+	"EUR": {"€", 2},
+	"GBP": {"£", 2},
+	"JPY": {"¥", 0},
+	"CNY": {"¥", 2},
+	"CHF": {"CHF", 2},
+	"INR": {"₹", 2},
+	"BRL": {"R$", 2},
+	"RUB": {"₽", 2},
+	"CAD": {"CA$", 2},
+	"AUD": {"A$", 2},
+	"KRW": {"₩", 0},
+	"SEK": {"kr", 2},
+	"BHD": {"BHD", 3},
+	"KWD": {"KWD", 3},
+}
+
+func localeNames() string {
+	var names []string
+	for k := range locales {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func getLocale(opts Map) locale {
+	name := ":en-US"
+	if ok, l := opts.Get(MakeKeyword("locale")); ok {
+		name = EnsureObjectIsKeyword(l, "locale: %s").ToString(false)
+	}
+	if l, ok := locales[name]; ok {
+		return l
+	}
+	panic(RT.NewError("Unsupported locale " + name + ". Supported locales are: " + localeNames()))
+}
+
+func getOpt(opts Map, key string, dflt Object) Object {
+	if ok, v := opts.Get(MakeKeyword(key)); ok {
+		return v
+	}
+	return dflt
+}
+
+// plainDecimal returns the decimal digits of the absolute value of x
+// (with "." as the decimal point) and whether x is negative.
+func plainDecimal(x Number, opts Map, defaultDecimals int) (string, bool) {
+	mode := EnsureObjectIsKeyword(getOpt(opts, "rounding", MakeKeyword("half-even")), "rounding: %s").ToString(false)
+	decimals := defaultDecimals
+	if d := getOpt(opts, "decimals", NIL); d != NIL {
+		decimals = EnsureObjectIsInt(d, "decimals: %s").I
+	}
+	var s string
+	switch {
+	case decimals >= 0:
+		s = RoundRat(DecimalRatio(x), decimals, mode).FloatString(decimals)
+	default:
+		switch x := x.(type) {
+		case Double:
+			s = strconv.FormatFloat(x.D, 'f', -1, 64)
+		case *BigFloat:
+			s = x.BigFloat().Text('f', -1)
+		case *Ratio:
+			s = strconv.FormatFloat(x.Double().D, 'f', -1, 64)
+		default:
+			s = x.BigInt().String()
+		}
+	}
+	if strings.HasPrefix(s, "-") {
+		s = s[1:]
+		return s, strings.Trim(s, "0.") != ""
+	}
+	return s, false
+}
+
+func group(digits string, l locale) string {
+	size := l.grouping[0]
+	if len(digits) <= size {
+		return digits
+	}
+	var parts []string
+	for len(digits) > size {
+		parts = append([]string{digits[len(digits)-size:]}, parts...)
+		digits = digits[:len(digits)-size]
+		size = l.grouping[1]
+	}
+	parts = append([]string{digits}, parts...)
+	return strings.Join(parts, l.group)
+}
+
+// validGrouping returns whether the grouping separators in intPart, the
+// integer part of a number, are where group puts them, if there are any.
+func validGrouping(intPart string, l locale) bool {
+	parts := strings.Split(intPart, l.group)
+	if len(parts) == 1 {
+		return true
+	}
+	for i, p := range parts {
+		size := l.grouping[1]
+		if i == len(parts)-1 {
+			size = l.grouping[0]
+		}
+		// Only the first group may be shorter.
+		if len(p) != size && (i > 0 || len(p) == 0 || len(p) > size) {
+			return false
+		}
+	}
+	return true
+}
+
+func localize(s string, l locale, grouping bool) string {
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+	if grouping {
+		intPart = group(intPart, l)
+	}
+	if fracPart != "" {
+		return intPart + l.decimal + fracPart
+	}
+	return intPart
+}
+
+func formatNumber(x Number, opts Map) string {
+	l := getLocale(opts)
+	s, neg := plainDecimal(x, opts, -1)
+	res := localize(s, l, ToBool(getOpt(opts, "grouping", Boolean{B: true})))
+	if neg {
+		return "-" + res
+	}
+	return res
+}
+
+func formatCurrency(x Number, code string, opts Map) string {
+	code = strings.ToUpper(strings.TrimPrefix(code, ":"))
+	c, ok := currencies[code]
+	if !ok {
+		c = currency{code, 2}
+	}
+	symbol := c.symbol
+	if EnsureObjectIsKeyword(getOpt(opts, "display", MakeKeyword("symbol")), "display: %s").ToString(false) == ":code" {
+		symbol = code
+	}
+	l := getLocale(opts)
+	if r, _ := utf8.DecodeLastRuneInString(symbol); unicode.IsLetter(r) && strings.HasPrefix(l.currency, "¤#") {
+		symbol += nbsp
+	}
+	s, neg := plainDecimal(x, opts, c.decimals)
+	res := strings.Replace(l.currency, "#", localize(s, l, ToBool(getOpt(opts, "grouping", Boolean{B: true}))), 1)
+	res = strings.Replace(res, "¤", symbol, 1)
+	if neg {
+		return "-" + res
+	}
+	return res
+}
+
+var plainNumberRe = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+func currencySymbols() []string {
+	var res []string
+	for code, c := range currencies {
+		res = append(res, code, c.symbol)
+	}
+	// Longest first, so that e.g. "CA$" is removed before "$".
+	sort.Slice(res, func(i, j int) bool { return len(res[i]) > len(res[j]) })
+	return res
+}
+
+var symbols = currencySymbols()
+
+func parseNumber(s string, opts Map) *big.Float {
+	l := getLocale(opts)
+	orig := s
+	for _, sym := range symbols {
+		s = strings.Replace(s, sym, "", -1)
+	}
+	s = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\u00a0', '\u202f', '\t':
+			return -1
+		}
+		return r
+	}, s)
+	neg := false
+	switch {
+	case strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")"):
+		neg, s = true, s[1:len(s)-1]
+	case strings.HasPrefix(s, "-"):
+		neg, s = true, s[1:]
+	case strings.HasSuffix(s, "-"):
+		neg, s = true, s[:len(s)-1]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	if strings.TrimSpace(l.group) != "" {
+		intPart, fracPart := s, ""
+		if i := strings.Index(s, l.decimal); i >= 0 {
+			intPart, fracPart = s[:i], s[i:]
+		}
+		if strings.Contains(fracPart, l.group) || !validGrouping(intPart, l) {
+			panic(RT.NewError("Cannot parse number: " + orig))
+		}
+		s = strings.Replace(s, l.group, "", -1)
+	}
+	s = strings.Replace(s, l.decimal, ".", 1)
+	if !plainNumberRe.MatchString(s) {
+		panic(RT.NewError("Cannot parse number: " + orig))
+	}
+	if neg {
+		s = "-" + s
+	}
+	res, _ := MakeBigFloatWithOrig(s, "")
+	return res.BigFloat()
+}
@@ -36,6 +36,24 @@ func __args_(_args []Object) Object {
 	return NIL
 }
 
+var __at_exit__P ProcFn = __at_exit_
+var at_exit_ Proc = Proc{Fn: __at_exit__P, Name: "at_exit_", Package: "std/os"}
+
+func __at_exit_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		f := ExtractCallable(_args, 0)
+		_res := NIL
+		AtExit(f)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __chdir__P ProcFn = __chdir_
 var chdir_ Proc = Proc{Fn: __chdir__P, Name: "chdir_", Package: "std/os"}
 
@@ -13,10 +13,10 @@ import (
 )
 
 func env() Object {
-	res := EmptyArrayMap()
+	var res Associative = EmptyArrayMap()
 	for _, v := range os.Environ() {
 		parts := strings.SplitN(v, "=", 2)
-		res.Add(String{S: parts[0]}, String{S: parts[1]})
+		res = res.Assoc(String{S: parts[0]}, String{S: parts[1]})
 	}
 	return res
 }
@@ -28,9 +28,9 @@ func sh(dir string, stdin io.Reader, stdout io.Writer, stderr io.Writer, name st
 	err := cmd.Start()
 	PanicOnErr(err)
 
-	RT.GIL.Unlock()
+	gs := RT.GIL.Unlock()
 	err = cmd.Wait()
-	RT.GIL.Lock()
+	RT.GIL.Lock(gs)
 
 	res := EmptyArrayMap()
 	res.Add(MakeKeyword("success"), Boolean{B: err == nil})
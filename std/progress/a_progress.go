@@ -0,0 +1,197 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+package progress
+
+import (
+	. "github.com/candid82/joker/core"
+)
+
+var __bar__P ProcFn = __bar_
+var bar_ Proc = Proc{Fn: __bar__P, Name: "bar_", Package: "std/progress"}
+
+func __bar_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		total := ExtractObject(_args, 0)
+		_res := newBar(total, EmptyArrayMap())
+		return MakeProgress(_res)
+
+	case _c == 2:
+		total := ExtractObject(_args, 0)
+		opts := ExtractMap(_args, 1)
+		_res := newBar(total, opts)
+		return MakeProgress(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __done_bang__P ProcFn = __done_bang_
+var done_bang_ Proc = Proc{Fn: __done_bang__P, Name: "done_bang_", Package: "std/progress"}
+
+func __done_bang_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		p := ExtractProgress(_args, 0)
+		_res := done(p, NIL)
+		return _res
+
+	case _c == 2:
+		p := ExtractProgress(_args, 0)
+		msg := ExtractString(_args, 1)
+		_res := done(p, MakeString(msg))
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __message__P ProcFn = __message_
+var message_ Proc = Proc{Fn: __message__P, Name: "message_", Package: "std/progress"}
+
+func __message_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		p := ExtractProgress(_args, 0)
+		s := ExtractString(_args, 1)
+		_res := message(p, s)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __render__P ProcFn = __render_
+var render_ Proc = Proc{Fn: __render__P, Name: "render_", Package: "std/progress"}
+
+func __render_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		p := ExtractProgress(_args, 0)
+		_res := render(p)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __set_label_bang__P ProcFn = __set_label_bang_
+var set_label_bang_ Proc = Proc{Fn: __set_label_bang__P, Name: "set_label_bang_", Package: "std/progress"}
+
+func __set_label_bang_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		p := ExtractProgress(_args, 0)
+		label := ExtractString(_args, 1)
+		_res := setLabel(p, label)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __set_progress_bang__P ProcFn = __set_progress_bang_
+var set_progress_bang_ Proc = Proc{Fn: __set_progress_bang__P, Name: "set_progress_bang_", Package: "std/progress"}
+
+func __set_progress_bang_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		p := ExtractProgress(_args, 0)
+		n := ExtractInt(_args, 1)
+		_res := setProgress(p, n)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __spinner__P ProcFn = __spinner_
+var spinner_ Proc = Proc{Fn: __spinner__P, Name: "spinner_", Package: "std/progress"}
+
+func __spinner_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := newSpinner(EmptyArrayMap())
+		return MakeProgress(_res)
+
+	case _c == 1:
+		opts := ExtractMap(_args, 0)
+		_res := newSpinner(opts)
+		return MakeProgress(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __suspend__P ProcFn = __suspend_
+var suspend_ Proc = Proc{Fn: __suspend__P, Name: "suspend_", Package: "std/progress"}
+
+func __suspend_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		p := ExtractProgress(_args, 0)
+		f := ExtractCallable(_args, 1)
+		_res := suspend(p, f)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __tick_bang__P ProcFn = __tick_bang_
+var tick_bang_ Proc = Proc{Fn: __tick_bang__P, Name: "tick_bang_", Package: "std/progress"}
+
+func __tick_bang_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		p := ExtractProgress(_args, 0)
+		_res := tick(p, 1)
+		return _res
+
+	case _c == 2:
+		p := ExtractProgress(_args, 0)
+		n := ExtractInt(_args, 1)
+		_res := tick(p, n)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+func Init() {
+
+	InternsOrThunks()
+}
+
+var progressNamespace = GLOBAL_ENV.EnsureSymbolIsLib(MakeSymbol("joker.progress"))
+
+func init() {
+	progressNamespace.Lazy = Init
+}
@@ -0,0 +1,107 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+package progress
+
+import (
+	"fmt"
+	. "github.com/candid82/joker/core"
+	"os"
+)
+
+func InternsOrThunks() {
+	if VerbosityLevel > 0 {
+		fmt.Fprintln(os.Stderr, "Lazily running slow version of progress.InternsOrThunks().")
+	}
+	progressNamespace.ResetMeta(MakeMeta(nil, `Provides progress bars and spinners for long-running scripts.
+
+         Progress is rendered on a single line of standard error, which is redrawn
+         in place as it changes and animated in the background. When standard error
+         is not a terminal nothing is drawn until done! prints the final state,
+         so logs don't fill up with intermediate lines.
+
+         Printing to the terminal while a bar is shown garbles the line.
+         Use message to print a line above the bar, or suspend to run a function
+         with the bar hidden.
+
+         Example:
+
+         (def p (joker.progress/bar (count files) {:label "Copying"}))
+         (doseq [f files]
+           (copy f)
+           (joker.progress/tick! p))
+         (joker.progress/done! p)
+
+         renders
+
+         Copying [===============>              ]  50% 5/10 2.5/s ETA 0:02`, "1.0"))
+
+	progressNamespace.InternVarPacked("bar", bar_, &VarMeta{
+		Arglists: "([total] [total opts])",
+		Doc: `Returns a new progress bar counting up to total, or an indeterminate one
+  if total is nil. opts is a map with optional keys:
+  :label - the text shown before the bar,
+  :width - the width of the bar in characters (30 by default),
+  :unit - the unit appended to counts and rates, e.g. "B".
+  Determinate bars show the percentage, count, rate and estimated time remaining;
+  indeterminate bars show the count and rate.`,
+		Added: "1.2",
+		Extra: "{:tag \"Progress\"}",
+	})
+
+	progressNamespace.InternVarPacked("done!", done_bang_, &VarMeta{
+		Arglists: "([p] [p msg])",
+		Doc: `Stops p and prints its final state on a line of its own.
+  A determinate bar is shown as complete. If msg is given, it replaces the label.
+  Calling done! again has no effect.`,
+		Added: "1.2",
+	})
+
+	progressNamespace.InternVarPacked("message", message_, &VarMeta{
+		Arglists: "([p s])",
+		Doc:      `Prints s followed by a newline to standard error above p.`,
+		Added:    "1.2",
+	})
+
+	progressNamespace.InternVarPacked("render", render_, &VarMeta{
+		Arglists: "([p])",
+		Doc:      `Returns the line currently shown for p, without drawing it.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	progressNamespace.InternVarPacked("set-label!", set_label_bang_, &VarMeta{
+		Arglists: "([p label])",
+		Doc:      `Sets the label of p.`,
+		Added:    "1.2",
+	})
+
+	progressNamespace.InternVarPacked("set-progress!", set_progress_bang_, &VarMeta{
+		Arglists: "([p n])",
+		Doc:      `Sets the count of p to n.`,
+		Added:    "1.2",
+	})
+
+	progressNamespace.InternVarPacked("spinner", spinner_, &VarMeta{
+		Arglists: "([] [opts])",
+		Doc: `Returns a new spinner showing the elapsed time. opts is a map with optional keys:
+  :label - the text shown after the spinner,
+  :frames - a sequence of strings the spinner cycles through.`,
+		Added: "1.2",
+		Extra: "{:tag \"Progress\"}",
+	})
+
+	progressNamespace.InternVarPacked("suspend", suspend_, &VarMeta{
+		Arglists: "([p f])",
+		Doc: `Hides p while calling f with no arguments, then redraws it.
+  Returns the result of f. Use it to print output of arbitrary length
+  to the terminal while p is shown.`,
+		Added: "1.2",
+	})
+
+	progressNamespace.InternVarPacked("tick!", tick_bang_, &VarMeta{
+		Arglists: "([p] [p n])",
+		Doc:      `Advances the count of p by n (1 by default).`,
+		Added:    "1.2",
+	})
+
+}
@@ -0,0 +1,353 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+	"unsafe"
+
+	. "github.com/candid82/joker/core"
+)
+
+type (
+	// Progress is a progress bar or spinner rendered to stderr.
+	// Its state is guarded by a mutex since it's redrawn by a separate goroutine.
+	Progress struct {
+		*progress
+		hash uint32
+	}
+
+	progress struct {
+		mutex    sync.Mutex
+		out      io.Writer
+		tty      bool
+		label    string
+		unit     string
+		total    int // negative for indeterminate progress
+		count    int
+		width    int
+		spinner  bool
+		frames   []string
+		frame    int
+		start    time.Time
+		finished bool
+		paused   bool
+		stop     chan struct{}
+		lineLen  int
+	}
+)
+
+var progressType *Type
+
+var defaultFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+const refreshInterval = 100 * time.Millisecond
+
+func MakeProgress(p *progress) Progress {
+	res := Progress{p, 0}
+	res.hash = HashPtr(uintptr(unsafe.Pointer(p)))
+	return res
+}
+
+func (p Progress) ToString(escape bool) string {
+	return "#object[Progress]"
+}
+
+func (p Progress) Equals(other interface{}) bool {
+	if otherP, ok := other.(Progress); ok {
+		return p.progress == otherP.progress
+	}
+	return false
+}
+
+func (p Progress) GetInfo() *ObjectInfo {
+	return nil
+}
+
+func (p Progress) GetType() *Type {
+	return progressType
+}
+
+func (p Progress) Hash() uint32 {
+	return p.hash
+}
+
+func (p Progress) WithInfo(info *ObjectInfo) Object {
+	return p
+}
+
+func EnsureArgIsProgress(args []Object, index int) Progress {
+	obj := args[index]
+	if c, yes := obj.(Progress); yes {
+		return c
+	}
+	panic(FailArg(obj, "Progress", index))
+}
+
+func ExtractProgress(args []Object, index int) Progress {
+	return EnsureArgIsProgress(args, index)
+}
+
+func stderrIsTerminal() bool {
+	fi, err := os.Stderr.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+func optString(opts Map, key string, def string) string {
+	if ok, v := opts.Get(MakeKeyword(key)); ok {
+		return EnsureObjectIsString(v, key+": %s").S
+	}
+	return def
+}
+
+func optInt(opts Map, key string, def int) int {
+	if ok, v := opts.Get(MakeKeyword(key)); ok {
+		return EnsureObjectIsInt(v, key+": %s").I
+	}
+	return def
+}
+
+func newProgress(opts Map) *progress {
+	p := &progress{
+		out:   os.Stderr,
+		tty:   stderrIsTerminal(),
+		label: optString(opts, "label", ""),
+		unit:  optString(opts, "unit", ""),
+		total: -1,
+		width: optInt(opts, "width", 30),
+		start: time.Now(),
+		stop:  make(chan struct{}),
+	}
+	if p.tty {
+		go p.refresh()
+	}
+	return p
+}
+
+func newBar(total Object, opts Map) *progress {
+	p := newProgress(opts)
+	if _, ok := total.(Nil); !ok {
+		p.total = EnsureObjectIsInt(total, "total: %s").I
+	}
+	return p
+}
+
+func newSpinner(opts Map) *progress {
+	p := newProgress(opts)
+	p.spinner = true
+	p.frames = defaultFrames
+	if ok, v := opts.Get(MakeKeyword("frames")); ok {
+		p.frames = nil
+		for s := EnsureObjectIsSeqable(v, "frames: %s").Seq(); !s.IsEmpty(); s = s.Rest() {
+			p.frames = append(p.frames, s.First().ToString(false))
+		}
+		if len(p.frames) == 0 {
+			panic(RT.NewError("frames must not be empty"))
+		}
+	}
+	return p
+}
+
+func (p *progress) refresh() {
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.mutex.Lock()
+			p.frame++
+			p.draw()
+			p.mutex.Unlock()
+		}
+	}
+}
+
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	h, m, s := int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
+
+func formatRate(rate float64) string {
+	switch {
+	case rate >= 1e9:
+		return fmt.Sprintf("%.1fG", rate/1e9)
+	case rate >= 1e6:
+		return fmt.Sprintf("%.1fM", rate/1e6)
+	case rate >= 1e3:
+		return fmt.Sprintf("%.1fk", rate/1e3)
+	}
+	return fmt.Sprintf("%.1f", rate)
+}
+
+func (p *progress) rate(elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(p.count) / elapsed.Seconds()
+}
+
+// line returns the current state of p as a single line of text.
+func (p *progress) line() string {
+	elapsed := time.Since(p.start)
+	var parts []string
+	if p.spinner {
+		frame := p.frames[p.frame%len(p.frames)]
+		if p.finished {
+			frame = "✓"
+		}
+		parts = append(parts, frame)
+		if p.label != "" {
+			parts = append(parts, p.label)
+		}
+		return strings.Join(append(parts, formatDuration(elapsed)), " ")
+	}
+	if p.label != "" {
+		parts = append(parts, p.label)
+	}
+	var b strings.Builder
+	b.WriteRune('[')
+	if p.total >= 0 {
+		filled := p.width
+		if p.total > 0 && p.count < p.total {
+			filled = p.width * p.count / p.total
+		}
+		b.WriteString(strings.Repeat("=", filled))
+		if filled < p.width {
+			b.WriteRune('>')
+			b.WriteString(strings.Repeat(" ", p.width-filled-1))
+		}
+	} else {
+		// A marker bouncing between the ends of the bar.
+		n := p.width - 3
+		pos := p.frame % (2 * n)
+		if pos > n {
+			pos = 2*n - pos
+		}
+		if p.finished {
+			b.WriteString(strings.Repeat("=", p.width))
+		} else {
+			b.WriteString(strings.Repeat(" ", pos) + "<=>" + strings.Repeat(" ", n-pos))
+		}
+	}
+	b.WriteRune(']')
+	parts = append(parts, b.String())
+	rate := p.rate(elapsed)
+	if p.total >= 0 {
+		percent := 100
+		if p.total > 0 {
+			percent = 100 * p.count / p.total
+		}
+		parts = append(parts, fmt.Sprintf("%3d%%", percent), fmt.Sprintf("%d/%d%s", p.count, p.total, p.unit))
+	} else {
+		parts = append(parts, fmt.Sprintf("%d%s", p.count, p.unit))
+	}
+	parts = append(parts, formatRate(rate)+p.unit+"/s")
+	switch {
+	case p.finished:
+		parts = append(parts, formatDuration(elapsed))
+	case p.total >= 0 && rate > 0:
+		eta := time.Duration(float64(p.total-p.count) / rate * float64(time.Second))
+		parts = append(parts, "ETA "+formatDuration(eta))
+	}
+	return strings.Join(parts, " ")
+}
+
+// draw redraws p in place. Must be called with p.mutex locked.
+func (p *progress) draw() {
+	if !p.tty || p.finished || p.paused {
+		return
+	}
+	s := p.line()
+	n := utf8.RuneCountInString(s)
+	pad := ""
+	if n < p.lineLen {
+		pad = strings.Repeat(" ", p.lineLen-n)
+	}
+	fmt.Fprint(p.out, "\r"+s+pad)
+	p.lineLen = n
+}
+
+// clear erases the line of p. Must be called with p.mutex locked.
+func (p *progress) clear() {
+	if p.tty && !p.finished && p.lineLen > 0 {
+		fmt.Fprint(p.out, "\r"+strings.Repeat(" ", p.lineLen)+"\r")
+		p.lineLen = 0
+	}
+}
+
+func (p *progress) update(f func()) Object {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	f()
+	p.draw()
+	return NIL
+}
+
+func tick(p Progress, n int) Object {
+	return p.update(func() { p.count += n })
+}
+
+func setProgress(p Progress, n int) Object {
+	return p.update(func() { p.count = n })
+}
+
+func setLabel(p Progress, label string) Object {
+	return p.update(func() { p.label = label })
+}
+
+func message(p Progress, s string) Object {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.clear()
+	fmt.Fprintln(p.out, s)
+	p.draw()
+	return NIL
+}
+
+func suspend(p Progress, f Callable) Object {
+	p.mutex.Lock()
+	p.clear()
+	p.paused = true
+	p.mutex.Unlock()
+	defer p.update(func() { p.paused = false })
+	return f.Call([]Object{})
+}
+
+func done(p Progress, msg Object) Object {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.finished {
+		return NIL
+	}
+	p.clear()
+	close(p.stop)
+	p.finished = true
+	if p.total >= 0 && p.count < p.total && !p.spinner {
+		p.count = p.total
+	}
+	if s, ok := msg.(String); ok {
+		p.label = s.S
+	}
+	fmt.Fprintln(p.out, p.line())
+	return NIL
+}
+
+func render(p Progress) string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.line()
+}
+
+func init() {
+	progressType = RegType("Progress", (*Progress)(nil), "A progress bar or spinner")
+}
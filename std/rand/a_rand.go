@@ -0,0 +1,212 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+package rand
+
+import (
+	. "github.com/candid82/joker/core"
+)
+
+var __exponential__P ProcFn = __exponential_
+var exponential_ Proc = Proc{Fn: __exponential__P, Name: "exponential_", Package: "std/rand"}
+
+func __exponential_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		g := ExtractGenerator(_args, 0)
+		_res := exponential(g, 1)
+		return MakeDouble(_res)
+
+	case _c == 2:
+		g := ExtractGenerator(_args, 0)
+		rate := ExtractNumber(_args, 1)
+		_res := exponential(g, rate.Double().D)
+		return MakeDouble(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __new_generator__P ProcFn = __new_generator_
+var new_generator_ Proc = Proc{Fn: __new_generator__P, Name: "new_generator_", Package: "std/rand"}
+
+func __new_generator_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := newGenerator()
+		return MakeGenerator(_res)
+
+	case _c == 1:
+		seed := ExtractInt(_args, 0)
+		_res := newSeededGenerator(seed)
+		return MakeGenerator(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __normal__P ProcFn = __normal_
+var normal_ Proc = Proc{Fn: __normal__P, Name: "normal_", Package: "std/rand"}
+
+func __normal_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		g := ExtractGenerator(_args, 0)
+		_res := normal(g, 0, 1)
+		return MakeDouble(_res)
+
+	case _c == 3:
+		g := ExtractGenerator(_args, 0)
+		mean := ExtractNumber(_args, 1)
+		stddev := ExtractNumber(_args, 2)
+		_res := normal(g, mean.Double().D, stddev.Double().D)
+		return MakeDouble(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __rand__P ProcFn = __rand_
+var rand_ Proc = Proc{Fn: __rand__P, Name: "rand_", Package: "std/rand"}
+
+func __rand_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		g := ExtractGenerator(_args, 0)
+		_res := double(g)
+		return MakeDouble(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __rand_int__P ProcFn = __rand_int_
+var rand_int_ Proc = Proc{Fn: __rand_int__P, Name: "rand_int_", Package: "std/rand"}
+
+func __rand_int_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		g := ExtractGenerator(_args, 0)
+		n := ExtractInt(_args, 1)
+		_res := randInt(g, n)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __rand_nth__P ProcFn = __rand_nth_
+var rand_nth_ Proc = Proc{Fn: __rand_nth__P, Name: "rand_nth_", Package: "std/rand"}
+
+func __rand_nth_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		g := ExtractGenerator(_args, 0)
+		coll := ExtractSeqable(_args, 1)
+		_res := randNth(g, coll)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __sample__P ProcFn = __sample_
+var sample_ Proc = Proc{Fn: __sample__P, Name: "sample_", Package: "std/rand"}
+
+func __sample_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 3:
+		g := ExtractGenerator(_args, 0)
+		coll := ExtractSeqable(_args, 1)
+		n := ExtractInt(_args, 2)
+		_res := sample(g, coll, n)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __shuffle__P ProcFn = __shuffle_
+var shuffle_ Proc = Proc{Fn: __shuffle__P, Name: "shuffle_", Package: "std/rand"}
+
+func __shuffle_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		g := ExtractGenerator(_args, 0)
+		coll := ExtractSeqable(_args, 1)
+		_res := shuffle(g, coll)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __split__P ProcFn = __split_
+var split_ Proc = Proc{Fn: __split__P, Name: "split_", Package: "std/rand"}
+
+func __split_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		g := ExtractGenerator(_args, 0)
+		_res := split(g)
+		return MakeGenerator(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __uniform__P ProcFn = __uniform_
+var uniform_ Proc = Proc{Fn: __uniform__P, Name: "uniform_", Package: "std/rand"}
+
+func __uniform_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 3:
+		g := ExtractGenerator(_args, 0)
+		lo := ExtractNumber(_args, 1)
+		hi := ExtractNumber(_args, 2)
+		_res := uniform(g, lo.Double().D, hi.Double().D)
+		return MakeDouble(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+func Init() {
+
+	InternsOrThunks()
+}
+
+var randNamespace = GLOBAL_ENV.EnsureSymbolIsLib(MakeSymbol("joker.rand"))
+
+func init() {
+	randNamespace.Lazy = Init
+}
@@ -0,0 +1,28 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+//go:build !gen_code
+// +build !gen_code
+
+package rand
+
+import (
+	"fmt"
+	. "github.com/candid82/joker/core"
+	"os"
+)
+
+func InternsOrThunks() {
+	if VerbosityLevel > 0 {
+		fmt.Fprintln(os.Stderr, "Lazily running fast version of rand.InternsOrThunks().")
+	}
+	STD_thunk_rand_exponential__var = __exponential_
+	STD_thunk_rand_new_generator__var = __new_generator_
+	STD_thunk_rand_normal__var = __normal_
+	STD_thunk_rand_rand__var = __rand_
+	STD_thunk_rand_rand_int__var = __rand_int_
+	STD_thunk_rand_rand_nth__var = __rand_nth_
+	STD_thunk_rand_sample__var = __sample_
+	STD_thunk_rand_shuffle__var = __shuffle_
+	STD_thunk_rand_split__var = __split_
+	STD_thunk_rand_uniform__var = __uniform_
+}
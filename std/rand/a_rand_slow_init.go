@@ -0,0 +1,104 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+//go:build gen_code
+// +build gen_code
+
+package rand
+
+import (
+	"fmt"
+	. "github.com/candid82/joker/core"
+	"os"
+)
+
+func InternsOrThunks() {
+	if VerbosityLevel > 0 {
+		fmt.Fprintln(os.Stderr, "Lazily running slow version of rand.InternsOrThunks().")
+	}
+	randNamespace.ResetMeta(MakeMeta(nil, `Provides seedable pseudo-random number generators and random sampling.
+
+         Every function takes an explicit generator, so that results are reproducible
+         when the generator is created with a seed. Generators are not suitable
+         for security-sensitive work.
+
+         Example:
+
+         user=> (def g (joker.rand/new-generator 42))
+         #'user/g
+         user=> (joker.rand/rand-int g 100)
+         5`, "1.0"))
+
+	randNamespace.InternVarPacked("exponential", exponential_, &VarMeta{
+		Arglists: "([g] [g rate])",
+		Doc: `Returns an exponentially distributed random Double with the given rate
+  (1 by default). The mean of the distribution is 1/rate.`,
+		Added: "1.2",
+		Extra: "{:tag \"Double\"}",
+	})
+
+	randNamespace.InternVarPacked("new-generator", new_generator_, &VarMeta{
+		Arglists: "([] [seed])",
+		Doc: `Returns a new pseudo-random number generator.
+  If seed is given, the generator produces the same sequence of values
+  every time. Otherwise it is seeded from the current time.`,
+		Added: "1.2",
+		Extra: "{:tag \"Generator\"}",
+	})
+
+	randNamespace.InternVarPacked("normal", normal_, &VarMeta{
+		Arglists: "([g] [g mean stddev])",
+		Doc: `Returns a normally distributed random Double with the given mean
+  and standard deviation (0 and 1 by default).`,
+		Added: "1.2",
+		Extra: "{:tag \"Double\"}",
+	})
+
+	randNamespace.InternVarPacked("rand", rand_, &VarMeta{
+		Arglists: "([g])",
+		Doc:      `Returns a random Double in the half-open interval [0.0, 1.0).`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	randNamespace.InternVarPacked("rand-int", rand_int_, &VarMeta{
+		Arglists: "([g n])",
+		Doc:      `Returns a random Int in the half-open interval [0, n). n must be positive.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+	randNamespace.InternVarPacked("rand-nth", rand_nth_, &VarMeta{
+		Arglists: "([g coll])",
+		Doc:      `Returns a random element of coll. Throws an exception if coll is empty.`,
+		Added:    "1.2",
+	})
+
+	randNamespace.InternVarPacked("sample", sample_, &VarMeta{
+		Arglists: "([g coll n])",
+		Doc: `Returns a vector of n elements of coll chosen at random without replacement,
+  or all elements of coll if it has fewer than n.`,
+		Added: "1.2",
+	})
+
+	randNamespace.InternVarPacked("shuffle", shuffle_, &VarMeta{
+		Arglists: "([g coll])",
+		Doc:      `Returns a random permutation of coll as a vector.`,
+		Added:    "1.2",
+	})
+
+	randNamespace.InternVarPacked("split", split_, &VarMeta{
+		Arglists: "([g])",
+		Doc: `Returns a new generator seeded from g. Use it to give each of several
+  goroutines its own generator while keeping results reproducible.`,
+		Added: "1.2",
+		Extra: "{:tag \"Generator\"}",
+	})
+
+	randNamespace.InternVarPacked("uniform", uniform_, &VarMeta{
+		Arglists: "([g lo hi])",
+		Doc:      `Returns a random Double uniformly distributed in [lo, hi).`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+}
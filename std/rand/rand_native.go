@@ -0,0 +1,170 @@
+package rand
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+	"unsafe"
+
+	. "github.com/candid82/joker/core"
+)
+
+type (
+	// Generator wraps math/rand.Rand, which is not safe for concurrent use.
+	Generator struct {
+		*rand.Rand
+		mutex *sync.Mutex
+		hash  uint32
+	}
+)
+
+var generatorType *Type
+
+func MakeGenerator(r *rand.Rand) Generator {
+	res := Generator{r, &sync.Mutex{}, 0}
+	res.hash = HashPtr(uintptr(unsafe.Pointer(r)))
+	return res
+}
+
+func (g Generator) ToString(escape bool) string {
+	return "#object[Generator]"
+}
+
+func (g Generator) Equals(other interface{}) bool {
+	if otherG, ok := other.(Generator); ok {
+		return g.Rand == otherG.Rand
+	}
+	return false
+}
+
+func (g Generator) GetInfo() *ObjectInfo {
+	return nil
+}
+
+func (g Generator) GetType() *Type {
+	return generatorType
+}
+
+func (g Generator) Hash() uint32 {
+	return g.hash
+}
+
+func (g Generator) WithInfo(info *ObjectInfo) Object {
+	return g
+}
+
+func EnsureArgIsGenerator(args []Object, index int) Generator {
+	obj := args[index]
+	if c, yes := obj.(Generator); yes {
+		return c
+	}
+	panic(FailArg(obj, "Generator", index))
+}
+
+func ExtractGenerator(args []Object, index int) Generator {
+	return EnsureArgIsGenerator(args, index)
+}
+
+func (g Generator) with(f func(r *rand.Rand)) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	f(g.Rand)
+}
+
+func newGenerator() *rand.Rand {
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
+}
+
+func newSeededGenerator(seed int) *rand.Rand {
+	return rand.New(rand.NewSource(int64(seed)))
+}
+
+func split(g Generator) (res *rand.Rand) {
+	g.with(func(r *rand.Rand) {
+		res = rand.New(rand.NewSource(r.Int63()))
+	})
+	return
+}
+
+func double(g Generator) (res float64) {
+	g.with(func(r *rand.Rand) {
+		res = r.Float64()
+	})
+	return
+}
+
+func uniform(g Generator, lo, hi float64) float64 {
+	return lo + (hi-lo)*double(g)
+}
+
+func randInt(g Generator, n int) (res int) {
+	if n <= 0 {
+		panic(RT.NewError("Upper bound must be positive"))
+	}
+	g.with(func(r *rand.Rand) {
+		res = r.Intn(n)
+	})
+	return
+}
+
+func normal(g Generator, mean, stddev float64) (res float64) {
+	g.with(func(r *rand.Rand) {
+		res = r.NormFloat64()*stddev + mean
+	})
+	return
+}
+
+func exponential(g Generator, rate float64) (res float64) {
+	if rate <= 0 || math.IsNaN(rate) {
+		panic(RT.NewError("Rate must be positive"))
+	}
+	g.with(func(r *rand.Rand) {
+		res = r.ExpFloat64() / rate
+	})
+	return
+}
+
+func randNth(g Generator, coll Seqable) Object {
+	objs := ToSlice(coll.Seq())
+	if len(objs) == 0 {
+		panic(RT.NewError("rand-nth of empty collection"))
+	}
+	return objs[randInt(g, len(objs))]
+}
+
+func shuffle(g Generator, coll Seqable) *Vector {
+	objs := ToSlice(coll.Seq())
+	g.with(func(r *rand.Rand) {
+		r.Shuffle(len(objs), func(i, j int) {
+			objs[i], objs[j] = objs[j], objs[i]
+		})
+	})
+	return NewVectorFrom(objs...)
+}
+
+// sample uses reservoir sampling. coll is realized before locking g, as
+// its items may be drawn from g.
+func sample(g Generator, coll Seqable, n int) *Vector {
+	if n < 0 {
+		panic(RT.NewError("Sample size must be non-negative"))
+	}
+	objs := ToSlice(coll.Seq())
+	if n > len(objs) {
+		n = len(objs)
+	}
+	res := make([]Object, n)
+	copy(res, objs)
+	g.with(func(r *rand.Rand) {
+		for i := n; i < len(objs); i++ {
+			if j := r.Intn(i + 1); j < n {
+				res[j] = objs[i]
+			}
+		}
+	})
+	return NewVectorFrom(res...)
+}
+
+func init() {
+	generatorType = RegType("Generator", (*Generator)(nil), "Wraps a seedable pseudo-random number generator")
+}
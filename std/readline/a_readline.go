@@ -0,0 +1,39 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+package readline
+
+import (
+	. "github.com/candid82/joker/core"
+)
+
+var __read_line__P ProcFn = __read_line_
+var read_line_ Proc = Proc{Fn: __read_line__P, Name: "read_line_", Package: "std/readline"}
+
+func __read_line_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := readLine(EmptyArrayMap())
+		return _res
+
+	case _c == 1:
+		opts := ExtractMap(_args, 0)
+		_res := readLine(opts)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+func Init() {
+
+	InternsOrThunks()
+}
+
+var readlineNamespace = GLOBAL_ENV.EnsureSymbolIsLib(MakeSymbol("joker.readline"))
+
+func init() {
+	readlineNamespace.Lazy = Init
+}
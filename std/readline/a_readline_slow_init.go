@@ -0,0 +1,51 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+package readline
+
+import (
+	"fmt"
+	. "github.com/candid82/joker/core"
+	"os"
+)
+
+func InternsOrThunks() {
+	if VerbosityLevel > 0 {
+		fmt.Fprintln(os.Stderr, "Lazily running slow version of readline.InternsOrThunks().")
+	}
+	readlineNamespace.ResetMeta(MakeMeta(nil, `Provides the line editor used by the REPL to scripts, so they can
+         offer their own interactive shells.
+
+         Lines are edited with the usual keys (arrows, Home, End, Ctrl-A,
+         Ctrl-E, Ctrl-K, Ctrl-W etc.), previous lines are recalled with
+         the up and down arrow keys or searched with Ctrl-R, and Tab completes
+         words if a completer is given.
+
+         The line editor is only used when *in* and *out* are bound to the
+         standard input and output of the process and both are terminals.
+         Otherwise the prompt is printed to *out* and the line is read from *in*,
+         so scripts work the same when input is piped.
+
+         Example:
+
+         (loop []
+           (when-let [line (joker.readline/read-line {:prompt "calc> "
+                                                      :history-file ".calc_history"})]
+             (println (eval-line line))
+             (recur)))`, "1.0"))
+
+	readlineNamespace.InternVarPacked("read-line", read_line_, &VarMeta{
+		Arglists: "([] [opts])",
+		Doc: `Prints the prompt and returns the line entered, or nil at the end of input
+  (e.g. when Ctrl-D is pressed). Throws if Ctrl-C is pressed.
+  opts is a map with optional keys:
+  :prompt - the prompt string (empty by default),
+  :history-file - the file the history is loaded from and saved to.
+  Without it, lines entered during the current run are used as the history,
+  :completer - a function called with the word before the cursor (the text
+  after the last space) when Tab is pressed, which returns a sequence of
+  strings replacing the word. If there are several, their common prefix
+  is inserted and they are listed below the line.`,
+		Added: "1.2",
+	})
+
+}
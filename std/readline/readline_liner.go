@@ -0,0 +1,80 @@
+//go:build !plan9
+// +build !plan9
+
+package readline
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	. "github.com/candid82/joker/core"
+	"github.com/candid82/liner"
+)
+
+func readLine(opts Map) Object {
+	prompt := optString(opts, "prompt")
+	if !useLineEditor() {
+		return readPlainLine(prompt)
+	}
+	historyFile := optString(opts, "history-file")
+	rl := liner.NewLiner()
+	defer rl.Close()
+	rl.SetCtrlCAborts(true)
+	if historyFile != "" {
+		if f, err := os.Open(historyFile); err == nil {
+			rl.ReadHistory(f)
+			f.Close()
+		}
+	} else {
+		for _, line := range history {
+			rl.AppendHistory(line)
+		}
+	}
+	var completerPanic interface{}
+	var gs *GoroutineState
+	if ok, c := opts.Get(MakeKeyword("completer")); ok && c != NIL {
+		completer := EnsureObjectIsCallable(c, "completer: %s")
+		rl.SetTabCompletionStyle(liner.TabPrints)
+		rl.SetWordCompleter(func(line string, pos int) (head string, c []string, tail string) {
+			// The completer is called while the line is being edited, with the GIL released.
+			RT.GIL.Lock(gs)
+			defer RT.GIL.Unlock()
+			s := RT.SaveCallState()
+			defer func() {
+				if r := recover(); r != nil && completerPanic == nil {
+					completerPanic = RT.Recovered(r, s)
+				}
+			}()
+			return completions(completer, line, pos)
+		})
+	}
+	gs = RT.GIL.Unlock()
+	line, err := rl.Prompt(prompt)
+	RT.GIL.Lock(gs)
+	if completerPanic != nil {
+		panic(completerPanic)
+	}
+	switch err {
+	case nil:
+	case io.EOF:
+		return NIL
+	case liner.ErrPromptAborted:
+		panic(RT.NewError("Interrupted"))
+	default:
+		panic(RT.NewError(err.Error()))
+	}
+	if strings.TrimSpace(line) != "" {
+		rl.AppendHistory(line)
+		if historyFile != "" {
+			f, err := os.Create(historyFile)
+			PanicOnErr(err)
+			defer f.Close()
+			_, err = rl.WriteHistory(f)
+			PanicOnErr(err)
+		} else {
+			history = append(history, line)
+		}
+	}
+	return MakeString(line)
+}
@@ -0,0 +1,58 @@
+package readline
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	. "github.com/candid82/joker/core"
+)
+
+// Lines entered during this run, used as the history
+// when no history file is given.
+var history []string
+
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// useLineEditor returns true if *in* and *out* are bound to
+// the standard streams of the process and both are terminals.
+func useLineEditor() bool {
+	initialIn, initialOut, _ := GLOBAL_ENV.InitialStdIO()
+	in, out, _ := GLOBAL_ENV.StdIO()
+	return in == initialIn && out == initialOut && isTerminal(os.Stdin) && isTerminal(os.Stdout)
+}
+
+// readPlainLine prints prompt to *out* and reads a line from *in*.
+// Returns nil at the end of input.
+func readPlainLine(prompt string) Object {
+	in, out, _ := GLOBAL_ENV.StdIO()
+	w := EnsureObjectIsio_Writer(out, "*out*: %s")
+	fmt.Fprint(w, prompt)
+	s, err := EnsureObjectIsStringReader(in, "*in*: %s").ReadString('\n')
+	if err != nil && s == "" {
+		return NIL
+	}
+	return MakeString(strings.TrimRight(s, "\r\n"))
+}
+
+func optString(opts Map, key string) string {
+	if ok, v := opts.Get(MakeKeyword(key)); ok && v != NIL {
+		return EnsureObjectIsString(v, key+": %s").S
+	}
+	return ""
+}
+
+// completions calls completer with the word before the cursor in line
+// and returns the arguments expected by liner's word completer.
+func completions(completer Callable, line string, pos int) (head string, c []string, tail string) {
+	head, tail = line[:pos], line[pos:]
+	start := strings.LastIndexAny(head, " \t") + 1
+	word := head[start:]
+	for s := EnsureObjectIsSeqable(completer.Call([]Object{MakeString(word)}), "completer result: %s").Seq(); !s.IsEmpty(); s = s.Rest() {
+		c = append(c, s.First().ToString(false))
+	}
+	return head[:start], c, tail
+}
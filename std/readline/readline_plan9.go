@@ -0,0 +1,9 @@
+package readline
+
+import (
+	. "github.com/candid82/joker/core"
+)
+
+func readLine(opts Map) Object {
+	return readPlainLine(optString(opts, "prompt"))
+}
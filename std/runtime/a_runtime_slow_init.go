@@ -14,20 +14,26 @@ func InternsOrThunks() {
 	}
 	runtimeNamespace.ResetMeta(MakeMeta(nil, `Provides access to Go and Joker runtime information.`, "1.0"))
 
-	runtimeNamespace.InternVar("go-root", go_root_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom()),
-			`Returns the GOROOT string (as returned by runtime/GOROOT()).`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
+	runtimeNamespace.InternVarPacked("go-root", go_root_, &VarMeta{
+		Arglists: "([])",
+		Doc:      `Returns the GOROOT string (as returned by runtime/GOROOT()).`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
 
-	runtimeNamespace.InternVar("go-version", go_version_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom()),
-			`Returns the Go version string (as returned by runtime/Version()).`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
+	runtimeNamespace.InternVarPacked("go-version", go_version_, &VarMeta{
+		Arglists: "([])",
+		Doc:      `Returns the Go version string (as returned by runtime/Version()).`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
 
-	runtimeNamespace.InternVar("joker-version", joker_version_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom()),
-			`Returns the raw Joker version string (including the leading 'v',
-  which joker.core/joker-version omits).`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
+	runtimeNamespace.InternVarPacked("joker-version", joker_version_, &VarMeta{
+		Arglists: "([])",
+		Doc: `Returns the raw Joker version string (including the leading 'v',
+  which joker.core/joker-version omits).`,
+		Added: "1.0",
+		Extra: "{:tag \"String\"}",
+	})
 
 }
@@ -0,0 +1,273 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+package sql
+
+import (
+	. "github.com/candid82/joker/core"
+)
+
+var __close__P ProcFn = __close_
+var close_ Proc = Proc{Fn: __close__P, Name: "close_", Package: "std/sql"}
+
+func __close_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		db := ExtractSQLDB(_args, 0)
+		_res := closeDB(db)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __close_prepared__P ProcFn = __close_prepared_
+var close_prepared_ Proc = Proc{Fn: __close_prepared__P, Name: "close_prepared_", Package: "std/sql"}
+
+func __close_prepared_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		stmt := ExtractSQLStmt(_args, 0)
+		_res := closePrepared(stmt)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __execute__P ProcFn = __execute_
+var execute_ Proc = Proc{Fn: __execute__P, Name: "execute_", Package: "std/sql"}
+
+func __execute_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		conn := ExtractSQLConn(_args, 0)
+		q := ExtractString(_args, 1)
+		_res := execute(conn, q, NIL, EmptyArrayMap())
+		return _res
+
+	case _c == 3:
+		conn := ExtractSQLConn(_args, 0)
+		q := ExtractString(_args, 1)
+		params := ExtractSeqable(_args, 2)
+		_res := execute(conn, q, params, EmptyArrayMap())
+		return _res
+
+	case _c == 4:
+		conn := ExtractSQLConn(_args, 0)
+		q := ExtractString(_args, 1)
+		params := ExtractSeqable(_args, 2)
+		opts := ExtractMap(_args, 3)
+		_res := execute(conn, q, params, opts)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __execute_batch__P ProcFn = __execute_batch_
+var execute_batch_ Proc = Proc{Fn: __execute_batch__P, Name: "execute_batch_", Package: "std/sql"}
+
+func __execute_batch_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 3:
+		conn := ExtractSQLConn(_args, 0)
+		q := ExtractString(_args, 1)
+		batch := ExtractSeqable(_args, 2)
+		_res := executeBatch(conn, q, batch, EmptyArrayMap())
+		return _res
+
+	case _c == 4:
+		conn := ExtractSQLConn(_args, 0)
+		q := ExtractString(_args, 1)
+		batch := ExtractSeqable(_args, 2)
+		opts := ExtractMap(_args, 3)
+		_res := executeBatch(conn, q, batch, opts)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __execute_prepared__P ProcFn = __execute_prepared_
+var execute_prepared_ Proc = Proc{Fn: __execute_prepared__P, Name: "execute_prepared_", Package: "std/sql"}
+
+func __execute_prepared_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		stmt := ExtractSQLStmt(_args, 0)
+		_res := executePrepared(stmt, NIL, EmptyArrayMap())
+		return _res
+
+	case _c == 2:
+		stmt := ExtractSQLStmt(_args, 0)
+		params := ExtractSeqable(_args, 1)
+		_res := executePrepared(stmt, params, EmptyArrayMap())
+		return _res
+
+	case _c == 3:
+		stmt := ExtractSQLStmt(_args, 0)
+		params := ExtractSeqable(_args, 1)
+		opts := ExtractMap(_args, 2)
+		_res := executePrepared(stmt, params, opts)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __open__P ProcFn = __open_
+var open_ Proc = Proc{Fn: __open__P, Name: "open_", Package: "std/sql"}
+
+func __open_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		driver := ExtractString(_args, 0)
+		dsn := ExtractString(_args, 1)
+		_res := open(driver, dsn, EmptyArrayMap())
+		return MakeSQLDB(_res)
+
+	case _c == 3:
+		driver := ExtractString(_args, 0)
+		dsn := ExtractString(_args, 1)
+		opts := ExtractMap(_args, 2)
+		_res := open(driver, dsn, opts)
+		return MakeSQLDB(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __prepare__P ProcFn = __prepare_
+var prepare_ Proc = Proc{Fn: __prepare__P, Name: "prepare_", Package: "std/sql"}
+
+func __prepare_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		conn := ExtractSQLConn(_args, 0)
+		q := ExtractString(_args, 1)
+		_res := prepare(conn, q)
+		return MakeSQLStmt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __query__P ProcFn = __query_
+var query_ Proc = Proc{Fn: __query__P, Name: "query_", Package: "std/sql"}
+
+func __query_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		conn := ExtractSQLConn(_args, 0)
+		q := ExtractString(_args, 1)
+		_res := query(conn, q, NIL, EmptyArrayMap())
+		return _res
+
+	case _c == 3:
+		conn := ExtractSQLConn(_args, 0)
+		q := ExtractString(_args, 1)
+		params := ExtractSeqable(_args, 2)
+		_res := query(conn, q, params, EmptyArrayMap())
+		return _res
+
+	case _c == 4:
+		conn := ExtractSQLConn(_args, 0)
+		q := ExtractString(_args, 1)
+		params := ExtractSeqable(_args, 2)
+		opts := ExtractMap(_args, 3)
+		_res := query(conn, q, params, opts)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __query_prepared__P ProcFn = __query_prepared_
+var query_prepared_ Proc = Proc{Fn: __query_prepared__P, Name: "query_prepared_", Package: "std/sql"}
+
+func __query_prepared_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		stmt := ExtractSQLStmt(_args, 0)
+		_res := queryPrepared(stmt, NIL, EmptyArrayMap())
+		return _res
+
+	case _c == 2:
+		stmt := ExtractSQLStmt(_args, 0)
+		params := ExtractSeqable(_args, 1)
+		_res := queryPrepared(stmt, params, EmptyArrayMap())
+		return _res
+
+	case _c == 3:
+		stmt := ExtractSQLStmt(_args, 0)
+		params := ExtractSeqable(_args, 1)
+		opts := ExtractMap(_args, 2)
+		_res := queryPrepared(stmt, params, opts)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __with_transaction__P ProcFn = __with_transaction_
+var with_transaction_ Proc = Proc{Fn: __with_transaction__P, Name: "with_transaction_", Package: "std/sql"}
+
+func __with_transaction_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		db := ExtractSQLDB(_args, 0)
+		f := ExtractCallable(_args, 1)
+		_res := withTransaction(db, EmptyArrayMap(), f)
+		return _res
+
+	case _c == 3:
+		db := ExtractSQLDB(_args, 0)
+		opts := ExtractMap(_args, 1)
+		f := ExtractCallable(_args, 2)
+		_res := withTransaction(db, opts, f)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+func Init() {
+
+	InternsOrThunks()
+}
+
+var sqlNamespace = GLOBAL_ENV.EnsureSymbolIsLib(MakeSymbol("joker.sql"))
+
+func init() {
+	sqlNamespace.Lazy = Init
+}
@@ -0,0 +1,140 @@
+package sql
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"io/ioutil"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/candid82/joker/core"
+	"github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+)
+
+var tlsConfigCount int
+
+func optString(opts Map, key string) (string, bool) {
+	if ok, v := opts.Get(MakeKeyword(key)); ok && !v.Equals(NIL) {
+		return EnsureObjectIsString(v, key+": %s").S, true
+	}
+	return "", false
+}
+
+func optInt(opts Map, key string) (int, bool) {
+	if ok, v := opts.Get(MakeKeyword(key)); ok && !v.Equals(NIL) {
+		return EnsureObjectIsInt(v, key+": %s").I, true
+	}
+	return 0, false
+}
+
+func optBool(opts Map, key string) bool {
+	ok, v := opts.Get(MakeKeyword(key))
+	return ok && ToBool(v)
+}
+
+func makeTLSConfig(opts Map) *tls.Config {
+	config := &tls.Config{InsecureSkipVerify: optBool(opts, "insecure-skip-verify")}
+	config.ServerName, _ = optString(opts, "server-name")
+	if caFile, ok := optString(opts, "ca-file"); ok {
+		pem, err := ioutil.ReadFile(caFile)
+		PanicOnErr(err)
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			panic(RT.NewError("No certificates found in " + caFile))
+		}
+	}
+	certFile, hasCert := optString(opts, "cert-file")
+	keyFile, hasKey := optString(opts, "key-file")
+	if hasCert != hasKey {
+		panic(RT.NewError(":cert-file and :key-file must be specified together"))
+	}
+	if hasCert {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		PanicOnErr(err)
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config
+}
+
+// mysqlDSN enables parsing of DATETIME and DATE columns into times
+// and registers the TLS configuration (if any) with the driver.
+func mysqlDSN(dsn string, tlsOpts Map) string {
+	cfg, err := mysql.ParseDSN(dsn)
+	PanicOnErr(err)
+	cfg.ParseTime = true
+	if tlsOpts != nil {
+		tlsConfigCount++
+		name := "joker-" + strconv.Itoa(tlsConfigCount)
+		PanicOnErr(mysql.RegisterTLSConfig(name, makeTLSConfig(tlsOpts)))
+		cfg.TLSConfig = name
+	}
+	return cfg.FormatDSN()
+}
+
+// postgresDSN adds TLS parameters to dsn, which is either
+// a URL or a list of space-separated key=value pairs.
+func postgresDSN(dsn string, tlsOpts Map) string {
+	if tlsOpts == nil {
+		return dsn
+	}
+	var params [][2]string
+	mode := "verify-full"
+	if optBool(tlsOpts, "insecure-skip-verify") {
+		mode = "require"
+	}
+	params = append(params, [2]string{"sslmode", mode})
+	for _, p := range [][2]string{{"ca-file", "sslrootcert"}, {"cert-file", "sslcert"}, {"key-file", "sslkey"}} {
+		if v, ok := optString(tlsOpts, p[0]); ok {
+			params = append(params, [2]string{p[1], v})
+		}
+	}
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		u, err := url.Parse(dsn)
+		PanicOnErr(err)
+		q := u.Query()
+		for _, p := range params {
+			q.Set(p[0], p[1])
+		}
+		u.RawQuery = q.Encode()
+		return u.String()
+	}
+	var b strings.Builder
+	b.WriteString(dsn)
+	for _, p := range params {
+		b.WriteString(" " + p[0] + "='" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(p[1]) + "'")
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func configureDSN(driver, dsn string, opts Map) string {
+	var tlsOpts Map
+	if ok, v := opts.Get(MakeKeyword("tls")); ok && !v.Equals(NIL) {
+		tlsOpts = EnsureObjectIsMap(v, "tls: %s")
+	}
+	switch driver {
+	case "mysql":
+		return mysqlDSN(dsn, tlsOpts)
+	case "postgres":
+		return postgresDSN(dsn, tlsOpts)
+	}
+	if tlsOpts != nil {
+		panic(RT.NewError("TLS options are not supported for driver " + driver))
+	}
+	return dsn
+}
+
+func configurePool(db *sql.DB, opts Map) {
+	if n, ok := optInt(opts, "max-open-conns"); ok {
+		db.SetMaxOpenConns(n)
+	}
+	if n, ok := optInt(opts, "max-idle-conns"); ok {
+		db.SetMaxIdleConns(n)
+	}
+	if n, ok := optInt(opts, "conn-max-lifetime"); ok {
+		db.SetConnMaxLifetime(time.Duration(n))
+	}
+}
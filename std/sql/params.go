@@ -0,0 +1,101 @@
+package sql
+
+import (
+	"strconv"
+	"strings"
+
+	. "github.com/candid82/joker/core"
+)
+
+func isParamNameChar(c byte) bool {
+	return c == '_' || c == '-' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// skipQuoted returns the index just past the quoted string, quoted
+// identifier or comment starting at q[i], or i if there's none.
+func skipQuoted(q string, i int) int {
+	var end string
+	switch {
+	case q[i] == '\'' || q[i] == '"' || q[i] == '`':
+		end = q[i : i+1]
+	case strings.HasPrefix(q[i:], "--"):
+		end = "\n"
+	case strings.HasPrefix(q[i:], "/*"):
+		end = "*/"
+	default:
+		return i
+	}
+	j := strings.Index(q[i+1:], end)
+	if j < 0 {
+		return len(q)
+	}
+	return i + 1 + j + len(end)
+}
+
+// bindNamed replaces :name placeholders in q with the driver's positional
+// placeholders, returning the new query and the names in the order of the
+// placeholders. Quoted strings, quoted identifiers, comments and
+// PostgreSQL casts like x::int are left intact.
+func bindNamed(q string, driver string) (string, []string) {
+	var b strings.Builder
+	var names []string
+	for i := 0; i < len(q); {
+		if j := skipQuoted(q, i); j > i {
+			b.WriteString(q[i:j])
+			i = j
+			continue
+		}
+		if q[i] == ':' && i+1 < len(q) && q[i+1] == ':' {
+			b.WriteString("::")
+			i += 2
+			continue
+		}
+		if q[i] == ':' && i+1 < len(q) && isParamNameChar(q[i+1]) && q[i+1] != '-' {
+			j := i + 1
+			for j < len(q) && isParamNameChar(q[j]) {
+				j++
+			}
+			names = append(names, q[i+1:j])
+			if driver == "postgres" {
+				b.WriteString("$" + strconv.Itoa(len(names)))
+			} else {
+				b.WriteByte('?')
+			}
+			i = j
+			continue
+		}
+		b.WriteByte(q[i])
+		i++
+	}
+	return b.String(), names
+}
+
+func namedValue(params Map, name string) Object {
+	if ok, v := params.Get(MakeKeyword(name)); ok {
+		return v
+	}
+	if ok, v := params.Get(MakeString(name)); ok {
+		return v
+	}
+	panic(RT.NewError("No value for parameter :" + name))
+}
+
+// namedArgs returns the values of params for names in order.
+func namedArgs(params Map, names []string) []interface{} {
+	res := make([]interface{}, len(names))
+	for i, name := range names {
+		res[i] = toSQLValue(namedValue(params, name))
+	}
+	return res
+}
+
+// bindParams returns the query to run and its arguments. If params is
+// a map, named placeholders in q are bound to its values; otherwise
+// params is a sequence of values bound to positional placeholders.
+func bindParams(q string, driver string, params Seqable) (string, []interface{}) {
+	if m, ok := params.(Map); ok && m != NIL {
+		q, names := bindNamed(q, driver)
+		return q, namedArgs(m, names)
+	}
+	return q, toSQLArgs(params)
+}
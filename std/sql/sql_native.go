@@ -0,0 +1,556 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+
+	. "github.com/candid82/joker/core"
+	_ "modernc.org/sqlite"
+)
+
+type (
+	// SQLDB is a database opened with a particular driver. The driver name
+	// determines the syntax of positional placeholders.
+	SQLDB struct {
+		*sqlDB
+		hash uint32
+	}
+
+	sqlDB struct {
+		*sql.DB
+		driver string
+	}
+
+	// SQLTx is a transaction started by with-transaction.
+	SQLTx struct {
+		*sqlTx
+		hash uint32
+	}
+
+	sqlTx struct {
+		*sql.Tx
+		driver string
+	}
+
+	// SQLStmt is a prepared statement. names holds the names of its
+	// placeholders if it was prepared from a query with named parameters.
+	SQLStmt struct {
+		*sqlStmt
+		hash uint32
+	}
+
+	sqlStmt struct {
+		*sql.Stmt
+		names []string
+	}
+
+	// SQLConn is either SQLDB or SQLTx.
+	SQLConn interface {
+		Object
+		conn() conn
+		driverName() string
+	}
+
+	conn interface {
+		QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	}
+)
+
+var sqlDBType *Type
+var sqlTxType *Type
+var sqlStmtType *Type
+
+func MakeSQLDB(db *sqlDB) SQLDB {
+	res := SQLDB{db, 0}
+	res.hash = HashPtr(uintptr(unsafe.Pointer(db)))
+	return res
+}
+
+func (db SQLDB) ToString(escape bool) string {
+	return "#object[SQLDB]"
+}
+
+func (db SQLDB) Equals(other interface{}) bool {
+	if otherDb, ok := other.(SQLDB); ok {
+		return db.sqlDB == otherDb.sqlDB
+	}
+	return false
+}
+
+func (db SQLDB) GetInfo() *ObjectInfo {
+	return nil
+}
+
+func (db SQLDB) GetType() *Type {
+	return sqlDBType
+}
+
+func (db SQLDB) Hash() uint32 {
+	return db.hash
+}
+
+func (db SQLDB) WithInfo(info *ObjectInfo) Object {
+	return db
+}
+
+func (db SQLDB) conn() conn {
+	return db.DB
+}
+
+func (db SQLDB) driverName() string {
+	return db.driver
+}
+
+func EnsureArgIsSQLDB(args []Object, index int) SQLDB {
+	obj := args[index]
+	if c, yes := obj.(SQLDB); yes {
+		return c
+	}
+	panic(FailArg(obj, "SQLDB", index))
+}
+
+func ExtractSQLDB(args []Object, index int) SQLDB {
+	return EnsureArgIsSQLDB(args, index)
+}
+
+func MakeSQLTx(tx *sqlTx) SQLTx {
+	res := SQLTx{tx, 0}
+	res.hash = HashPtr(uintptr(unsafe.Pointer(tx)))
+	return res
+}
+
+func (tx SQLTx) ToString(escape bool) string {
+	return "#object[SQLTx]"
+}
+
+func (tx SQLTx) Equals(other interface{}) bool {
+	if otherTx, ok := other.(SQLTx); ok {
+		return tx.sqlTx == otherTx.sqlTx
+	}
+	return false
+}
+
+func (tx SQLTx) GetInfo() *ObjectInfo {
+	return nil
+}
+
+func (tx SQLTx) GetType() *Type {
+	return sqlTxType
+}
+
+func (tx SQLTx) Hash() uint32 {
+	return tx.hash
+}
+
+func (tx SQLTx) WithInfo(info *ObjectInfo) Object {
+	return tx
+}
+
+func (tx SQLTx) conn() conn {
+	return tx.Tx
+}
+
+func (tx SQLTx) driverName() string {
+	return tx.driver
+}
+
+func EnsureArgIsSQLConn(args []Object, index int) SQLConn {
+	obj := args[index]
+	if c, yes := obj.(SQLConn); yes {
+		return c
+	}
+	panic(FailArg(obj, "SQLDB or SQLTx", index))
+}
+
+func ExtractSQLConn(args []Object, index int) SQLConn {
+	return EnsureArgIsSQLConn(args, index)
+}
+
+func MakeSQLStmt(stmt *sqlStmt) SQLStmt {
+	res := SQLStmt{stmt, 0}
+	res.hash = HashPtr(uintptr(unsafe.Pointer(stmt)))
+	return res
+}
+
+func (stmt SQLStmt) ToString(escape bool) string {
+	return "#object[SQLStmt]"
+}
+
+func (stmt SQLStmt) Equals(other interface{}) bool {
+	if otherStmt, ok := other.(SQLStmt); ok {
+		return stmt.sqlStmt == otherStmt.sqlStmt
+	}
+	return false
+}
+
+func (stmt SQLStmt) GetInfo() *ObjectInfo {
+	return nil
+}
+
+func (stmt SQLStmt) GetType() *Type {
+	return sqlStmtType
+}
+
+func (stmt SQLStmt) Hash() uint32 {
+	return stmt.hash
+}
+
+func (stmt SQLStmt) WithInfo(info *ObjectInfo) Object {
+	return stmt
+}
+
+func EnsureArgIsSQLStmt(args []Object, index int) SQLStmt {
+	obj := args[index]
+	if c, yes := obj.(SQLStmt); yes {
+		return c
+	}
+	panic(FailArg(obj, "SQLStmt", index))
+}
+
+func ExtractSQLStmt(args []Object, index int) SQLStmt {
+	return EnsureArgIsSQLStmt(args, index)
+}
+
+// toSQLValue converts a Joker value to a query parameter.
+func toSQLValue(obj Object) interface{} {
+	switch obj := obj.(type) {
+	case Nil:
+		return nil
+	case Int:
+		return int64(obj.I)
+	case Double:
+		return obj.D
+	case Boolean:
+		return obj.B
+	case String:
+		return obj.S
+	case Char:
+		return string(obj.Ch)
+	case Keyword:
+		return obj.ToString(false)[1:]
+	case Time:
+		return obj.T
+	case *BigInt:
+		return obj.BigInt().String()
+	case *BigFloat:
+		return obj.BigFloat().Text('f', -1)
+	default:
+		panic(RT.NewError("Cannot use " + obj.GetType().ToString(false) + " as SQL parameter"))
+	}
+}
+
+// fromSQLValue converts a value scanned from a result row to a Joker value.
+// dbType is the database type of the column, used for drivers that
+// return numbers as text (e.g. DECIMAL columns).
+func fromSQLValue(v interface{}, dbType string) Object {
+	switch v := v.(type) {
+	case nil:
+		return NIL
+	case int64:
+		return MakeInt(int(v))
+	case uint64:
+		if v > math.MaxInt64 {
+			return MakeBigInt(new(big.Int).SetUint64(v))
+		}
+		return MakeInt(int(v))
+	case float32:
+		return MakeDouble(float64(v))
+	case float64:
+		return MakeDouble(v)
+	case bool:
+		return MakeBoolean(v)
+	case []byte:
+		return fromSQLText(string(v), dbType)
+	case string:
+		return fromSQLText(v, dbType)
+	case time.Time:
+		return MakeTime(v)
+	default:
+		panic(RT.NewError(fmt.Sprintf("Unsupported SQL value type: %T", v)))
+	}
+}
+
+func fromSQLText(s string, dbType string) Object {
+	switch strings.ToUpper(dbType) {
+	case "DECIMAL", "NUMERIC":
+		if res, ok := MakeBigFloatWithOrig(s, ""); ok {
+			return res
+		}
+	case "TINYINT", "SMALLINT", "MEDIUMINT", "INT", "INTEGER", "BIGINT", "YEAR",
+		"UNSIGNED TINYINT", "UNSIGNED SMALLINT", "UNSIGNED MEDIUMINT", "UNSIGNED INT", "UNSIGNED BIGINT":
+		if n, err := strconv.ParseInt(s, 10, 0); err == nil {
+			return MakeInt(int(n))
+		}
+		if b, ok := new(big.Int).SetString(s, 10); ok {
+			return MakeBigInt(b)
+		}
+	case "FLOAT", "DOUBLE", "REAL":
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return MakeDouble(f)
+		}
+	}
+	return MakeString(s)
+}
+
+func toSQLArgs(params Seqable) []interface{} {
+	var res []interface{}
+	for s := params.Seq(); !s.IsEmpty(); s = s.Rest() {
+		res = append(res, toSQLValue(s.First()))
+	}
+	return res
+}
+
+func open(driver, dsn string, opts Map) *sqlDB {
+	db, err := sql.Open(driver, configureDSN(driver, dsn, opts))
+	PanicOnErr(err)
+	configurePool(db, opts)
+	gs := RT.GIL.Unlock()
+	err = db.Ping()
+	RT.GIL.Lock(gs)
+	if err != nil {
+		db.Close()
+		PanicOnErr(err)
+	}
+	return &sqlDB{db, driver}
+}
+
+func closeDB(db SQLDB) Nil {
+	PanicOnErr(db.Close())
+	return NIL
+}
+
+// scanRows reads all rows into memory. Must be called with the GIL unlocked.
+func scanRows(rows *sql.Rows) ([]*sql.ColumnType, [][]interface{}, error) {
+	defer rows.Close()
+	cols, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, nil, err
+	}
+	var res [][]interface{}
+	for rows.Next() {
+		vals := make([]interface{}, len(cols))
+		ptrs := make([]interface{}, len(cols))
+		for i := range vals {
+			ptrs[i] = &vals[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, nil, err
+		}
+		res = append(res, vals)
+	}
+	return cols, res, rows.Err()
+}
+
+func rowsToVector(cols []*sql.ColumnType, rows [][]interface{}) *Vector {
+	keys := make([]Keyword, len(cols))
+	for i, col := range cols {
+		keys[i] = MakeKeyword(col.Name())
+	}
+	res := EmptyVector()
+	for _, row := range rows {
+		m := EmptyArrayMap()
+		for i, v := range row {
+			m.Add(keys[i], fromSQLValue(v, cols[i].DatabaseTypeName()))
+		}
+		res = res.Conjoin(m)
+	}
+	return res
+}
+
+func resultToMap(r sql.Result) Map {
+	res := EmptyArrayMap()
+	if n, err := r.RowsAffected(); err == nil {
+		res.Add(MakeKeyword("rows-affected"), MakeInt(int(n)))
+	}
+	if id, err := r.LastInsertId(); err == nil {
+		res.Add(MakeKeyword("last-insert-id"), MakeInt(int(id)))
+	}
+	return res
+}
+
+// withTimeout returns a context that expires after the :timeout
+// (in nanoseconds) of opts, if any.
+func withTimeout(opts Map) (context.Context, context.CancelFunc) {
+	if n, ok := optInt(opts, "timeout"); ok {
+		return context.WithTimeout(context.Background(), time.Duration(n))
+	}
+	return context.Background(), func() {}
+}
+
+func runQuery(opts Map, q func(ctx context.Context) (*sql.Rows, error)) *Vector {
+	ctx, cancel := withTimeout(opts)
+	defer cancel()
+	gs := RT.GIL.Unlock()
+	rows, err := q(ctx)
+	var cols []*sql.ColumnType
+	var data [][]interface{}
+	if err == nil {
+		cols, data, err = scanRows(rows)
+	}
+	RT.GIL.Lock(gs)
+	PanicOnErr(err)
+	return rowsToVector(cols, data)
+}
+
+func runExecute(opts Map, e func(ctx context.Context) (sql.Result, error)) Map {
+	ctx, cancel := withTimeout(opts)
+	defer cancel()
+	gs := RT.GIL.Unlock()
+	res, err := e(ctx)
+	RT.GIL.Lock(gs)
+	PanicOnErr(err)
+	return resultToMap(res)
+}
+
+func query(c SQLConn, q string, params Seqable, opts Map) *Vector {
+	q, args := bindParams(q, c.driverName(), params)
+	return runQuery(opts, func(ctx context.Context) (*sql.Rows, error) {
+		return c.conn().QueryContext(ctx, q, args...)
+	})
+}
+
+func execute(c SQLConn, q string, params Seqable, opts Map) Map {
+	q, args := bindParams(q, c.driverName(), params)
+	return runExecute(opts, func(ctx context.Context) (sql.Result, error) {
+		return c.conn().ExecContext(ctx, q, args...)
+	})
+}
+
+func prepareStmt(c conn, q string, driver string) (*sqlStmt, error) {
+	q, names := bindNamed(q, driver)
+	stmt, err := c.PrepareContext(context.Background(), q)
+	if err != nil {
+		return nil, err
+	}
+	return &sqlStmt{stmt, names}, nil
+}
+
+func prepare(c SQLConn, q string) *sqlStmt {
+	gs := RT.GIL.Unlock()
+	stmt, err := prepareStmt(c.conn(), q, c.driverName())
+	RT.GIL.Lock(gs)
+	PanicOnErr(err)
+	return stmt
+}
+
+func (stmt *sqlStmt) args(params Seqable) []interface{} {
+	if m, ok := params.(Map); ok && m != NIL {
+		if len(stmt.names) == 0 {
+			panic(RT.NewError("Statement has no named parameters"))
+		}
+		return namedArgs(m, stmt.names)
+	}
+	if len(stmt.names) > 0 {
+		panic(RT.NewError("Statement has named parameters, a map of values expected"))
+	}
+	return toSQLArgs(params)
+}
+
+func queryPrepared(stmt SQLStmt, params Seqable, opts Map) *Vector {
+	args := stmt.args(params)
+	return runQuery(opts, func(ctx context.Context) (*sql.Rows, error) {
+		return stmt.QueryContext(ctx, args...)
+	})
+}
+
+func executePrepared(stmt SQLStmt, params Seqable, opts Map) Map {
+	args := stmt.args(params)
+	return runExecute(opts, func(ctx context.Context) (sql.Result, error) {
+		return stmt.ExecContext(ctx, args...)
+	})
+}
+
+func closePrepared(stmt SQLStmt) Nil {
+	PanicOnErr(stmt.Close())
+	return NIL
+}
+
+var isolationLevels = map[string]sql.IsolationLevel{
+	":default":          sql.LevelDefault,
+	":read-uncommitted": sql.LevelReadUncommitted,
+	":read-committed":   sql.LevelReadCommitted,
+	":write-committed":  sql.LevelWriteCommitted,
+	":repeatable-read":  sql.LevelRepeatableRead,
+	":snapshot":         sql.LevelSnapshot,
+	":serializable":     sql.LevelSerializable,
+	":linearizable":     sql.LevelLinearizable,
+}
+
+func txOptions(opts Map) *sql.TxOptions {
+	res := &sql.TxOptions{ReadOnly: optBool(opts, "read-only")}
+	if ok, v := opts.Get(MakeKeyword("isolation")); ok {
+		level, found := isolationLevels[EnsureObjectIsKeyword(v, "isolation: %s").ToString(false)]
+		if !found {
+			panic(RT.NewError("Unknown isolation level: " + v.ToString(false)))
+		}
+		res.Isolation = level
+	}
+	return res
+}
+
+// inTransaction calls f with a new transaction of db, committing it if f
+// returns normally and rolling it back if f panics.
+func inTransaction(db SQLDB, opts Map, f func(tx *sqlTx) Object) (res Object) {
+	txOpts := txOptions(opts)
+	gs := RT.GIL.Unlock()
+	tx, err := db.BeginTx(context.Background(), txOpts)
+	RT.GIL.Lock(gs)
+	PanicOnErr(err)
+	committed := false
+	defer func() {
+		if !committed {
+			gs := RT.GIL.Unlock()
+			tx.Rollback()
+			RT.GIL.Lock(gs)
+		}
+	}()
+	res = f(&sqlTx{tx, db.driver})
+	gs = RT.GIL.Unlock()
+	err = tx.Commit()
+	RT.GIL.Lock(gs)
+	committed = true
+	PanicOnErr(err)
+	return res
+}
+
+func withTransaction(db SQLDB, opts Map, f Callable) Object {
+	return inTransaction(db, opts, func(tx *sqlTx) Object {
+		return f.Call([]Object{MakeSQLTx(tx)})
+	})
+}
+
+func executeBatch(c SQLConn, q string, batch Seqable, opts Map) Map {
+	if db, ok := c.(SQLDB); ok {
+		return inTransaction(db, EmptyArrayMap(), func(tx *sqlTx) Object {
+			return executeBatch(MakeSQLTx(tx), q, batch, opts)
+		}).(Map)
+	}
+	stmt := MakeSQLStmt(prepare(c, q))
+	defer stmt.Close()
+	total := 0
+	for s := batch.Seq(); !s.IsEmpty(); s = s.Rest() {
+		params := EnsureObjectIsSeqable(s.First(), "params: %s")
+		res := executePrepared(stmt, params, opts)
+		if ok, n := res.Get(MakeKeyword("rows-affected")); ok {
+			total += n.(Int).I
+		}
+	}
+	res := EmptyArrayMap()
+	res.Add(MakeKeyword("rows-affected"), MakeInt(total))
+	return res
+}
+
+func init() {
+	sqlDBType = RegType("SQLDB", (*SQLDB)(nil), "Wraps a database/sql connection pool")
+	sqlTxType = RegType("SQLTx", (*SQLTx)(nil), "Wraps a database/sql transaction")
+	sqlStmtType = RegType("SQLStmt", (*SQLStmt)(nil), "Wraps a prepared SQL statement")
+}
@@ -131,6 +131,29 @@ func __itoa_(_args []Object) Object {
 	return NIL
 }
 
+var __parse_bigint__P ProcFn = __parse_bigint_
+var parse_bigint_ Proc = Proc{Fn: __parse_bigint__P, Name: "parse_bigint_", Package: "std/strconv"}
+
+func __parse_bigint_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		s := ExtractString(_args, 0)
+		_res := parseBigInt(s, 10)
+		return MakeBigInt(_res)
+
+	case _c == 2:
+		s := ExtractString(_args, 0)
+		radix := ExtractInt(_args, 1)
+		_res := parseBigInt(s, radix)
+		return MakeBigInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __parse_bool__P ProcFn = __parse_bool_
 var parse_bool_ Proc = Proc{Fn: __parse_bool__P, Name: "parse_bool_", Package: "std/strconv"}
 
@@ -188,6 +211,29 @@ func __parse_int_(_args []Object) Object {
 	return NIL
 }
 
+var __parse_long__P ProcFn = __parse_long_
+var parse_long_ Proc = Proc{Fn: __parse_long__P, Name: "parse_long_", Package: "std/strconv"}
+
+func __parse_long_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		s := ExtractString(_args, 0)
+		_res := parseLong(s, 10)
+		return MakeInt(_res)
+
+	case _c == 2:
+		s := ExtractString(_args, 0)
+		radix := ExtractInt(_args, 1)
+		_res := parseLong(s, radix)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __isprintable__P ProcFn = __isprintable_
 var isprintable_ Proc = Proc{Fn: __isprintable__P, Name: "isprintable_", Package: "std/strconv"}
 
@@ -14,105 +14,157 @@ func InternsOrThunks() {
 	}
 	strconvNamespace.ResetMeta(MakeMeta(nil, `Implements conversions to and from string representations of basic data types.`, "1.0"))
 
-	strconvNamespace.InternVar("atoi", atoi_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Equivalent to (parse-int s 10 0).`, "1.0").Plus(MakeKeyword("tag"), String{S: "Int"}))
-
-	strconvNamespace.InternVar("can-backquote?", iscan_backquote_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Reports whether the string s can be represented unchanged as a single-line backquoted string without control characters other than tab.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Boolean"}))
-
-	strconvNamespace.InternVar("format-bool", format_bool_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("b"))),
-			`Returns "true" or "false" according to the value of b.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	strconvNamespace.InternVar("format-double", format_double_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("f"), MakeSymbol("fmt"), MakeSymbol("prec"), MakeSymbol("bitSize"))),
-			`Converts the floating-point number f to a string, according to the format fmt and precision prec. It rounds the result assuming that the original was obtained from a floating-point value of bitSize bits (32 for float32, 64 for float64).
+	strconvNamespace.InternVarPacked("atoi", atoi_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Equivalent to (parse-int s 10 0).`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+	strconvNamespace.InternVarPacked("can-backquote?", iscan_backquote_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Reports whether the string s can be represented unchanged as a single-line backquoted string without control characters other than tab.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Boolean\"}",
+	})
+
+	strconvNamespace.InternVarPacked("format-bool", format_bool_, &VarMeta{
+		Arglists: "([b])",
+		Doc:      `Returns "true" or "false" according to the value of b.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	strconvNamespace.InternVarPacked("format-double", format_double_, &VarMeta{
+		Arglists: "([f fmt prec bitSize])",
+		Doc: `Converts the floating-point number f to a string, according to the format fmt and precision prec. It rounds the result assuming that the original was obtained from a floating-point value of bitSize bits (32 for float32, 64 for float64).
   The format fmt is one of 'b' (-ddddp±ddd, a binary exponent), 'e' (-d.dddde±dd, a decimal exponent), 'E' (-d.ddddE±dd, a decimal exponent), 'f' (-ddd.dddd, no exponent), 'g' ('e' for large exponents, 'f' otherwise), or 'G' ('E' for large exponents, 'f' otherwise).
-  The precision prec controls the number of digits (excluding the exponent) printed by the 'e', 'E', 'f', 'g', and 'G' formats. For 'e', 'E', and 'f' it is the number of digits after the decimal point. For 'g' and 'G' it is the maximum number of significant digits (trailing zeros are removed). The special precision -1 uses the smallest number of digits necessary such that ParseFloat will return f exactly.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	strconvNamespace.InternVar("format-int", format_int_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("i"), MakeSymbol("base"))),
-			`Returns the string representation of i in the given base, for 2 <= base <= 36. The result uses the lower-case letters 'a' to 'z' for digit values >= 10.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	strconvNamespace.InternVar("graphic?", isgraphic_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("c"))),
-			`Reports whether the char is defined as a Graphic by Unicode. Such characters include letters, marks, numbers, punctuation, symbols, and spaces, from categories L, M, N, P, S, and Zs.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Boolean"}))
-
-	strconvNamespace.InternVar("itoa", itoa_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("i"))),
-			`Equivalent to (format-int i 10).`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	strconvNamespace.InternVar("parse-bool", parse_bool_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Returns the boolean value represented by the string. It accepts 1, t, T, TRUE, true, True, 0, f, F, FALSE, false, False. Any other value returns an error.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Boolean"}))
-
-	strconvNamespace.InternVar("parse-double", parse_double_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Converts the string s to a floating-point number.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	strconvNamespace.InternVar("parse-int", parse_int_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"), MakeSymbol("base"), MakeSymbol("bitSize"))),
-			`Interprets a string s in the given base (0, 2 to 36) and bit size (0 to 64) and returns the corresponding value i.
+  The precision prec controls the number of digits (excluding the exponent) printed by the 'e', 'E', 'f', 'g', and 'G' formats. For 'e', 'E', and 'f' it is the number of digits after the decimal point. For 'g' and 'G' it is the maximum number of significant digits (trailing zeros are removed). The special precision -1 uses the smallest number of digits necessary such that ParseFloat will return f exactly.`,
+		Added: "1.0",
+		Extra: "{:tag \"String\"}",
+	})
+
+	strconvNamespace.InternVarPacked("format-int", format_int_, &VarMeta{
+		Arglists: "([i base])",
+		Doc:      `Returns the string representation of i in the given base, for 2 <= base <= 36. The result uses the lower-case letters 'a' to 'z' for digit values >= 10.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	strconvNamespace.InternVarPacked("graphic?", isgraphic_, &VarMeta{
+		Arglists: "([c])",
+		Doc:      `Reports whether the char is defined as a Graphic by Unicode. Such characters include letters, marks, numbers, punctuation, symbols, and spaces, from categories L, M, N, P, S, and Zs.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Boolean\"}",
+	})
+
+	strconvNamespace.InternVarPacked("itoa", itoa_, &VarMeta{
+		Arglists: "([i])",
+		Doc:      `Equivalent to (format-int i 10).`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	strconvNamespace.InternVarPacked("parse-bigint", parse_bigint_, &VarMeta{
+		Arglists: "([s] [s radix])",
+		Doc: `Like joker.core/parse-bigint, but throws an exception instead of returning nil
+  if s is not a valid integer in the given radix (10 by default).`,
+		Added: "1.2",
+		Extra: "{:tag \"BigInt\"}",
+	})
+
+	strconvNamespace.InternVarPacked("parse-bool", parse_bool_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Returns the boolean value represented by the string. It accepts 1, t, T, TRUE, true, True, 0, f, F, FALSE, false, False. Any other value returns an error.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Boolean\"}",
+	})
+
+	strconvNamespace.InternVarPacked("parse-double", parse_double_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Converts the string s to a floating-point number.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	strconvNamespace.InternVarPacked("parse-int", parse_int_, &VarMeta{
+		Arglists: "([s base bitSize])",
+		Doc: `Interprets a string s in the given base (0, 2 to 36) and bit size (0 to 64) and returns the corresponding value i.
   If base == 0, the base is implied by the string's prefix: base 16 for "0x", base 8 for "0", and base 10 otherwise. For bases 1, below 0 or above 36 an error is returned.
-  The bitSize argument specifies the integer type that the result must fit into. Bit sizes 0, 8, 16, 32, and 64 correspond to int, int8, int16, int32, and int64. For a bitSize below 0 or above 64 an error is returned.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Int"}))
-
-	strconvNamespace.InternVar("printable?", isprintable_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("c"))),
-			`Reports whether the char is defined as printable by Joker: letters, numbers, punctuation, symbols and ASCII space.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Boolean"}))
-
-	strconvNamespace.InternVar("quote", quote_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Returns a double-quoted string literal representing s. The returned string uses escape sequences (\t, \n, \xFF, \u0100)
-  for control characters and non-printable characters as defined by printable?.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	strconvNamespace.InternVar("quote-char", quote_char_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("c"))),
-			`Returns a single-quoted char literal representing the character. The returned string uses escape sequences (\t, \n, \xFF, \u0100)
-  for control characters and non-printable characters as defined by printable?.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	strconvNamespace.InternVar("quote-char-to-ascii", quote_char_to_ascii_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("c"))),
-			`Returns a single-quoted char literal representing the character. The returned string uses escape sequences (\t, \n, \xFF, \u0100)
-  for non-ASCII characters and non-printable characters as defined by printable?.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	strconvNamespace.InternVar("quote-char-to-graphic", quote_char_to_graphic_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("c"))),
-			`Returns a single-quoted char literal representing the character. The returned string uses escape sequences (\t, \n, \xFF, \u0100)
-  for non-ASCII characters and non-printable characters as defined by graphic?.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	strconvNamespace.InternVar("quote-to-ascii", quote_to_ascii_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Returns a double-quoted string literal representing s. The returned string uses escape sequences (\t, \n, \xFF, \u0100)
-  for non-ASCII characters and non-printable characters as defined by printable?.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	strconvNamespace.InternVar("quote-to-graphic", quote_to_graphic_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Returns a double-quoted string literal representing s. The returned string uses escape sequences (\t, \n, \xFF, \u0100)
-  for non-ASCII characters and non-printable characters as defined by graphic?.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	strconvNamespace.InternVar("unquote", unquote_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Interprets s as a single-quoted, double-quoted, or backquoted string literal, returning the string value that s quotes.
-  (If s is single-quoted, it would be a Go character literal; Unquote returns the corresponding one-character string.)`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
+  The bitSize argument specifies the integer type that the result must fit into. Bit sizes 0, 8, 16, 32, and 64 correspond to int, int8, int16, int32, and int64. For a bitSize below 0 or above 64 an error is returned.`,
+		Added: "1.0",
+		Extra: "{:tag \"Int\"}",
+	})
+
+	strconvNamespace.InternVarPacked("parse-long", parse_long_, &VarMeta{
+		Arglists: "([s] [s radix])",
+		Doc: `Like joker.core/parse-long, but throws an exception instead of returning nil
+  if s is not a valid integer in the given radix (10 by default) or is out of range.`,
+		Added: "1.2",
+		Extra: "{:tag \"Int\"}",
+	})
+
+	strconvNamespace.InternVarPacked("printable?", isprintable_, &VarMeta{
+		Arglists: "([c])",
+		Doc:      `Reports whether the char is defined as printable by Joker: letters, numbers, punctuation, symbols and ASCII space.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Boolean\"}",
+	})
+
+	strconvNamespace.InternVarPacked("quote", quote_, &VarMeta{
+		Arglists: "([s])",
+		Doc: `Returns a double-quoted string literal representing s. The returned string uses escape sequences (\t, \n, \xFF, \u0100)
+  for control characters and non-printable characters as defined by printable?.`,
+		Added: "1.0",
+		Extra: "{:tag \"String\"}",
+	})
+
+	strconvNamespace.InternVarPacked("quote-char", quote_char_, &VarMeta{
+		Arglists: "([c])",
+		Doc: `Returns a single-quoted char literal representing the character. The returned string uses escape sequences (\t, \n, \xFF, \u0100)
+  for control characters and non-printable characters as defined by printable?.`,
+		Added: "1.0",
+		Extra: "{:tag \"String\"}",
+	})
+
+	strconvNamespace.InternVarPacked("quote-char-to-ascii", quote_char_to_ascii_, &VarMeta{
+		Arglists: "([c])",
+		Doc: `Returns a single-quoted char literal representing the character. The returned string uses escape sequences (\t, \n, \xFF, \u0100)
+  for non-ASCII characters and non-printable characters as defined by printable?.`,
+		Added: "1.0",
+		Extra: "{:tag \"String\"}",
+	})
+
+	strconvNamespace.InternVarPacked("quote-char-to-graphic", quote_char_to_graphic_, &VarMeta{
+		Arglists: "([c])",
+		Doc: `Returns a single-quoted char literal representing the character. The returned string uses escape sequences (\t, \n, \xFF, \u0100)
+  for non-ASCII characters and non-printable characters as defined by graphic?.`,
+		Added: "1.0",
+		Extra: "{:tag \"String\"}",
+	})
+
+	strconvNamespace.InternVarPacked("quote-to-ascii", quote_to_ascii_, &VarMeta{
+		Arglists: "([s])",
+		Doc: `Returns a double-quoted string literal representing s. The returned string uses escape sequences (\t, \n, \xFF, \u0100)
+  for non-ASCII characters and non-printable characters as defined by printable?.`,
+		Added: "1.0",
+		Extra: "{:tag \"String\"}",
+	})
+
+	strconvNamespace.InternVarPacked("quote-to-graphic", quote_to_graphic_, &VarMeta{
+		Arglists: "([s])",
+		Doc: `Returns a double-quoted string literal representing s. The returned string uses escape sequences (\t, \n, \xFF, \u0100)
+  for non-ASCII characters and non-printable characters as defined by graphic?.`,
+		Added: "1.0",
+		Extra: "{:tag \"String\"}",
+	})
+
+	strconvNamespace.InternVarPacked("unquote", unquote_, &VarMeta{
+		Arglists: "([s])",
+		Doc: `Interprets s as a single-quoted, double-quoted, or backquoted string literal, returning the string value that s quotes.
+  (If s is single-quoted, it would be a Go character literal; Unquote returns the corresponding one-character string.)`,
+		Added: "1.0",
+		Extra: "{:tag \"String\"}",
+	})
 
 }
@@ -0,0 +1,25 @@
+package strconv
+
+import (
+	"fmt"
+	"math/big"
+
+	. "github.com/candid82/joker/core"
+)
+
+func parseLong(s string, radix int) int {
+	if i, ok := ParseIntStrict(s, radix); ok {
+		return i
+	}
+	if ParseBigIntStrict(s, radix) != nil {
+		panic(RT.NewError(fmt.Sprintf("Value out of range: %s", s)))
+	}
+	panic(RT.NewError(fmt.Sprintf("Invalid integer in radix %d: %s", radix, s)))
+}
+
+func parseBigInt(s string, radix int) *big.Int {
+	if b := ParseBigIntStrict(s, radix); b != nil {
+		return b
+	}
+	panic(RT.NewError(fmt.Sprintf("Invalid integer in radix %d: %s", radix, s)))
+}
@@ -9,6 +9,33 @@ import (
 	"unicode"
 )
 
+var __align__P ProcFn = __align_
+var align_ Proc = Proc{Fn: __align__P, Name: "align_", Package: "std/string"}
+
+func __align_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 3:
+		s := ExtractString(_args, 0)
+		n := ExtractInt(_args, 1)
+		alignment := ExtractKeyword(_args, 2)
+		_res := align(s, n, alignment, " ")
+		return MakeString(_res)
+
+	case _c == 4:
+		s := ExtractString(_args, 0)
+		n := ExtractInt(_args, 1)
+		alignment := ExtractKeyword(_args, 2)
+		pad := ExtractStringable(_args, 3)
+		_res := align(s, n, alignment, pad)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __isblank__P ProcFn = __isblank_
 var isblank_ Proc = Proc{Fn: __isblank__P, Name: "isblank_", Package: "std/string"}
 
@@ -26,6 +53,23 @@ func __isblank_(_args []Object) Object {
 	return NIL
 }
 
+var __camel_case__P ProcFn = __camel_case_
+var camel_case_ Proc = Proc{Fn: __camel_case__P, Name: "camel_case_", Package: "std/string"}
+
+func __camel_case_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		s := ExtractObject(_args, 0)
+		_res := convertCase(s, camelCase)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __capitalize__P ProcFn = __capitalize_
 var capitalize_ Proc = Proc{Fn: __capitalize__P, Name: "capitalize_", Package: "std/string"}
 
@@ -43,6 +87,98 @@ func __capitalize_(_args []Object) Object {
 	return NIL
 }
 
+var __category__P ProcFn = __category_
+var category_ Proc = Proc{Fn: __category__P, Name: "category_", Package: "std/string"}
+
+func __category_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		c := ExtractChar(_args, 0)
+		_res := category(c)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __columnize__P ProcFn = __columnize_
+var columnize_ Proc = Proc{Fn: __columnize__P, Name: "columnize_", Package: "std/string"}
+
+func __columnize_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		rows := ExtractSeqable(_args, 0)
+		_res := columnize(rows, EmptyArrayMap())
+		return MakeString(_res)
+
+	case _c == 2:
+		rows := ExtractSeqable(_args, 0)
+		opts := ExtractMap(_args, 1)
+		_res := columnize(rows, opts)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __damerau_levenshtein__P ProcFn = __damerau_levenshtein_
+var damerau_levenshtein_ Proc = Proc{Fn: __damerau_levenshtein__P, Name: "damerau_levenshtein_", Package: "std/string"}
+
+func __damerau_levenshtein_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		s1 := ExtractString(_args, 0)
+		s2 := ExtractString(_args, 1)
+		_res := damerauLevenshtein(s1, s2)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __dedent__P ProcFn = __dedent_
+var dedent_ Proc = Proc{Fn: __dedent__P, Name: "dedent_", Package: "std/string"}
+
+func __dedent_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		s := ExtractString(_args, 0)
+		_res := dedent(s)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __isdigit__P ProcFn = __isdigit_
+var isdigit_ Proc = Proc{Fn: __isdigit__P, Name: "isdigit_", Package: "std/string"}
+
+func __isdigit_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		c := ExtractChar(_args, 0)
+		_res := unicode.IsDigit(c)
+		return MakeBoolean(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __isends_with__P ProcFn = __isends_with_
 var isends_with_ Proc = Proc{Fn: __isends_with__P, Name: "isends_with_", Package: "std/string"}
 
@@ -61,6 +197,24 @@ func __isends_with_(_args []Object) Object {
 	return NIL
 }
 
+var __isequal_fold__P ProcFn = __isequal_fold_
+var isequal_fold_ Proc = Proc{Fn: __isequal_fold__P, Name: "isequal_fold_", Package: "std/string"}
+
+func __isequal_fold_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		s1 := ExtractString(_args, 0)
+		s2 := ExtractString(_args, 1)
+		_res := strings.EqualFold(s1, s2)
+		return MakeBoolean(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __escape__P ProcFn = __escape_
 var escape_ Proc = Proc{Fn: __escape__P, Name: "escape_", Package: "std/string"}
 
@@ -79,6 +233,41 @@ func __escape_(_args []Object) Object {
 	return NIL
 }
 
+var __fold_case__P ProcFn = __fold_case_
+var fold_case_ Proc = Proc{Fn: __fold_case__P, Name: "fold_case_", Package: "std/string"}
+
+func __fold_case_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		s := ExtractString(_args, 0)
+		_res := foldCase(s)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __fuzzy_match__P ProcFn = __fuzzy_match_
+var fuzzy_match_ Proc = Proc{Fn: __fuzzy_match__P, Name: "fuzzy_match_", Package: "std/string"}
+
+func __fuzzy_match_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		pattern := ExtractString(_args, 0)
+		s := ExtractString(_args, 1)
+		_res := fuzzyMatch(pattern, s)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __isincludes__P ProcFn = __isincludes_
 var isincludes_ Proc = Proc{Fn: __isincludes__P, Name: "isincludes_", Package: "std/string"}
 
@@ -97,6 +286,24 @@ func __isincludes_(_args []Object) Object {
 	return NIL
 }
 
+var __indent__P ProcFn = __indent_
+var indent_ Proc = Proc{Fn: __indent__P, Name: "indent_", Package: "std/string"}
+
+func __indent_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		s := ExtractString(_args, 0)
+		prefix := ExtractObject(_args, 1)
+		_res := indent(s, prefix)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __index_of__P ProcFn = __index_of_
 var index_of_ Proc = Proc{Fn: __index_of__P, Name: "index_of_", Package: "std/string"}
 
@@ -122,6 +329,24 @@ func __index_of_(_args []Object) Object {
 	return NIL
 }
 
+var __jaro_winkler__P ProcFn = __jaro_winkler_
+var jaro_winkler_ Proc = Proc{Fn: __jaro_winkler__P, Name: "jaro_winkler_", Package: "std/string"}
+
+func __jaro_winkler_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		s1 := ExtractString(_args, 0)
+		s2 := ExtractString(_args, 1)
+		_res := jaroWinkler(s1, s2)
+		return MakeDouble(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __join__P ProcFn = __join_
 var join_ Proc = Proc{Fn: __join__P, Name: "join_", Package: "std/string"}
 
@@ -145,6 +370,23 @@ func __join_(_args []Object) Object {
 	return NIL
 }
 
+var __kebab_case__P ProcFn = __kebab_case_
+var kebab_case_ Proc = Proc{Fn: __kebab_case__P, Name: "kebab_case_", Package: "std/string"}
+
+func __kebab_case_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		s := ExtractObject(_args, 0)
+		_res := convertCase(s, kebabCase)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __last_index_of__P ProcFn = __last_index_of_
 var last_index_of_ Proc = Proc{Fn: __last_index_of__P, Name: "last_index_of_", Package: "std/string"}
 
@@ -170,6 +412,41 @@ func __last_index_of_(_args []Object) Object {
 	return NIL
 }
 
+var __isletter__P ProcFn = __isletter_
+var isletter_ Proc = Proc{Fn: __isletter__P, Name: "isletter_", Package: "std/string"}
+
+func __isletter_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		c := ExtractChar(_args, 0)
+		_res := unicode.IsLetter(c)
+		return MakeBoolean(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __levenshtein__P ProcFn = __levenshtein_
+var levenshtein_ Proc = Proc{Fn: __levenshtein__P, Name: "levenshtein_", Package: "std/string"}
+
+func __levenshtein_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		s1 := ExtractString(_args, 0)
+		s2 := ExtractString(_args, 1)
+		_res := levenshtein(s1, s2)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __lower_case__P ProcFn = __lower_case_
 var lower_case_ Proc = Proc{Fn: __lower_case__P, Name: "lower_case_", Package: "std/string"}
 
@@ -187,6 +464,101 @@ func __lower_case_(_args []Object) Object {
 	return NIL
 }
 
+var __islower_case__P ProcFn = __islower_case_
+var islower_case_ Proc = Proc{Fn: __islower_case__P, Name: "islower_case_", Package: "std/string"}
+
+func __islower_case_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		c := ExtractChar(_args, 0)
+		_res := unicode.IsLower(c)
+		return MakeBoolean(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __ismark__P ProcFn = __ismark_
+var ismark_ Proc = Proc{Fn: __ismark__P, Name: "ismark_", Package: "std/string"}
+
+func __ismark_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		c := ExtractChar(_args, 0)
+		_res := unicode.IsMark(c)
+		return MakeBoolean(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __natural_compare__P ProcFn = __natural_compare_
+var natural_compare_ Proc = Proc{Fn: __natural_compare__P, Name: "natural_compare_", Package: "std/string"}
+
+func __natural_compare_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		a := ExtractString(_args, 0)
+		b := ExtractString(_args, 1)
+		_res := naturalCompare(a, b, EmptyArrayMap())
+		return MakeInt(_res)
+
+	case _c == 3:
+		a := ExtractString(_args, 0)
+		b := ExtractString(_args, 1)
+		opts := ExtractMap(_args, 2)
+		_res := naturalCompare(a, b, opts)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __normalize__P ProcFn = __normalize_
+var normalize_ Proc = Proc{Fn: __normalize__P, Name: "normalize_", Package: "std/string"}
+
+func __normalize_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		s := ExtractString(_args, 0)
+		form := ExtractKeyword(_args, 1)
+		_res := normalizationForm(form).String(s)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __isnormalized__P ProcFn = __isnormalized_
+var isnormalized_ Proc = Proc{Fn: __isnormalized__P, Name: "isnormalized_", Package: "std/string"}
+
+func __isnormalized_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		s := ExtractString(_args, 0)
+		form := ExtractKeyword(_args, 1)
+		_res := normalizationForm(form).IsNormalString(s)
+		return MakeBoolean(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __pad_left__P ProcFn = __pad_left_
 var pad_left_ Proc = Proc{Fn: __pad_left__P, Name: "pad_left_", Package: "std/string"}
 
@@ -225,6 +597,40 @@ func __pad_right_(_args []Object) Object {
 	return NIL
 }
 
+var __pascal_case__P ProcFn = __pascal_case_
+var pascal_case_ Proc = Proc{Fn: __pascal_case__P, Name: "pascal_case_", Package: "std/string"}
+
+func __pascal_case_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		s := ExtractObject(_args, 0)
+		_res := convertCase(s, pascalCase)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __ispunctuation__P ProcFn = __ispunctuation_
+var ispunctuation_ Proc = Proc{Fn: __ispunctuation__P, Name: "ispunctuation_", Package: "std/string"}
+
+func __ispunctuation_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		c := ExtractChar(_args, 0)
+		_res := unicode.IsPunct(c)
+		return MakeBoolean(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __re_quote__P ProcFn = __re_quote_
 var re_quote_ Proc = Proc{Fn: __re_quote__P, Name: "re_quote_", Package: "std/string"}
 
@@ -242,6 +648,23 @@ func __re_quote_(_args []Object) Object {
 	return NIL
 }
 
+var __re_quote_replacement__P ProcFn = __re_quote_replacement_
+var re_quote_replacement_ Proc = Proc{Fn: __re_quote_replacement__P, Name: "re_quote_replacement_", Package: "std/string"}
+
+func __re_quote_replacement_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		replacement := ExtractString(_args, 0)
+		_res := strings.ReplaceAll(replacement, "$", "$$")
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __replace__P ProcFn = __replace_
 var replace_ Proc = Proc{Fn: __replace__P, Name: "replace_", Package: "std/string"}
 
@@ -251,7 +674,7 @@ func __replace_(_args []Object) Object {
 	case _c == 3:
 		s := ExtractString(_args, 0)
 		match := ExtractObject(_args, 1)
-		repl := ExtractStringable(_args, 2)
+		repl := ExtractObject(_args, 2)
 		_res := replace(s, match, repl)
 		return MakeString(_res)
 
@@ -270,7 +693,7 @@ func __replace_first_(_args []Object) Object {
 	case _c == 3:
 		s := ExtractString(_args, 0)
 		match := ExtractObject(_args, 1)
-		repl := ExtractStringable(_args, 2)
+		repl := ExtractObject(_args, 2)
 		_res := replaceFirst(s, match, repl)
 		return MakeString(_res)
 
@@ -297,6 +720,63 @@ func __reverse_(_args []Object) Object {
 	return NIL
 }
 
+var __screaming_snake_case__P ProcFn = __screaming_snake_case_
+var screaming_snake_case_ Proc = Proc{Fn: __screaming_snake_case__P, Name: "screaming_snake_case_", Package: "std/string"}
+
+func __screaming_snake_case_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		s := ExtractObject(_args, 0)
+		_res := convertCase(s, screamingSnakeCase)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __slugify__P ProcFn = __slugify_
+var slugify_ Proc = Proc{Fn: __slugify__P, Name: "slugify_", Package: "std/string"}
+
+func __slugify_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		s := ExtractString(_args, 0)
+		_res := slugify(s, EmptyArrayMap())
+		return MakeString(_res)
+
+	case _c == 2:
+		s := ExtractString(_args, 0)
+		opts := ExtractMap(_args, 1)
+		_res := slugify(s, opts)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __snake_case__P ProcFn = __snake_case_
+var snake_case_ Proc = Proc{Fn: __snake_case__P, Name: "snake_case_", Package: "std/string"}
+
+func __snake_case_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		s := ExtractObject(_args, 0)
+		_res := convertCase(s, snakeCase)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __split__P ProcFn = __split_
 var split_ Proc = Proc{Fn: __split__P, Name: "split_", Package: "std/string"}
 
@@ -357,6 +837,47 @@ func __isstarts_with_(_args []Object) Object {
 	return NIL
 }
 
+var __transform_keys__P ProcFn = __transform_keys_
+var transform_keys_ Proc = Proc{Fn: __transform_keys__P, Name: "transform_keys_", Package: "std/string"}
+
+func __transform_keys_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		f := ExtractCallable(_args, 0)
+		m := ExtractObject(_args, 1)
+		_res := transformKeys(f, m)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __transliterate__P ProcFn = __transliterate_
+var transliterate_ Proc = Proc{Fn: __transliterate__P, Name: "transliterate_", Package: "std/string"}
+
+func __transliterate_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		s := ExtractString(_args, 0)
+		_res := transliterate(s, EmptyArrayMap())
+		return MakeString(_res)
+
+	case _c == 2:
+		s := ExtractString(_args, 0)
+		opts := ExtractMap(_args, 1)
+		_res := transliterate(s, opts)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 var __trim__P ProcFn = __trim_
 var trim_ Proc = Proc{Fn: __trim__P, Name: "trim_", Package: "std/string"}
 
@@ -476,6 +997,65 @@ func __upper_case_(_args []Object) Object {
 	return NIL
 }
 
+var __isupper_case__P ProcFn = __isupper_case_
+var isupper_case_ Proc = Proc{Fn: __isupper_case__P, Name: "isupper_case_", Package: "std/string"}
+
+func __isupper_case_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		c := ExtractChar(_args, 0)
+		_res := unicode.IsUpper(c)
+		return MakeBoolean(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __iswhitespace__P ProcFn = __iswhitespace_
+var iswhitespace_ Proc = Proc{Fn: __iswhitespace__P, Name: "iswhitespace_", Package: "std/string"}
+
+func __iswhitespace_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		c := ExtractChar(_args, 0)
+		_res := unicode.IsSpace(c)
+		return MakeBoolean(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __word_wrap__P ProcFn = __word_wrap_
+var word_wrap_ Proc = Proc{Fn: __word_wrap__P, Name: "word_wrap_", Package: "std/string"}
+
+func __word_wrap_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		s := ExtractString(_args, 0)
+		width := ExtractInt(_args, 1)
+		_res := wordWrap(s, width, EmptyArrayMap())
+		return MakeString(_res)
+
+	case _c == 3:
+		s := ExtractString(_args, 0)
+		width := ExtractInt(_args, 1)
+		opts := ExtractMap(_args, 2)
+		_res := wordWrap(s, width, opts)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 func Init() {
 
 	InternsOrThunks()
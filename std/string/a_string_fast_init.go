@@ -15,24 +15,52 @@ func InternsOrThunks() {
 	if VerbosityLevel > 0 {
 		fmt.Fprintln(os.Stderr, "Lazily running fast version of string.InternsOrThunks().")
 	}
+	STD_thunk_string_align__var = __align_
 	STD_thunk_string_isblank__var = __isblank_
+	STD_thunk_string_camel_case__var = __camel_case_
 	STD_thunk_string_capitalize__var = __capitalize_
+	STD_thunk_string_category__var = __category_
+	STD_thunk_string_columnize__var = __columnize_
+	STD_thunk_string_damerau_levenshtein__var = __damerau_levenshtein_
+	STD_thunk_string_dedent__var = __dedent_
+	STD_thunk_string_isdigit__var = __isdigit_
 	STD_thunk_string_isends_with__var = __isends_with_
+	STD_thunk_string_isequal_fold__var = __isequal_fold_
 	STD_thunk_string_escape__var = __escape_
+	STD_thunk_string_fold_case__var = __fold_case_
+	STD_thunk_string_fuzzy_match__var = __fuzzy_match_
 	STD_thunk_string_isincludes__var = __isincludes_
+	STD_thunk_string_indent__var = __indent_
 	STD_thunk_string_index_of__var = __index_of_
+	STD_thunk_string_jaro_winkler__var = __jaro_winkler_
 	STD_thunk_string_join__var = __join_
+	STD_thunk_string_kebab_case__var = __kebab_case_
 	STD_thunk_string_last_index_of__var = __last_index_of_
+	STD_thunk_string_isletter__var = __isletter_
+	STD_thunk_string_levenshtein__var = __levenshtein_
 	STD_thunk_string_lower_case__var = __lower_case_
+	STD_thunk_string_islower_case__var = __islower_case_
+	STD_thunk_string_ismark__var = __ismark_
+	STD_thunk_string_natural_compare__var = __natural_compare_
+	STD_thunk_string_normalize__var = __normalize_
+	STD_thunk_string_isnormalized__var = __isnormalized_
 	STD_thunk_string_pad_left__var = __pad_left_
 	STD_thunk_string_pad_right__var = __pad_right_
+	STD_thunk_string_pascal_case__var = __pascal_case_
+	STD_thunk_string_ispunctuation__var = __ispunctuation_
 	STD_thunk_string_re_quote__var = __re_quote_
+	STD_thunk_string_re_quote_replacement__var = __re_quote_replacement_
 	STD_thunk_string_replace__var = __replace_
 	STD_thunk_string_replace_first__var = __replace_first_
 	STD_thunk_string_reverse__var = __reverse_
+	STD_thunk_string_screaming_snake_case__var = __screaming_snake_case_
+	STD_thunk_string_slugify__var = __slugify_
+	STD_thunk_string_snake_case__var = __snake_case_
 	STD_thunk_string_split__var = __split_
 	STD_thunk_string_split_lines__var = __split_lines_
 	STD_thunk_string_isstarts_with__var = __isstarts_with_
+	STD_thunk_string_transform_keys__var = __transform_keys_
+	STD_thunk_string_transliterate__var = __transliterate_
 	STD_thunk_string_trim__var = __trim_
 	STD_thunk_string_trim_left__var = __trim_left_
 	STD_thunk_string_trim_newline__var = __trim_newline_
@@ -40,4 +68,7 @@ func InternsOrThunks() {
 	STD_thunk_string_triml__var = __triml_
 	STD_thunk_string_trimr__var = __trimr_
 	STD_thunk_string_upper_case__var = __upper_case_
+	STD_thunk_string_isupper_case__var = __isupper_case_
+	STD_thunk_string_iswhitespace__var = __iswhitespace_
+	STD_thunk_string_word_wrap__var = __word_wrap_
 }
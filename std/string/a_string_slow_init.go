@@ -17,150 +17,452 @@ func InternsOrThunks() {
 	}
 	stringNamespace.ResetMeta(MakeMeta(nil, `Implements simple functions to manipulate strings.`, "1.0"))
 
-	stringNamespace.InternVar("blank?", isblank_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`True if s is nil, empty, or contains only whitespace.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Boolean"}))
-
-	stringNamespace.InternVar("capitalize", capitalize_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Converts first character of the string to upper-case, all other
-  characters to lower-case.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	stringNamespace.InternVar("ends-with?", isends_with_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"), MakeSymbol("substr"))),
-			`True if s ends with substr.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Boolean"}))
-
-	stringNamespace.InternVar("escape", escape_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"), MakeSymbol("cmap"))),
-			`Return a new string, using cmap to escape each character ch
+	stringNamespace.InternVarPacked("align", align_, &VarMeta{
+		Arglists: "([s n alignment] [s n alignment pad])",
+		Doc: `Returns s padded with pad (a space by default) to length n.
+  alignment is one of :left, :right or :center.`,
+		Added: "1.2",
+		Extra: "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("blank?", isblank_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `True if s is nil, empty, or contains only whitespace.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Boolean\"}",
+	})
+
+	stringNamespace.InternVarPacked("camel-case", camel_case_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Like kebab-case, but converts s to camelCase.`,
+		Added:    "1.2",
+	})
+
+	stringNamespace.InternVarPacked("capitalize", capitalize_, &VarMeta{
+		Arglists: "([s])",
+		Doc: `Converts first character of the string to upper-case, all other
+  characters to lower-case.`,
+		Added: "1.0",
+		Extra: "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("category", category_, &VarMeta{
+		Arglists: "([c])",
+		Doc: `Returns the two-letter Unicode general category of c, e.g. "Lu" for
+  upper-case letters or "Nd" for decimal digits.`,
+		Added: "1.2",
+		Extra: "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("columnize", columnize_, &VarMeta{
+		Arglists: "([rows] [rows opts])",
+		Doc: `Lays out rows, a seq of seqs of values, as lines of fixed-width columns.
+  Each column is as wide as its widest value. opts may have the keys
+  :separator - string between columns (two spaces by default),
+  :align - :left (the default), :right or :center, or a vector of those,
+  one per column.`,
+		Added: "1.2",
+		Extra: "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("damerau-levenshtein", damerau_levenshtein_, &VarMeta{
+		Arglists: "([s1 s2])",
+		Doc: `Like levenshtein, but also counts a transposition of two adjacent
+  characters as a single edit (optimal string alignment distance).`,
+		Added: "1.2",
+		Extra: "{:tag \"Int\"}",
+	})
+
+	stringNamespace.InternVarPacked("dedent", dedent_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Removes the whitespace common to the beginning of all non-blank lines of s.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("digit?", isdigit_, &VarMeta{
+		Arglists: "([c])",
+		Doc:      `True if c is a Unicode decimal digit.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Boolean\"}",
+	})
+
+	stringNamespace.InternVarPacked("ends-with?", isends_with_, &VarMeta{
+		Arglists: "([s substr])",
+		Doc:      `True if s ends with substr.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Boolean\"}",
+	})
+
+	stringNamespace.InternVarPacked("equal-fold?", isequal_fold_, &VarMeta{
+		Arglists: "([s1 s2])",
+		Doc:      `True if s1 and s2 are equal under Unicode case folding.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Boolean\"}",
+	})
+
+	stringNamespace.InternVarPacked("escape", escape_, &VarMeta{
+		Arglists: "([s cmap])",
+		Doc: `Return a new string, using cmap to escape each character ch
   from s as follows:
 
   If (cmap ch) is nil, append ch to the new string.
-  If (cmap ch) is non-nil, append (str (cmap ch)) instead.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	stringNamespace.InternVar("includes?", isincludes_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"), MakeSymbol("substr"))),
-			`True if s includes substr.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Boolean"}))
-
-	stringNamespace.InternVar("index-of", index_of_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"), MakeSymbol("value")), NewVectorFrom(MakeSymbol("s"), MakeSymbol("value"), MakeSymbol("from"))),
-			`Return index of value (string or char) in s, optionally searching
-  forward from from or nil if not found.`, "1.0"))
-
-	stringNamespace.InternVar("join", join_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("coll")), NewVectorFrom(MakeSymbol("separator"), MakeSymbol("coll"))),
-			`Returns a string of all elements in coll, as returned by (seq coll), separated by an optional separator.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	stringNamespace.InternVar("last-index-of", last_index_of_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"), MakeSymbol("value")), NewVectorFrom(MakeSymbol("s"), MakeSymbol("value"), MakeSymbol("from"))),
-			`Return last index of value (string or char) in s, optionally
-  searching backward from from or nil if not found.`, "1.0"))
-
-	stringNamespace.InternVar("lower-case", lower_case_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Converts string to all lower-case.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	stringNamespace.InternVar("pad-left", pad_left_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"), MakeSymbol("pad"), MakeSymbol("n"))),
-			`Returns s padded with pad at the beginning to length n.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	stringNamespace.InternVar("pad-right", pad_right_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"), MakeSymbol("pad"), MakeSymbol("n"))),
-			`Returns s padded with pad at the end to length n.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	stringNamespace.InternVar("re-quote", re_quote_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Returns an instance of Regex that matches the string exactly`, "1.0").Plus(MakeKeyword("tag"), String{S: "Regex"}))
-
-	stringNamespace.InternVar("replace", replace_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"), MakeSymbol("match"), MakeSymbol("repl"))),
-			`Replaces all instances of match (String or Regex) with string repl in string s.
-
-  If match is Regex, $1, $2, etc. in the replacement string repl are
-  substituted with the string that matched the corresponding
-  parenthesized group in the pattern.
-  `, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	stringNamespace.InternVar("replace-first", replace_first_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"), MakeSymbol("match"), MakeSymbol("repl"))),
-			`Replaces the first instance of match (String or Regex) with string repl in string s.
-
-  If match is Regex, $1, $2, etc. in the replacement string repl are
-  substituted with the string that matched the corresponding
-  parenthesized group in the pattern.
-  `, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	stringNamespace.InternVar("reverse", reverse_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Returns s with its characters reversed.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	stringNamespace.InternVar("split", split_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"), MakeSymbol("sep")), NewVectorFrom(MakeSymbol("s"), MakeSymbol("sep"), MakeSymbol("n"))),
-			`Splits string on a string or regular expression. Returns vector of the splits.
+  If (cmap ch) is non-nil, append (str (cmap ch)) instead.`,
+		Added: "1.0",
+		Extra: "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("fold-case", fold_case_, &VarMeta{
+		Arglists: "([s])",
+		Doc: `Returns s with Unicode simple case folding applied, suitable for
+  case-insensitive comparisons.`,
+		Added: "1.2",
+		Extra: "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("fuzzy-match", fuzzy_match_, &VarMeta{
+		Arglists: "([pattern s])",
+		Doc: `Matches pattern against s as a case-insensitive subsequence, the way
+  fuzzy finders do. Returns nil if s doesn't contain all characters of pattern
+  in order, otherwise a map with keys :score (higher is better; consecutive
+  matches and matches at word starts score higher) and :indices (vector of
+  positions of the matched characters in s, e.g. for highlighting).`,
+		Added: "1.2",
+	})
+
+	stringNamespace.InternVarPacked("includes?", isincludes_, &VarMeta{
+		Arglists: "([s substr])",
+		Doc:      `True if s includes substr.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Boolean\"}",
+	})
+
+	stringNamespace.InternVarPacked("indent", indent_, &VarMeta{
+		Arglists: "([s prefix])",
+		Doc:      `Prefixes each non-blank line of s with prefix, a string or a number of spaces.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("index-of", index_of_, &VarMeta{
+		Arglists: "([s value] [s value from])",
+		Doc: `Return index of value (string or char) in s, optionally searching
+  forward from from or nil if not found.`,
+		Added: "1.0",
+	})
+
+	stringNamespace.InternVarPacked("jaro-winkler", jaro_winkler_, &VarMeta{
+		Arglists: "([s1 s2])",
+		Doc: `Returns the Jaro-Winkler similarity of s1 and s2, between 0.0 (no
+  similarity) and 1.0 (equal strings). Strings sharing a common prefix score higher.`,
+		Added: "1.2",
+		Extra: "{:tag \"Double\"}",
+	})
+
+	stringNamespace.InternVarPacked("join", join_, &VarMeta{
+		Arglists: "([coll] [separator coll])",
+		Doc:      `Returns a string of all elements in coll, as returned by (seq coll), separated by an optional separator.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("kebab-case", kebab_case_, &VarMeta{
+		Arglists: "([s])",
+		Doc: `Converts s (a string, keyword or symbol) to kebab-case, e.g. "fooBar" -> "foo-bar".
+  Words are delimited by non-alphanumeric characters and by case changes.
+  Returns a value of the same type as s; the namespace of keywords and symbols is kept.`,
+		Added: "1.2",
+	})
+
+	stringNamespace.InternVarPacked("last-index-of", last_index_of_, &VarMeta{
+		Arglists: "([s value] [s value from])",
+		Doc: `Return last index of value (string or char) in s, optionally
+  searching backward from from or nil if not found.`,
+		Added: "1.0",
+	})
+
+	stringNamespace.InternVarPacked("letter?", isletter_, &VarMeta{
+		Arglists: "([c])",
+		Doc:      `True if c is a Unicode letter.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Boolean\"}",
+	})
+
+	stringNamespace.InternVarPacked("levenshtein", levenshtein_, &VarMeta{
+		Arglists: "([s1 s2])",
+		Doc: `Returns the Levenshtein distance between s1 and s2: the minimum number
+  of single-character insertions, deletions and substitutions needed to
+  turn one into the other.`,
+		Added: "1.2",
+		Extra: "{:tag \"Int\"}",
+	})
+
+	stringNamespace.InternVarPacked("lower-case", lower_case_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Converts string to all lower-case.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("lower-case?", islower_case_, &VarMeta{
+		Arglists: "([c])",
+		Doc:      `True if c is a lower-case letter.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Boolean\"}",
+	})
+
+	stringNamespace.InternVarPacked("mark?", ismark_, &VarMeta{
+		Arglists: "([c])",
+		Doc:      `True if c is a Unicode mark character, e.g. a combining accent.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Boolean\"}",
+	})
+
+	stringNamespace.InternVarPacked("natural-compare", natural_compare_, &VarMeta{
+		Arglists: "([a b] [a b opts])",
+		Doc: `Compares strings a and b like compare, but treating runs of digits as
+  numbers, so that "file2" sorts before "file10". Usable as the comparator
+  of sort and sort-by. opts may have the keys
+  :ignore-case - whether to ignore differences in case (false by default),
+  :collate - whether to compare letters by their base form first, so that
+  accented letters sort next to their unaccented counterparts (e.g. "é" between
+  "e" and "f"), then by accents and finally by case (false by default).
+  Strings that only differ in the leading zeros of numbers are ordered by
+  code point, so that only equal strings compare as 0 unless :ignore-case is set.`,
+		Added: "1.2",
+		Extra: "{:tag \"Int\"}",
+	})
+
+	stringNamespace.InternVarPacked("normalize", normalize_, &VarMeta{
+		Arglists: "([s form])",
+		Doc: `Returns s in Unicode normalization form, one of :nfc, :nfd, :nfkc or :nfkd.
+  Visually identical strings, such as "\u00e9" and "e\u0301", are equal
+  after normalization to the same form.`,
+		Added: "1.2",
+		Extra: "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("normalized?", isnormalized_, &VarMeta{
+		Arglists: "([s form])",
+		Doc:      `True if s is in Unicode normalization form, one of :nfc, :nfd, :nfkc or :nfkd.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Boolean\"}",
+	})
+
+	stringNamespace.InternVarPacked("pad-left", pad_left_, &VarMeta{
+		Arglists: "([s pad n])",
+		Doc:      `Returns s padded with pad at the beginning to length n.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("pad-right", pad_right_, &VarMeta{
+		Arglists: "([s pad n])",
+		Doc:      `Returns s padded with pad at the end to length n.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("pascal-case", pascal_case_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Like kebab-case, but converts s to PascalCase.`,
+		Added:    "1.2",
+	})
+
+	stringNamespace.InternVarPacked("punctuation?", ispunctuation_, &VarMeta{
+		Arglists: "([c])",
+		Doc:      `True if c is a Unicode punctuation character.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Boolean\"}",
+	})
+
+	stringNamespace.InternVarPacked("re-quote", re_quote_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Returns an instance of Regex that matches the string exactly`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Regex\"}",
+	})
+
+	stringNamespace.InternVarPacked("re-quote-replacement", re_quote_replacement_, &VarMeta{
+		Arglists: "([replacement])",
+		Doc: `Escapes $ characters in replacement so that it is used literally
+  as the replacement string in replace and replace-first.`,
+		Added: "1.2",
+		Extra: "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("replace", replace_, &VarMeta{
+		Arglists: "([s match repl])",
+		Doc: `Replaces all instances of match (String or Regex) with string repl in string s.
+
+  If match is Regex, $1, $2, etc. (or ${name} for named groups) in the
+  replacement string repl are substituted with the string that matched
+  the corresponding parenthesized group in the pattern.
+  If match is Regex, repl may also be a function, which is called with
+  each match (as returned by re-find) and returns the replacement string.
+  `,
+		Added: "1.0",
+		Extra: "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("replace-first", replace_first_, &VarMeta{
+		Arglists: "([s match repl])",
+		Doc: `Replaces the first instance of match (String or Regex) with string repl in string s.
+
+  If match is Regex, $1, $2, etc. (or ${name} for named groups) in the
+  replacement string repl are substituted with the string that matched
+  the corresponding parenthesized group in the pattern.
+  If match is Regex, repl may also be a function, which is called with
+  the match (as returned by re-find) and returns the replacement string.
+  `,
+		Added: "1.0",
+		Extra: "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("reverse", reverse_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Returns s with its characters reversed.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("screaming-snake-case", screaming_snake_case_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Like kebab-case, but converts s to SCREAMING_SNAKE_CASE.`,
+		Added:    "1.2",
+	})
+
+	stringNamespace.InternVarPacked("slugify", slugify_, &VarMeta{
+		Arglists: "([s] [s opts])",
+		Doc: `Returns a slug for s, suitable for file names and URLs: s is transliterated,
+  lower-cased and runs of other characters than letters and digits are replaced
+  by a separator, e.g. "Hello, Wörld!" -> "hello-world". opts may have the keys
+  :separator - the separator ("-" by default),
+  :max-length - the maximum length; the slug is cut at a separator if possible,
+  :lower-case - whether to lower-case the slug (true by default),
+  :keep-unknown - whether to keep letters with no ASCII approximation (false by default).`,
+		Added: "1.2",
+		Extra: "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("snake-case", snake_case_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Like kebab-case, but converts s to snake_case.`,
+		Added:    "1.2",
+	})
+
+	stringNamespace.InternVarPacked("split", split_, &VarMeta{
+		Arglists: "([s sep] [s sep n])",
+		Doc: `Splits string on a string or regular expression. Returns vector of the splits.
 
   No more than n elements will be returned in the vector; the last element will
   be the unsplit remainder. If n is <= 0, no limit is in effect (all substrings
-  are returned in the vector).`, "1.0"))
-
-	stringNamespace.InternVar("split-lines", split_lines_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Splits string on \n or \r\n. Returns vector of the splits.`, "1.0"))
-
-	stringNamespace.InternVar("starts-with?", isstarts_with_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"), MakeSymbol("substr"))),
-			`True if s starts with substr.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Boolean"}))
-
-	stringNamespace.InternVar("trim", trim_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Removes whitespace from both ends of string.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	stringNamespace.InternVar("trim-left", trim_left_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Removes whitespace from the left side of string.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	stringNamespace.InternVar("trim-newline", trim_newline_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Removes all trailing newline \n or return \r characters from string.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	stringNamespace.InternVar("trim-right", trim_right_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Removes whitespace from the right side of string.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	stringNamespace.InternVar("triml", triml_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Removes whitespace from the left side of string.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	stringNamespace.InternVar("trimr", trimr_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Removes whitespace from the right side of string.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	stringNamespace.InternVar("upper-case", upper_case_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Converts string to all upper-case.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
+  are returned in the vector).`,
+		Added: "1.0",
+	})
+
+	stringNamespace.InternVarPacked("split-lines", split_lines_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Splits string on \n or \r\n. Returns vector of the splits.`,
+		Added:    "1.0",
+	})
+
+	stringNamespace.InternVarPacked("starts-with?", isstarts_with_, &VarMeta{
+		Arglists: "([s substr])",
+		Doc:      `True if s starts with substr.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Boolean\"}",
+	})
+
+	stringNamespace.InternVarPacked("transform-keys", transform_keys_, &VarMeta{
+		Arglists: "([f m])",
+		Doc: `Returns m with f applied to all its keys, recursing into nested maps
+  and vectors. E.g. (transform-keys kebab-case {"userId" 1}) returns {"user-id" 1}.`,
+		Added: "1.2",
+	})
+
+	stringNamespace.InternVarPacked("transliterate", transliterate_, &VarMeta{
+		Arglists: "([s] [s opts])",
+		Doc: `Returns s with non-ASCII characters replaced by ASCII approximations,
+  e.g. "Grüße, Ørsted" -> "Grusse, Orsted". Accents are removed and
+  Latin ligatures, Greek and Cyrillic letters are transliterated.
+  opts may have the key
+  :keep-unknown - whether to keep characters that have no ASCII approximation,
+  such as CJK characters (false by default: they are removed).`,
+		Added: "1.2",
+		Extra: "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("trim", trim_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Removes whitespace from both ends of string.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("trim-left", trim_left_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Removes whitespace from the left side of string.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("trim-newline", trim_newline_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Removes all trailing newline \n or return \r characters from string.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("trim-right", trim_right_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Removes whitespace from the right side of string.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("triml", triml_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Removes whitespace from the left side of string.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("trimr", trimr_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Removes whitespace from the right side of string.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("upper-case", upper_case_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Converts string to all upper-case.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	stringNamespace.InternVarPacked("upper-case?", isupper_case_, &VarMeta{
+		Arglists: "([c])",
+		Doc:      `True if c is an upper-case letter.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Boolean\"}",
+	})
+
+	stringNamespace.InternVarPacked("whitespace?", iswhitespace_, &VarMeta{
+		Arglists: "([c])",
+		Doc:      `True if c is a Unicode whitespace character.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Boolean\"}",
+	})
+
+	stringNamespace.InternVarPacked("word-wrap", word_wrap_, &VarMeta{
+		Arglists: "([s width] [s width opts])",
+		Doc: `Wraps s into lines no longer than width characters, breaking lines at
+  whitespace. Existing line breaks are preserved. opts may have the key
+  :break-long-words - whether to split words longer than width (true by default).`,
+		Added: "1.2",
+		Extra: "{:tag \"String\"}",
+	})
 
 }
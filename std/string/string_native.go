@@ -3,14 +3,17 @@ package string
 import (
 	"bytes"
 	"regexp"
+	"sort"
 	"strings"
 	"unicode"
 	"unicode/utf8"
 
 	. "github.com/candid82/joker/core"
+	"golang.org/x/text/unicode/norm"
 )
 
 var newLine *regexp.Regexp
+var categoryNames []string
 
 func padRight(s, pad string, n int) string {
 	toAdd := n - utf8.RuneCountInString(s)
@@ -160,27 +163,77 @@ func lastIndexOf(s string, value Object, from int) Object {
 	return MakeInt(utf8.RuneCountInString(s[:res]))
 }
 
-func replace(s string, match Object, repl string) string {
+func stringableRepl(repl Object) string {
+	switch repl := repl.(type) {
+	case String:
+		return repl.S
+	case Char:
+		return string(repl.Ch)
+	default:
+		panic(RT.NewArgTypeError(2, repl, "String or Char"))
+	}
+}
+
+// matchGroups returns the match at indexes the way re-find does:
+// a string if re has no groups, otherwise a vector of the match and its groups.
+func matchGroups(s string, indexes []int) Object {
+	if len(indexes) == 2 {
+		return MakeString(s[indexes[0]:indexes[1]])
+	}
+	v := EmptyVector()
+	for i := 0; i < len(indexes); i += 2 {
+		if indexes[i] == -1 {
+			v = v.Conjoin(NIL)
+		} else {
+			v = v.Conjoin(MakeString(s[indexes[i]:indexes[i+1]]))
+		}
+	}
+	return v
+}
+
+func replaceRegex(s string, re *regexp.Regexp, repl Object, n int) string {
+	var f Callable
+	var template string
+	if c, ok := repl.(Callable); ok {
+		if _, isKeyword := repl.(Keyword); !isKeyword {
+			f = c
+		}
+	}
+	if f == nil {
+		template = stringableRepl(repl)
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range re.FindAllStringSubmatchIndex(s, n) {
+		b.WriteString(s[last:m[0]])
+		if f != nil {
+			b.WriteString(EnsureObjectIsString(f.Call([]Object{matchGroups(s, m)}), "replacement: %s").S)
+		} else {
+			b.Write(re.ExpandString(nil, template, s, m))
+		}
+		last = m[1]
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+func replace(s string, match Object, repl Object) string {
 	switch match := match.(type) {
 	case String:
-		return strings.Replace(s, match.S, repl, -1)
+		return strings.Replace(s, match.S, stringableRepl(repl), -1)
 	case *Regex:
-		return match.R.ReplaceAllString(s, repl)
+		return replaceRegex(s, match.R, repl, -1)
 	default:
 		panic(RT.NewArgTypeError(1, match, "String or Regex"))
 	}
 }
 
-func replaceFirst(s string, match Object, repl string) string {
+func replaceFirst(s string, match Object, repl Object) string {
 	switch match := match.(type) {
 	case String:
-		return strings.Replace(s, match.S, repl, 1)
+		return strings.Replace(s, match.S, stringableRepl(repl), 1)
 	case *Regex:
-		m := match.R.FindStringIndex(s)
-		if m == nil {
-			return s
-		}
-		return s[:m[0]] + repl + s[m[1]:]
+		return replaceRegex(s, match.R, repl, 1)
 	default:
 		panic(RT.NewArgTypeError(1, match, "String or Regex"))
 	}
@@ -194,6 +247,664 @@ func reverse(s string) string {
 	return string(runes)
 }
 
+func normalizationForm(form string) norm.Form {
+	switch form {
+	case ":nfc":
+		return norm.NFC
+	case ":nfd":
+		return norm.NFD
+	case ":nfkc":
+		return norm.NFKC
+	case ":nfkd":
+		return norm.NFKD
+	default:
+		panic(RT.NewError("Unknown normalization form: " + form + ". Must be one of :nfc, :nfd, :nfkc or :nfkd"))
+	}
+}
+
+func foldCase(s string) string {
+	return strings.Map(func(r rune) rune {
+		return unicode.ToLower(unicode.ToUpper(r))
+	}, s)
+}
+
+func category(c rune) string {
+	for _, name := range categoryNames {
+		if unicode.Is(unicode.Categories[name], c) {
+			return name
+		}
+	}
+	return "Cn"
+}
+
 func init() {
 	newLine, _ = regexp.Compile("\r?\n")
+	for name := range unicode.Categories {
+		// LC (cased letter) is a union of Lu, Ll and Lt rather than a general category.
+		if len(name) == 2 && name != "LC" {
+			categoryNames = append(categoryNames, name)
+		}
+	}
+	sort.Strings(categoryNames)
+}
+
+func levenshtein(s1, s2 string) int {
+	a, b := []rune(s1), []rune(s2)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func damerauLevenshtein(s1, s2 string) int {
+	a, b := []rune(s1), []rune(s2)
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min3(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] && d[i-2][j-2]+1 < d[i][j] {
+				d[i][j] = d[i-2][j-2] + 1
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+func jaroWinkler(s1, s2 string) float64 {
+	a, b := []rune(s1), []rune(s2)
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	window := len(a)
+	if len(b) > window {
+		window = len(b)
+	}
+	window = window/2 - 1
+	if window < 0 {
+		window = 0
+	}
+	matchedA := make([]bool, len(a))
+	matchedB := make([]bool, len(b))
+	matches := 0
+	for i := range a {
+		lo, hi := i-window, i+window+1
+		if lo < 0 {
+			lo = 0
+		}
+		if hi > len(b) {
+			hi = len(b)
+		}
+		for j := lo; j < hi; j++ {
+			if !matchedB[j] && a[i] == b[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+	transpositions := 0
+	j := 0
+	for i := range a {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if a[i] != b[j] {
+			transpositions++
+		}
+		j++
+	}
+	m := float64(matches)
+	jaro := (m/float64(len(a)) + m/float64(len(b)) + (m-float64(transpositions/2))/m) / 3
+	prefix := 0
+	for prefix < 4 && prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+const (
+	fuzzyMatchScore       = 16
+	fuzzyConsecutiveBonus = 8
+	fuzzyBoundaryBonus    = 8
+	fuzzyGapPenalty       = 1
+)
+
+func isWordStart(runes []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev, curr := runes[i-1], runes[i]
+	if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(curr)
+}
+
+// fuzzyMatch finds the earliest occurrence of pattern as a subsequence of s,
+// then scans backwards from its end to find the shortest such match.
+func fuzzyMatch(pattern, s string) Object {
+	p := []rune(strings.ToLower(pattern))
+	runes := []rune(s)
+	if len(p) == 0 {
+		return EmptyArrayMap().Plus(MakeKeyword("score"), MakeInt(0)).Plus(MakeKeyword("indices"), EmptyVector())
+	}
+	pi, end := 0, -1
+	for i, r := range runes {
+		if unicode.ToLower(r) == p[pi] {
+			pi++
+			if pi == len(p) {
+				end = i
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return NIL
+	}
+	indices := make([]int, len(p))
+	pi = len(p) - 1
+	for i := end; pi >= 0; i-- {
+		if unicode.ToLower(runes[i]) == p[pi] {
+			indices[pi] = i
+			pi--
+		}
+	}
+	score := 0
+	res := EmptyVector()
+	for k, i := range indices {
+		score += fuzzyMatchScore
+		if isWordStart(runes, i) {
+			score += fuzzyBoundaryBonus
+		}
+		if k > 0 {
+			if i == indices[k-1]+1 {
+				score += fuzzyConsecutiveBonus
+			} else {
+				score -= fuzzyGapPenalty * (i - indices[k-1] - 1)
+			}
+		}
+		res = res.Conjoin(MakeInt(i))
+	}
+	return EmptyArrayMap().Plus(MakeKeyword("score"), MakeInt(score)).Plus(MakeKeyword("indices"), res)
+}
+
+func wordWrap(s string, width int, opts Map) string {
+	if width < 1 {
+		panic(RT.NewError("width must be positive"))
+	}
+	breakLong := true
+	if ok, v := opts.Get(MakeKeyword("break-long-words")); ok {
+		breakLong = ToBool(v)
+	}
+	lines := strings.Split(s, "\n")
+	var b strings.Builder
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		lineLen := 0
+		for _, word := range strings.Fields(line) {
+			runes := []rune(word)
+			if lineLen > 0 && lineLen+1+len(runes) <= width {
+				b.WriteByte(' ')
+				b.WriteString(word)
+				lineLen += 1 + len(runes)
+				continue
+			}
+			if lineLen > 0 {
+				b.WriteByte('\n')
+				lineLen = 0
+			}
+			for breakLong && len(runes) > width {
+				b.WriteString(string(runes[:width]))
+				b.WriteByte('\n')
+				runes = runes[width:]
+			}
+			b.WriteString(string(runes))
+			lineLen = len(runes)
+		}
+	}
+	return b.String()
+}
+
+func indent(s string, prefix Object) string {
+	var p string
+	switch prefix := prefix.(type) {
+	case Int:
+		p = strings.Repeat(" ", prefix.I)
+	case String:
+		p = prefix.S
+	default:
+		panic(RT.NewArgTypeError(1, prefix, "String or Int"))
+	}
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			lines[i] = p + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func dedent(s string) string {
+	lines := strings.Split(s, "\n")
+	common := ""
+	first := true
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		ws := line[:len(line)-len(strings.TrimLeftFunc(line, unicode.IsSpace))]
+		if first {
+			common = ws
+			first = false
+			continue
+		}
+		n := 0
+		for n < len(common) && n < len(ws) && common[n] == ws[n] {
+			n++
+		}
+		common = common[:n]
+	}
+	for i, line := range lines {
+		if strings.HasPrefix(line, common) {
+			lines[i] = line[len(common):]
+		} else {
+			lines[i] = strings.TrimSpace(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func align(s string, n int, alignment string, pad string) string {
+	switch alignment {
+	case ":left":
+		return padRight(s, pad, n)
+	case ":right":
+		return padLeft(s, pad, n)
+	case ":center":
+		left := (n - utf8.RuneCountInString(s)) / 2
+		if left < 0 {
+			left = 0
+		}
+		return padRight(padLeft(s, pad, left+utf8.RuneCountInString(s)), pad, n)
+	default:
+		panic(RT.NewError("Unknown alignment: " + alignment + ". Must be one of :left, :right or :center"))
+	}
+}
+
+func columnize(rows Seqable, opts Map) string {
+	sep := "  "
+	if ok, v := opts.Get(MakeKeyword("separator")); ok {
+		sep = EnsureObjectIsString(v, "separator: %s").S
+	}
+	var alignments Object = MakeKeyword("left")
+	if ok, v := opts.Get(MakeKeyword("align")); ok {
+		alignments = v
+	}
+	columnAlignment := func(i int) string {
+		if v, ok := alignments.(*Vector); ok {
+			if i < v.Count() {
+				return EnsureObjectIsKeyword(v.Nth(i), "align: %s").ToString(false)
+			}
+			return ":left"
+		}
+		return EnsureObjectIsKeyword(alignments, "align: %s").ToString(false)
+	}
+	var cells [][]string
+	var widths []int
+	for s := rows.Seq(); !s.IsEmpty(); s = s.Rest() {
+		var row []string
+		for i, c := 0, EnsureObjectIsSeqable(s.First(), "row: %s").Seq(); !c.IsEmpty(); i, c = i+1, c.Rest() {
+			var cell string
+			if _, ok := c.First().(Nil); !ok {
+				cell = c.First().ToString(false)
+			}
+			row = append(row, cell)
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			if w := utf8.RuneCountInString(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+		cells = append(cells, row)
+	}
+	lines := make([]string, len(cells))
+	for r, row := range cells {
+		parts := make([]string, len(row))
+		for i, cell := range row {
+			parts[i] = align(cell, widths[i], columnAlignment(i), " ")
+		}
+		lines[r] = strings.TrimRightFunc(strings.Join(parts, sep), unicode.IsSpace)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// splitWords splits s into words at non-alphanumeric characters, at
+// lower-to-upper case changes ("fooBar") and before the last upper-case
+// letter of an acronym followed by a lower-case letter ("HTTPServer").
+func splitWords(s string) []string {
+	var words []string
+	var word []rune
+	runes := []rune(s)
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = nil
+		}
+	}
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && len(word) > 0 {
+			prev := word[len(word)-1]
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				(unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+	return words
+}
+
+func capitalizeWord(w string) string {
+	runes := []rune(strings.ToLower(w))
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+func joinWords(s string, sep string, f func(i int, w string) string) string {
+	words := splitWords(s)
+	for i, w := range words {
+		words[i] = f(i, w)
+	}
+	return strings.Join(words, sep)
+}
+
+func kebabCase(s string) string {
+	return joinWords(s, "-", func(_ int, w string) string { return strings.ToLower(w) })
+}
+
+func snakeCase(s string) string {
+	return joinWords(s, "_", func(_ int, w string) string { return strings.ToLower(w) })
+}
+
+func screamingSnakeCase(s string) string {
+	return joinWords(s, "_", func(_ int, w string) string { return strings.ToUpper(w) })
+}
+
+func camelCase(s string) string {
+	return joinWords(s, "", func(i int, w string) string {
+		if i == 0 {
+			return strings.ToLower(w)
+		}
+		return capitalizeWord(w)
+	})
+}
+
+func pascalCase(s string) string {
+	return joinWords(s, "", func(_ int, w string) string { return capitalizeWord(w) })
+}
+
+func convertCase(obj Object, f func(string) string) Object {
+	switch obj := obj.(type) {
+	case String:
+		return MakeString(f(obj.S))
+	case Keyword:
+		if ns := obj.Namespace(); ns != "" {
+			return MakeKeyword(ns + "/" + f(obj.Name()))
+		}
+		return MakeKeyword(f(obj.Name()))
+	case Symbol:
+		if ns := obj.Namespace(); ns != "" {
+			return MakeSymbol(ns + "/" + f(obj.Name()))
+		}
+		return MakeSymbol(f(obj.Name()))
+	default:
+		panic(RT.NewArgTypeError(0, obj, "String, Keyword or Symbol"))
+	}
+}
+
+func transformKeys(f Callable, obj Object) Object {
+	switch obj := obj.(type) {
+	case Map:
+		var res Associative = EmptyArrayMap()
+		for iter := obj.Iter(); iter.HasNext(); {
+			p := iter.Next()
+			res = res.Assoc(f.Call([]Object{p.Key}), transformKeys(f, p.Value))
+		}
+		return res
+	case *Vector:
+		res := EmptyVector()
+		for i := 0; i < obj.Count(); i++ {
+			res = res.Conjoin(transformKeys(f, obj.Nth(i)))
+		}
+		return res
+	default:
+		return obj
+	}
+}
+
+var transliterations = map[rune]string{
+	'ß': "ss", 'ẞ': "SS", 'Æ': "AE", 'æ': "ae", 'Œ': "OE", 'œ': "oe",
+	'Ø': "O", 'ø': "o", 'Ł': "L", 'ł': "l", 'Đ': "D", 'đ': "d",
+	'Ð': "D", 'ð': "d", 'Þ': "TH", 'þ': "th", 'Ħ': "H", 'ħ': "h",
+	'ı': "i", 'Ŀ': "L", 'ŀ': "l", 'Ŋ': "N", 'ŋ': "n", 'ſ': "s",
+	'‘': "'", '’': "'", '‚': "'", '“': "\"", '”': "\"", '„': "\"",
+	'–': "-", '—': "-", '…': "...", '«': "\"", '»': "\"", '€': "EUR",
+	'£': "GBP", '©': "(c)", '®': "(r)", '™': "TM", '×': "x", '÷': "/",
+	' ': " ",
+
+	'А': "A", 'Б': "B", 'В': "V", 'Г': "G", 'Д': "D", 'Е': "E", 'Ё': "Yo",
+	'Ж': "Zh", 'З': "Z", 'И': "I", 'Й': "Y", 'К': "K", 'Л': "L", 'М': "M",
+	'Н': "N", 'О': "O", 'П': "P", 'Р': "R", 'С': "S", 'Т': "T", 'У': "U",
+	'Ф': "F", 'Х': "Kh", 'Ц': "Ts", 'Ч': "Ch", 'Ш': "Sh", 'Щ': "Shch", 'Ъ': "",
+	'Ы': "Y", 'Ь': "", 'Э': "E", 'Ю': "Yu", 'Я': "Ya", 'Є': "Ye", 'І': "I",
+	'Ї': "Yi", 'Ґ': "G",
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "",
+	'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya", 'є': "ye", 'і': "i",
+	'ї': "yi", 'ґ': "g",
+
+	'Α': "A", 'Β': "V", 'Γ': "G", 'Δ': "D", 'Ε': "E", 'Ζ': "Z", 'Η': "I",
+	'Θ': "Th", 'Ι': "I", 'Κ': "K", 'Λ': "L", 'Μ': "M", 'Ν': "N", 'Ξ': "X",
+	'Ο': "O", 'Π': "P", 'Ρ': "R", 'Σ': "S", 'Τ': "T", 'Υ': "Y", 'Φ': "F",
+	'Χ': "Ch", 'Ψ': "Ps", 'Ω': "O",
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i",
+	'θ': "th", 'ι': "i", 'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x",
+	'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y",
+	'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+func transliterate(s string, opts Map) string {
+	keepUnknown := false
+	if ok, v := opts.Get(MakeKeyword("keep-unknown")); ok {
+		keepUnknown = ToBool(v)
+	}
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		switch {
+		case r < utf8.RuneSelf:
+			b.WriteRune(r)
+		case unicode.Is(unicode.Mn, r):
+		default:
+			if t, ok := transliterations[r]; ok {
+				b.WriteString(t)
+			} else if d := norm.NFKD.String(string(r)); d != string(r) {
+				b.WriteString(transliterate(d, opts))
+			} else if keepUnknown {
+				b.WriteString(norm.NFC.String(string(r)))
+			}
+		}
+	}
+	return b.String()
+}
+
+func slugify(s string, opts Map) string {
+	sep := "-"
+	if ok, v := opts.Get(MakeKeyword("separator")); ok {
+		sep = EnsureObjectIsString(v, "separator: %s").S
+	}
+	maxLength := -1
+	if ok, v := opts.Get(MakeKeyword("max-length")); ok {
+		maxLength = EnsureObjectIsInt(v, "max-length: %s").I
+	}
+	s = transliterate(s, opts)
+	if ok, v := opts.Get(MakeKeyword("lower-case")); !ok || ToBool(v) {
+		s = strings.ToLower(s)
+	}
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	res := strings.Join(words, sep)
+	if maxLength >= 0 && utf8.RuneCountInString(res) > maxLength {
+		runes := []rune(res)
+		rest := string(runes[maxLength:])
+		res = string(runes[:maxLength])
+		if sep != "" && !strings.HasPrefix(rest, sep) {
+			if i := strings.LastIndex(res, sep); i > 0 {
+				res = res[:i]
+			}
+		}
+		res = strings.TrimSuffix(res, sep)
+	}
+	return res
+}
+
+func isASCIIDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// compareNatural compares a and b treating runs of ASCII digits as numbers.
+// Numbers that differ only in leading zeros compare as equal.
+func compareNatural(a, b string) int {
+	for a != "" && b != "" {
+		ra, _ := utf8.DecodeRuneInString(a)
+		rb, _ := utf8.DecodeRuneInString(b)
+		if isASCIIDigit(ra) && isASCIIDigit(rb) {
+			i := 0
+			for i < len(a) && isASCIIDigit(rune(a[i])) {
+				i++
+			}
+			j := 0
+			for j < len(b) && isASCIIDigit(rune(b[j])) {
+				j++
+			}
+			na := strings.TrimLeft(a[:i], "0")
+			nb := strings.TrimLeft(b[:j], "0")
+			if len(na) != len(nb) {
+				if len(na) < len(nb) {
+					return -1
+				}
+				return 1
+			}
+			if c := strings.Compare(na, nb); c != 0 {
+				return c
+			}
+			a, b = a[i:], b[j:]
+			continue
+		}
+		if ra != rb {
+			if ra < rb {
+				return -1
+			}
+			return 1
+		}
+		a, b = a[utf8.RuneLen(ra):], b[utf8.RuneLen(rb):]
+	}
+	switch {
+	case a != "":
+		return 1
+	case b != "":
+		return -1
+	}
+	return 0
+}
+
+// collationKey returns s without accents (secondary == false)
+// and case folded, for the levels of a multi-level comparison.
+func collationKey(s string, secondary bool) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		if !secondary && unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+func naturalCompare(a, b string, opts Map) int {
+	collate := false
+	if ok, v := opts.Get(MakeKeyword("collate")); ok {
+		collate = ToBool(v)
+	}
+	ignoreCase := false
+	if ok, v := opts.Get(MakeKeyword("ignore-case")); ok {
+		ignoreCase = ToBool(v)
+	}
+	if collate {
+		if c := compareNatural(collationKey(a, false), collationKey(b, false)); c != 0 {
+			return c
+		}
+		if c := compareNatural(collationKey(a, true), collationKey(b, true)); c != 0 || ignoreCase {
+			return c
+		}
+	} else if ignoreCase {
+		return compareNatural(foldCase(a), foldCase(b))
+	}
+	if c := compareNatural(a, b); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
 }
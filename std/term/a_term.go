@@ -0,0 +1,318 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+package term
+
+import (
+	. "github.com/candid82/joker/core"
+)
+
+var __clear_line__P ProcFn = __clear_line_
+var clear_line_ Proc = Proc{Fn: __clear_line__P, Name: "clear_line_", Package: "std/term"}
+
+func __clear_line_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := "\x1b[2K"
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __clear_screen__P ProcFn = __clear_screen_
+var clear_screen_ Proc = Proc{Fn: __clear_screen__P, Name: "clear_screen_", Package: "std/term"}
+
+func __clear_screen_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := "\x1b[2J"
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __clear_to_end__P ProcFn = __clear_to_end_
+var clear_to_end_ Proc = Proc{Fn: __clear_to_end__P, Name: "clear_to_end_", Package: "std/term"}
+
+func __clear_to_end_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := "\x1b[0J"
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __cursor_back__P ProcFn = __cursor_back_
+var cursor_back_ Proc = Proc{Fn: __cursor_back__P, Name: "cursor_back_", Package: "std/term"}
+
+func __cursor_back_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := csi(1, "D")
+		return MakeString(_res)
+
+	case _c == 1:
+		n := ExtractInt(_args, 0)
+		_res := csi(n, "D")
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __cursor_down__P ProcFn = __cursor_down_
+var cursor_down_ Proc = Proc{Fn: __cursor_down__P, Name: "cursor_down_", Package: "std/term"}
+
+func __cursor_down_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := csi(1, "B")
+		return MakeString(_res)
+
+	case _c == 1:
+		n := ExtractInt(_args, 0)
+		_res := csi(n, "B")
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __cursor_forward__P ProcFn = __cursor_forward_
+var cursor_forward_ Proc = Proc{Fn: __cursor_forward__P, Name: "cursor_forward_", Package: "std/term"}
+
+func __cursor_forward_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := csi(1, "C")
+		return MakeString(_res)
+
+	case _c == 1:
+		n := ExtractInt(_args, 0)
+		_res := csi(n, "C")
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __cursor_to__P ProcFn = __cursor_to_
+var cursor_to_ Proc = Proc{Fn: __cursor_to__P, Name: "cursor_to_", Package: "std/term"}
+
+func __cursor_to_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		row := ExtractInt(_args, 0)
+		col := ExtractInt(_args, 1)
+		_res := cursorTo(row, col)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __cursor_up__P ProcFn = __cursor_up_
+var cursor_up_ Proc = Proc{Fn: __cursor_up__P, Name: "cursor_up_", Package: "std/term"}
+
+func __cursor_up_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := csi(1, "A")
+		return MakeString(_res)
+
+	case _c == 1:
+		n := ExtractInt(_args, 0)
+		_res := csi(n, "A")
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __hide_cursor__P ProcFn = __hide_cursor_
+var hide_cursor_ Proc = Proc{Fn: __hide_cursor__P, Name: "hide_cursor_", Package: "std/term"}
+
+func __hide_cursor_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := "\x1b[?25l"
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __read_key__P ProcFn = __read_key_
+var read_key_ Proc = Proc{Fn: __read_key__P, Name: "read_key_", Package: "std/term"}
+
+func __read_key_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := readKey()
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __resize_chan__P ProcFn = __resize_chan_
+var resize_chan_ Proc = Proc{Fn: __resize_chan__P, Name: "resize_chan_", Package: "std/term"}
+
+func __resize_chan_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := resizeChannel()
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __restore_cursor__P ProcFn = __restore_cursor_
+var restore_cursor_ Proc = Proc{Fn: __restore_cursor__P, Name: "restore_cursor_", Package: "std/term"}
+
+func __restore_cursor_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := "\x1b8"
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __save_cursor__P ProcFn = __save_cursor_
+var save_cursor_ Proc = Proc{Fn: __save_cursor__P, Name: "save_cursor_", Package: "std/term"}
+
+func __save_cursor_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := "\x1b7"
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __set_raw_mode_bang__P ProcFn = __set_raw_mode_bang_
+var set_raw_mode_bang_ Proc = Proc{Fn: __set_raw_mode_bang__P, Name: "set_raw_mode_bang_", Package: "std/term"}
+
+func __set_raw_mode_bang_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		on := ExtractBoolean(_args, 0)
+		_res := setRawMode(on)
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __show_cursor__P ProcFn = __show_cursor_
+var show_cursor_ Proc = Proc{Fn: __show_cursor__P, Name: "show_cursor_", Package: "std/term"}
+
+func __show_cursor_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := "\x1b[?25h"
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __terminal_size__P ProcFn = __terminal_size_
+var terminal_size_ Proc = Proc{Fn: __terminal_size__P, Name: "terminal_size_", Package: "std/term"}
+
+func __terminal_size_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := terminalSize()
+		return _res
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __istty__P ProcFn = __istty_
+var istty_ Proc = Proc{Fn: __istty__P, Name: "istty_", Package: "std/term"}
+
+func __istty_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := isTTY(MakeKeyword("stdout"))
+		return MakeBoolean(_res)
+
+	case _c == 1:
+		stream := ExtractObject(_args, 0)
+		_res := isTTY(stream)
+		return MakeBoolean(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+func Init() {
+
+	InternsOrThunks()
+}
+
+var termNamespace = GLOBAL_ENV.EnsureSymbolIsLib(MakeSymbol("joker.term"))
+
+func init() {
+	termNamespace.Lazy = Init
+}
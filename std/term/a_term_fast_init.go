@@ -0,0 +1,35 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+//go:build !gen_code
+// +build !gen_code
+
+package term
+
+import (
+	"fmt"
+	. "github.com/candid82/joker/core"
+	"os"
+)
+
+func InternsOrThunks() {
+	if VerbosityLevel > 0 {
+		fmt.Fprintln(os.Stderr, "Lazily running fast version of term.InternsOrThunks().")
+	}
+	STD_thunk_term_clear_line__var = __clear_line_
+	STD_thunk_term_clear_screen__var = __clear_screen_
+	STD_thunk_term_clear_to_end__var = __clear_to_end_
+	STD_thunk_term_cursor_back__var = __cursor_back_
+	STD_thunk_term_cursor_down__var = __cursor_down_
+	STD_thunk_term_cursor_forward__var = __cursor_forward_
+	STD_thunk_term_cursor_to__var = __cursor_to_
+	STD_thunk_term_cursor_up__var = __cursor_up_
+	STD_thunk_term_hide_cursor__var = __hide_cursor_
+	STD_thunk_term_read_key__var = __read_key_
+	STD_thunk_term_resize_chan__var = __resize_chan_
+	STD_thunk_term_restore_cursor__var = __restore_cursor_
+	STD_thunk_term_save_cursor__var = __save_cursor_
+	STD_thunk_term_set_raw_mode_bang__var = __set_raw_mode_bang_
+	STD_thunk_term_show_cursor__var = __show_cursor_
+	STD_thunk_term_terminal_size__var = __terminal_size_
+	STD_thunk_term_istty__var = __istty_
+}
@@ -0,0 +1,155 @@
+// This file is generated by generate-std.joke script. Do not edit manually!
+
+//go:build gen_code
+// +build gen_code
+
+package term
+
+import (
+	"fmt"
+	. "github.com/candid82/joker/core"
+	"os"
+)
+
+func InternsOrThunks() {
+	if VerbosityLevel > 0 {
+		fmt.Fprintln(os.Stderr, "Lazily running slow version of term.InternsOrThunks().")
+	}
+	termNamespace.ResetMeta(MakeMeta(nil, `Provides access to the terminal: size, tty checks, raw-mode key input,
+         cursor movement and screen clearing.
+
+         The cursor and screen functions return ANSI escape sequences, which take
+         effect when printed, e.g. (print (joker.term/clear-screen) (joker.term/cursor-to 1 1)).
+
+         Raw mode and resize events are only supported on Unix-like systems.`, "1.0"))
+
+	termNamespace.InternVarPacked("clear-line", clear_line_, &VarMeta{
+		Arglists: "([])",
+		Doc: `Returns the escape sequence clearing the line the cursor is on.
+  Doesn't move the cursor.`,
+		Added: "1.2",
+		Extra: "{:tag \"String\"}",
+	})
+
+	termNamespace.InternVarPacked("clear-screen", clear_screen_, &VarMeta{
+		Arglists: "([])",
+		Doc:      `Returns the escape sequence clearing the screen. Doesn't move the cursor.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	termNamespace.InternVarPacked("clear-to-end", clear_to_end_, &VarMeta{
+		Arglists: "([])",
+		Doc:      `Returns the escape sequence clearing the screen from the cursor to the end.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	termNamespace.InternVarPacked("cursor-back", cursor_back_, &VarMeta{
+		Arglists: "([] [n])",
+		Doc:      `Returns the escape sequence moving the cursor left n (1 by default) columns.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	termNamespace.InternVarPacked("cursor-down", cursor_down_, &VarMeta{
+		Arglists: "([] [n])",
+		Doc:      `Returns the escape sequence moving the cursor down n (1 by default) rows.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	termNamespace.InternVarPacked("cursor-forward", cursor_forward_, &VarMeta{
+		Arglists: "([] [n])",
+		Doc:      `Returns the escape sequence moving the cursor right n (1 by default) columns.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	termNamespace.InternVarPacked("cursor-to", cursor_to_, &VarMeta{
+		Arglists: "([row col])",
+		Doc:      `Returns the escape sequence moving the cursor to row and col (starting at 1).`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	termNamespace.InternVarPacked("cursor-up", cursor_up_, &VarMeta{
+		Arglists: "([] [n])",
+		Doc:      `Returns the escape sequence moving the cursor up n (1 by default) rows.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	termNamespace.InternVarPacked("hide-cursor", hide_cursor_, &VarMeta{
+		Arglists: "([])",
+		Doc:      `Returns the escape sequence hiding the cursor.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	termNamespace.InternVarPacked("read-key", read_key_, &VarMeta{
+		Arglists: "([])",
+		Doc: `Reads a single key press from stdin, which must be a terminal, without
+  waiting for Enter and without echoing it. Returns a Char for ordinary keys,
+  or a keyword for special keys: :up, :down, :left, :right, :home, :end,
+  :page-up, :page-down, :insert, :delete, :f1 to :f4, :enter, :tab, :shift-tab,
+  :backspace, :escape, :ctrl-a to :ctrl-z (note that Ctrl-C is returned
+  as :ctrl-c rather than interrupting the program) and :alt-x for Alt
+  combined with character x.`,
+		Added: "1.2",
+	})
+
+	termNamespace.InternVarPacked("resize-chan", resize_chan_, &VarMeta{
+		Arglists: "([])",
+		Doc: `Returns a channel that receives the new terminal size, a map with keys
+  :cols and :rows, whenever the terminal is resized. Sizes are dropped while
+  a previous one has not been taken. Use with <! inside go blocks or <!!.`,
+		Added: "1.2",
+	})
+
+	termNamespace.InternVarPacked("restore-cursor", restore_cursor_, &VarMeta{
+		Arglists: "([])",
+		Doc:      `Returns the escape sequence restoring the cursor position saved by save-cursor.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	termNamespace.InternVarPacked("save-cursor", save_cursor_, &VarMeta{
+		Arglists: "([])",
+		Doc:      `Returns the escape sequence saving the cursor position.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	termNamespace.InternVarPacked("set-raw-mode!", set_raw_mode_bang_, &VarMeta{
+		Arglists: "([on])",
+		Doc: `Puts the terminal connected to stdin into raw mode (on is true): key presses
+  are available immediately, are not echoed and Ctrl-C doesn't interrupt
+  the program. Restores the previous mode if on is false.
+  The mode is also restored when the program exits.`,
+		Added: "1.2",
+	})
+
+	termNamespace.InternVarPacked("show-cursor", show_cursor_, &VarMeta{
+		Arglists: "([])",
+		Doc:      `Returns the escape sequence showing the cursor.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	termNamespace.InternVarPacked("terminal-size", terminal_size_, &VarMeta{
+		Arglists: "([])",
+		Doc: `Returns the size of the terminal as a map with keys :cols and :rows,
+  or nil if none of the standard streams is connected to a terminal.`,
+		Added: "1.2",
+	})
+
+	termNamespace.InternVarPacked("tty?", istty_, &VarMeta{
+		Arglists: "([] [stream])",
+		Doc: `Returns true if stream is connected to a terminal. stream is one of :stdin,
+  :stdout (the default) and :stderr, a File, or the value of *in*, *out* or *err*.`,
+		Added: "1.2",
+		Extra: "{:tag \"Boolean\"}",
+	})
+
+}
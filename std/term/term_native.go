@@ -0,0 +1,235 @@
+package term
+
+import (
+	"os"
+	"strconv"
+	"unicode/utf8"
+
+	. "github.com/candid82/joker/core"
+)
+
+var (
+	colsKeyword = MakeKeyword("cols")
+	rowsKeyword = MakeKeyword("rows")
+)
+
+// Keys sent by terminals as escape sequences.
+var escapeKeys = map[string]string{
+	"[A":  "up",
+	"[B":  "down",
+	"[C":  "right",
+	"[D":  "left",
+	"[H":  "home",
+	"[F":  "end",
+	"OA":  "up",
+	"OB":  "down",
+	"OC":  "right",
+	"OD":  "left",
+	"OH":  "home",
+	"OF":  "end",
+	"OP":  "f1",
+	"OQ":  "f2",
+	"OR":  "f3",
+	"OS":  "f4",
+	"[1~": "home",
+	"[2~": "insert",
+	"[3~": "delete",
+	"[4~": "end",
+	"[5~": "page-up",
+	"[6~": "page-down",
+	"[7~": "home",
+	"[8~": "end",
+	"[Z":  "shift-tab",
+}
+
+// fileOf returns the file behind stream, which is one of the keywords
+// :stdin, :stdout and :stderr, a File or the value of *in*, *out* or *err*.
+func fileOf(stream Object) *os.File {
+	switch s := stream.(type) {
+	case Keyword:
+		switch s.Name() {
+		case "stdin":
+			return os.Stdin
+		case "stdout":
+			return os.Stdout
+		case "stderr":
+			return os.Stderr
+		}
+	case *File:
+		return s.File
+	case *IOWriter:
+		if f, ok := s.Writer.(*os.File); ok {
+			return f
+		}
+	}
+	stdin, stdout, stderr := GLOBAL_ENV.InitialStdIO()
+	switch stream {
+	case stdin:
+		return os.Stdin
+	case stdout:
+		return os.Stdout
+	case stderr:
+		return os.Stderr
+	}
+	return nil
+}
+
+func isTTY(stream Object) bool {
+	f := fileOf(stream)
+	return f != nil && isTerminal(f)
+}
+
+func sizeMap(cols, rows int) Map {
+	m := EmptyArrayMap()
+	m.Add(colsKeyword, MakeInt(cols))
+	m.Add(rowsKeyword, MakeInt(rows))
+	return m
+}
+
+func terminalSize() Object {
+	for _, f := range []*os.File{os.Stdout, os.Stderr, os.Stdin} {
+		if cols, rows, ok := getSize(f.Fd()); ok {
+			return sizeMap(cols, rows)
+		}
+	}
+	return NIL
+}
+
+func resizeChannel() *Channel {
+	ch := make(chan FutureResult, 1)
+	watchResize(func() {
+		if cols, rows, ok := getSize(os.Stdout.Fd()); ok {
+			select {
+			case ch <- MakeFutureResult(sizeMap(cols, rows), nil):
+			default:
+			}
+		}
+	})
+	return MakeChannel(ch)
+}
+
+// decodeKey returns the key read as the bytes b: a Char, or a keyword
+// such as :up, :enter or :ctrl-a for special keys.
+func decodeKey(b []byte) Object {
+	switch {
+	case len(b) == 0:
+		return NIL
+	case b[0] == 27:
+		if len(b) == 1 {
+			return MakeKeyword("escape")
+		}
+		if k, ok := escapeKeys[string(b[1:])]; ok {
+			return MakeKeyword(k)
+		}
+		if len(b) == 2 {
+			r, _ := utf8.DecodeRune(b[1:])
+			return MakeKeyword("alt-" + string(r))
+		}
+		return MakeKeyword("unknown")
+	case b[0] == '\r' || b[0] == '\n':
+		return MakeKeyword("enter")
+	case b[0] == '\t':
+		return MakeKeyword("tab")
+	case b[0] == 127 || b[0] == 8:
+		return MakeKeyword("backspace")
+	case b[0] == 0:
+		return MakeKeyword("ctrl-space")
+	case b[0] < 27:
+		return MakeKeyword("ctrl-" + string(rune('a'+b[0]-1)))
+	}
+	r, _ := utf8.DecodeRune(b)
+	return Char{Ch: r}
+}
+
+// keyLength returns the number of bytes of the first key in b, so that
+// several keys read at once (e.g. when pasting) are returned one by one.
+func keyLength(b []byte) int {
+	if b[0] != 27 || len(b) == 1 {
+		_, n := utf8.DecodeRune(b)
+		return n
+	}
+	switch b[1] {
+	case '[':
+		// CSI sequence: parameter bytes followed by a final byte.
+		for i := 2; i < len(b); i++ {
+			if b[i] >= 0x40 && b[i] <= 0x7e {
+				return i + 1
+			}
+		}
+		return len(b)
+	case 'O':
+		if len(b) > 2 {
+			return 3
+		}
+		return 2
+	}
+	_, n := utf8.DecodeRune(b[1:])
+	return 1 + n
+}
+
+// Keys read from stdin but not returned yet.
+var pendingInput []byte
+
+func readKey() Object {
+	if len(pendingInput) == 0 {
+		if !isTerminal(os.Stdin) {
+			panic(RT.NewError("read-key: stdin is not a terminal"))
+		}
+		fd := os.Stdin.Fd()
+		if !rawMode {
+			state, err := makeRaw(fd)
+			PanicOnErr(err)
+			defer restore(fd, state)
+		}
+		buf := make([]byte, 64)
+		gs := RT.GIL.Unlock()
+		n, err := os.Stdin.Read(buf)
+		RT.GIL.Lock(gs)
+		PanicOnErr(err)
+		pendingInput = buf[:n]
+	}
+	if len(pendingInput) == 0 {
+		return NIL
+	}
+	n := keyLength(pendingInput)
+	key := decodeKey(pendingInput[:n])
+	pendingInput = pendingInput[n:]
+	return key
+}
+
+var (
+	rawMode         bool
+	savedState      *termState
+	restoreOnExitOn bool
+)
+
+func setRawMode(on bool) Object {
+	fd := os.Stdin.Fd()
+	switch {
+	case on && !rawMode:
+		state, err := makeRaw(fd)
+		PanicOnErr(err)
+		savedState = state
+		rawMode = true
+		if !restoreOnExitOn {
+			restoreOnExitOn = true
+			OnExit(func() {
+				if rawMode {
+					restore(fd, savedState)
+				}
+			})
+		}
+	case !on && rawMode:
+		PanicOnErr(restore(fd, savedState))
+		rawMode = false
+	}
+	return NIL
+}
+
+func csi(n int, code string) string {
+	return "\x1b[" + strconv.Itoa(n) + code
+}
+
+func cursorTo(row, col int) string {
+	return "\x1b[" + strconv.Itoa(row) + ";" + strconv.Itoa(col) + "H"
+}
@@ -0,0 +1,33 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+// +build !linux,!darwin,!dragonfly,!freebsd,!netbsd,!openbsd
+
+package term
+
+import (
+	"errors"
+	"os"
+)
+
+type termState struct{}
+
+var errUnsupported = errors.New("raw mode is not supported on this platform")
+
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+func getSize(fd uintptr) (cols, rows int, ok bool) {
+	return 0, 0, false
+}
+
+func makeRaw(fd uintptr) (*termState, error) {
+	return nil, errUnsupported
+}
+
+func restore(fd uintptr, state *termState) error {
+	return errUnsupported
+}
+
+func watchResize(f func()) {
+}
@@ -14,213 +14,271 @@ func InternsOrThunks() {
 	}
 	timeNamespace.ResetMeta(MakeMeta(nil, `Provides functionality for measuring and displaying time.`, "1.0"))
 
-	timeNamespace.InternVar("ansi-c", ansi_c_,
-		MakeMeta(
-			nil,
-			`Mon Jan _2 15:04:05 2006`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	timeNamespace.InternVar("hour", hour_,
-		MakeMeta(
-			nil,
-			`Number of nanoseconds in 1 hour`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "BigInt"}))
-
-	timeNamespace.InternVar("kitchen", kitchen_,
-		MakeMeta(
-			nil,
-			`3:04PM`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	timeNamespace.InternVar("microsecond", microsecond_,
-		MakeMeta(
-			nil,
-			`Number of nanoseconds in 1 microsecond`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "Int"}))
-
-	timeNamespace.InternVar("millisecond", millisecond_,
-		MakeMeta(
-			nil,
-			`Number of nanoseconds in 1 millisecond`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "Int"}))
-
-	timeNamespace.InternVar("minute", minute_,
-		MakeMeta(
-			nil,
-			`Number of nanoseconds in 1 minute`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "BigInt"}))
-
-	timeNamespace.InternVar("nanosecond", nanosecond_,
-		MakeMeta(
-			nil,
-			`Number of nanoseconds in 1 nanosecond`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "Int"}))
-
-	timeNamespace.InternVar("rfc1123", rfc1123_,
-		MakeMeta(
-			nil,
-			`Mon, 02 Jan 2006 15:04:05 MST`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	timeNamespace.InternVar("rfc1123-z", rfc1123_z_,
-		MakeMeta(
-			nil,
-			`Mon, 02 Jan 2006 15:04:05 -0700`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	timeNamespace.InternVar("rfc3339", rfc3339_,
-		MakeMeta(
-			nil,
-			`2006-01-02T15:04:05Z07:00`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	timeNamespace.InternVar("rfc3339-nano", rfc3339_nano_,
-		MakeMeta(
-			nil,
-			`2006-01-02T15:04:05.999999999Z07:00`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	timeNamespace.InternVar("rfc822", rfc822_,
-		MakeMeta(
-			nil,
-			`02 Jan 06 15:04 MST`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	timeNamespace.InternVar("rfc822-z", rfc822_z_,
-		MakeMeta(
-			nil,
-			`02 Jan 06 15:04 -0700`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	timeNamespace.InternVar("rfc850", rfc850_,
-		MakeMeta(
-			nil,
-			`Monday, 02-Jan-06 15:04:05 MST`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	timeNamespace.InternVar("ruby-date", ruby_date_,
-		MakeMeta(
-			nil,
-			`Mon Jan 02 15:04:05 -0700 2006`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	timeNamespace.InternVar("second", second_,
-		MakeMeta(
-			nil,
-			`Number of nanoseconds in 1 second`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "Int"}))
-
-	timeNamespace.InternVar("stamp", stamp_,
-		MakeMeta(
-			nil,
-			`Jan _2 15:04:05`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	timeNamespace.InternVar("stamp-micro", stamp_micro_,
-		MakeMeta(
-			nil,
-			`Jan _2 15:04:05.000000`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	timeNamespace.InternVar("stamp-milli", stamp_milli_,
-		MakeMeta(
-			nil,
-			`Jan _2 15:04:05.000`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	timeNamespace.InternVar("stamp-nano", stamp_nano_,
-		MakeMeta(
-			nil,
-			`Jan _2 15:04:05.000000000`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	timeNamespace.InternVar("unix-date", unix_date_,
-		MakeMeta(
-			nil,
-			`Mon Jan _2 15:04:05 MST 2006`, "1.0").Plus(MakeKeyword("const"), String{S: "true"}).Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	timeNamespace.InternVar("add", add_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("t"), MakeSymbol("d"))),
-			`Returns the time t+d.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Time"}))
-
-	timeNamespace.InternVar("add-date", add_date_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("t"), MakeSymbol("years"), MakeSymbol("months"), MakeSymbol("days"))),
-			`Returns the time t + (years, months, days).`, "1.0").Plus(MakeKeyword("tag"), String{S: "Time"}))
-
-	timeNamespace.InternVar("format", format_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("t"), MakeSymbol("layout"))),
-			`Returns a textual representation of the time value formatted according to layout,
+	timeNamespace.InternVarPacked("ansi-c", ansi_c_, &VarMeta{
+		Doc:   `Mon Jan _2 15:04:05 2006`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"String\"}",
+	})
+
+	timeNamespace.InternVarPacked("hour", hour_, &VarMeta{
+		Doc:   `Number of nanoseconds in 1 hour`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"BigInt\"}",
+	})
+
+	timeNamespace.InternVarPacked("kitchen", kitchen_, &VarMeta{
+		Doc:   `3:04PM`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"String\"}",
+	})
+
+	timeNamespace.InternVarPacked("microsecond", microsecond_, &VarMeta{
+		Doc:   `Number of nanoseconds in 1 microsecond`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"Int\"}",
+	})
+
+	timeNamespace.InternVarPacked("millisecond", millisecond_, &VarMeta{
+		Doc:   `Number of nanoseconds in 1 millisecond`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"Int\"}",
+	})
+
+	timeNamespace.InternVarPacked("minute", minute_, &VarMeta{
+		Doc:   `Number of nanoseconds in 1 minute`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"BigInt\"}",
+	})
+
+	timeNamespace.InternVarPacked("nanosecond", nanosecond_, &VarMeta{
+		Doc:   `Number of nanoseconds in 1 nanosecond`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"Int\"}",
+	})
+
+	timeNamespace.InternVarPacked("rfc1123", rfc1123_, &VarMeta{
+		Doc:   `Mon, 02 Jan 2006 15:04:05 MST`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"String\"}",
+	})
+
+	timeNamespace.InternVarPacked("rfc1123-z", rfc1123_z_, &VarMeta{
+		Doc:   `Mon, 02 Jan 2006 15:04:05 -0700`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"String\"}",
+	})
+
+	timeNamespace.InternVarPacked("rfc3339", rfc3339_, &VarMeta{
+		Doc:   `2006-01-02T15:04:05Z07:00`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"String\"}",
+	})
+
+	timeNamespace.InternVarPacked("rfc3339-nano", rfc3339_nano_, &VarMeta{
+		Doc:   `2006-01-02T15:04:05.999999999Z07:00`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"String\"}",
+	})
+
+	timeNamespace.InternVarPacked("rfc822", rfc822_, &VarMeta{
+		Doc:   `02 Jan 06 15:04 MST`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"String\"}",
+	})
+
+	timeNamespace.InternVarPacked("rfc822-z", rfc822_z_, &VarMeta{
+		Doc:   `02 Jan 06 15:04 -0700`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"String\"}",
+	})
+
+	timeNamespace.InternVarPacked("rfc850", rfc850_, &VarMeta{
+		Doc:   `Monday, 02-Jan-06 15:04:05 MST`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"String\"}",
+	})
+
+	timeNamespace.InternVarPacked("ruby-date", ruby_date_, &VarMeta{
+		Doc:   `Mon Jan 02 15:04:05 -0700 2006`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"String\"}",
+	})
+
+	timeNamespace.InternVarPacked("second", second_, &VarMeta{
+		Doc:   `Number of nanoseconds in 1 second`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"Int\"}",
+	})
+
+	timeNamespace.InternVarPacked("stamp", stamp_, &VarMeta{
+		Doc:   `Jan _2 15:04:05`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"String\"}",
+	})
+
+	timeNamespace.InternVarPacked("stamp-micro", stamp_micro_, &VarMeta{
+		Doc:   `Jan _2 15:04:05.000000`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"String\"}",
+	})
+
+	timeNamespace.InternVarPacked("stamp-milli", stamp_milli_, &VarMeta{
+		Doc:   `Jan _2 15:04:05.000`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"String\"}",
+	})
+
+	timeNamespace.InternVarPacked("stamp-nano", stamp_nano_, &VarMeta{
+		Doc:   `Jan _2 15:04:05.000000000`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"String\"}",
+	})
+
+	timeNamespace.InternVarPacked("unix-date", unix_date_, &VarMeta{
+		Doc:   `Mon Jan _2 15:04:05 MST 2006`,
+		Added: "1.0",
+		Extra: "{:const \"true\", :tag \"String\"}",
+	})
+
+	timeNamespace.InternVarPacked("add", add_, &VarMeta{
+		Arglists: "([t d])",
+		Doc:      `Returns the time t+d.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Time\"}",
+	})
+
+	timeNamespace.InternVarPacked("add-date", add_date_, &VarMeta{
+		Arglists: "([t years months days])",
+		Doc:      `Returns the time t + (years, months, days).`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Time\"}",
+	})
+
+	timeNamespace.InternVarPacked("format", format_, &VarMeta{
+		Arglists: "([t layout])",
+		Doc: `Returns a textual representation of the time value formatted according to layout,
   which defines the format by showing how the reference time, defined to be
   Mon Jan 2 15:04:05 -0700 MST 2006
   would be displayed if it were the value; it serves as an example of the desired output.
-  The same display rules will then be applied to the time value..`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	timeNamespace.InternVar("from-unix", from_unix_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("sec"), MakeSymbol("nsec"))),
-			`Returns the local Time corresponding to the given Unix time, sec seconds and
-  nsec nanoseconds since January 1, 1970 UTC. It is valid to pass nsec outside the range [0, 999999999].`, "1.0").Plus(MakeKeyword("tag"), String{S: "Time"}))
-
-	timeNamespace.InternVar("hours", hours_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("d"))),
-			`Returns the duration (passed as a number of nanoseconds) as a floating point number of hours.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	timeNamespace.InternVar("in-timezone", in_timezone_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("t"), MakeSymbol("tz"))),
-			`Returns a copy of t representing the same time instant, but with the copy's timezone information set to tz for display purposes.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Time"}))
-
-	timeNamespace.InternVar("minutes", minutes_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("d"))),
-			`Returns the duration (passed as a number of nanoseconds) as a floating point number of minutes.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	timeNamespace.InternVar("now", now_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom()),
-			`Returns the current local time.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Time"}))
-
-	timeNamespace.InternVar("parse", parse_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("layout"), MakeSymbol("value"))),
-			`Parses a time string.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Time"}))
-
-	timeNamespace.InternVar("parse-duration", parse_duration_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Parses a duration string. A duration string is a possibly signed sequence of decimal numbers,
+  The same display rules will then be applied to the time value..`,
+		Added: "1.0",
+		Extra: "{:tag \"String\"}",
+	})
+
+	timeNamespace.InternVarPacked("from-unix", from_unix_, &VarMeta{
+		Arglists: "([sec nsec])",
+		Doc: `Returns the local Time corresponding to the given Unix time, sec seconds and
+  nsec nanoseconds since January 1, 1970 UTC. It is valid to pass nsec outside the range [0, 999999999].`,
+		Added: "1.0",
+		Extra: "{:tag \"Time\"}",
+	})
+
+	timeNamespace.InternVarPacked("hours", hours_, &VarMeta{
+		Arglists: "([d])",
+		Doc:      `Returns the duration (passed as a number of nanoseconds) as a floating point number of hours.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	timeNamespace.InternVarPacked("in-timezone", in_timezone_, &VarMeta{
+		Arglists: "([t tz])",
+		Doc:      `Returns a copy of t representing the same time instant, but with the copy's timezone information set to tz for display purposes.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Time\"}",
+	})
+
+	timeNamespace.InternVarPacked("minutes", minutes_, &VarMeta{
+		Arglists: "([d])",
+		Doc:      `Returns the duration (passed as a number of nanoseconds) as a floating point number of minutes.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	timeNamespace.InternVarPacked("now", now_, &VarMeta{
+		Arglists: "([])",
+		Doc:      `Returns the current local time.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Time\"}",
+	})
+
+	timeNamespace.InternVarPacked("parse", parse_, &VarMeta{
+		Arglists: "([layout value])",
+		Doc:      `Parses a time string.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Time\"}",
+	})
+
+	timeNamespace.InternVarPacked("parse-duration", parse_duration_, &VarMeta{
+		Arglists: "([s])",
+		Doc: `Parses a duration string. A duration string is a possibly signed sequence of decimal numbers,
   each with optional fraction and a unit suffix, such as 300ms, -1.5h or 2h45m. Valid time units are
-  ns, us (or µs), ms, s, m, h.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Int"}))
-
-	timeNamespace.InternVar("round", round_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("d"), MakeSymbol("m"))),
-			`Returns the result of rounding d to the nearest multiple of m. d and m represent time durations in nanoseconds.
-  The rounding behavior for halfway values is to round away from zero. If m <= 0, returns d unchanged.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Int"}))
-
-	timeNamespace.InternVar("seconds", seconds_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("d"))),
-			`Returns the duration (passed as a number of nanoseconds) as a floating point number of seconds.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Double"}))
-
-	timeNamespace.InternVar("since", since_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("t"))),
-			`Returns the time in nanoseconds elapsed since t.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Int"}))
-
-	timeNamespace.InternVar("sleep", sleep_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("d"))),
-			`Pauses the execution thread for at least the duration d (expressed in nanoseconds).
-  A negative or zero duration causes sleep to return immediately.`, "1.0"))
-
-	timeNamespace.InternVar("string", string_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("d"))),
-			`Returns a string representing the duration in the form 72h3m0.5s.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	timeNamespace.InternVar("sub", sub_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("t"), MakeSymbol("u"))),
-			`Returns the duration t-u in nanoseconds.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Int"}))
-
-	timeNamespace.InternVar("truncate", truncate_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("d"), MakeSymbol("m"))),
-			`Returns the result of rounding d toward zero to a multiple of m. If m <= 0, returns d unchanged.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Int"}))
-
-	timeNamespace.InternVar("unix", unix_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("t"))),
-			`Returns t as a Unix time, the number of seconds elapsed since January 1, 1970 UTC.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Int"}))
-
-	timeNamespace.InternVar("until", until_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("t"))),
-			`Returns the duration in nanoseconds until t.`, "1.0").Plus(MakeKeyword("tag"), String{S: "Int"}))
+  ns, us (or µs), ms, s, m, h.`,
+		Added: "1.0",
+		Extra: "{:tag \"Int\"}",
+	})
+
+	timeNamespace.InternVarPacked("round", round_, &VarMeta{
+		Arglists: "([d m])",
+		Doc: `Returns the result of rounding d to the nearest multiple of m. d and m represent time durations in nanoseconds.
+  The rounding behavior for halfway values is to round away from zero. If m <= 0, returns d unchanged.`,
+		Added: "1.0",
+		Extra: "{:tag \"Int\"}",
+	})
+
+	timeNamespace.InternVarPacked("seconds", seconds_, &VarMeta{
+		Arglists: "([d])",
+		Doc:      `Returns the duration (passed as a number of nanoseconds) as a floating point number of seconds.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Double\"}",
+	})
+
+	timeNamespace.InternVarPacked("since", since_, &VarMeta{
+		Arglists: "([t])",
+		Doc:      `Returns the time in nanoseconds elapsed since t.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+	timeNamespace.InternVarPacked("sleep", sleep_, &VarMeta{
+		Arglists: "([d])",
+		Doc: `Pauses the execution thread for at least the duration d (expressed in nanoseconds).
+  A negative or zero duration causes sleep to return immediately.`,
+		Added: "1.0",
+	})
+
+	timeNamespace.InternVarPacked("string", string_, &VarMeta{
+		Arglists: "([d])",
+		Doc:      `Returns a string representing the duration in the form 72h3m0.5s.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	timeNamespace.InternVarPacked("sub", sub_, &VarMeta{
+		Arglists: "([t u])",
+		Doc:      `Returns the duration t-u in nanoseconds.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+	timeNamespace.InternVarPacked("truncate", truncate_, &VarMeta{
+		Arglists: "([d m])",
+		Doc:      `Returns the result of rounding d toward zero to a multiple of m. If m <= 0, returns d unchanged.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+	timeNamespace.InternVarPacked("unix", unix_, &VarMeta{
+		Arglists: "([t])",
+		Doc:      `Returns t as a Unix time, the number of seconds elapsed since January 1, 1970 UTC.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Int\"}",
+	})
+
+	timeNamespace.InternVarPacked("until", until_, &VarMeta{
+		Arglists: "([t])",
+		Doc:      `Returns the duration in nanoseconds until t.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"Int\"}",
+	})
 
 }
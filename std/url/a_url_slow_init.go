@@ -14,30 +14,38 @@ func InternsOrThunks() {
 	}
 	urlNamespace.ResetMeta(MakeMeta(nil, `Parses URLs and implements query escaping.`, "1.0"))
 
-	urlNamespace.InternVar("path-escape", path_escape_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Escapes the string so it can be safely placed inside a URL path segment.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	urlNamespace.InternVar("path-unescape", path_unescape_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Does the inverse transformation of path-escape, converting each 3-byte encoded
+	urlNamespace.InternVarPacked("path-escape", path_escape_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Escapes the string so it can be safely placed inside a URL path segment.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	urlNamespace.InternVarPacked("path-unescape", path_unescape_, &VarMeta{
+		Arglists: "([s])",
+		Doc: `Does the inverse transformation of path-escape, converting each 3-byte encoded
   substring of the form "%AB" into the hex-decoded byte 0xAB. It also converts
   '+' into ' ' (space). It returns an error if any % is not followed by two hexadecimal digits.
 
-  PathUnescape is identical to QueryUnescape except that it does not unescape '+' to ' ' (space).`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	urlNamespace.InternVar("query-escape", query_escape_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Escapes the string so it can be safely placed inside a URL query.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
-
-	urlNamespace.InternVar("query-unescape", query_unescape_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Does the inverse transformation of query-escape, converting each 3-byte encoded
+  PathUnescape is identical to QueryUnescape except that it does not unescape '+' to ' ' (space).`,
+		Added: "1.0",
+		Extra: "{:tag \"String\"}",
+	})
+
+	urlNamespace.InternVarPacked("query-escape", query_escape_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Escapes the string so it can be safely placed inside a URL query.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	urlNamespace.InternVarPacked("query-unescape", query_unescape_, &VarMeta{
+		Arglists: "([s])",
+		Doc: `Does the inverse transformation of query-escape, converting each 3-byte encoded
   substring of the form "%AB" into the hex-decoded byte 0xAB. It also converts
-  '+' into ' ' (space). It returns an error if any % is not followed by two hexadecimal digits.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
+  '+' into ' ' (space). It returns an error if any % is not followed by two hexadecimal digits.`,
+		Added: "1.0",
+		Extra: "{:tag \"String\"}",
+	})
 
 }
@@ -22,6 +22,155 @@ func __new_(_args []Object) Object {
 	return NIL
 }
 
+var __new_ksuid__P ProcFn = __new_ksuid_
+var new_ksuid_ Proc = Proc{Fn: __new_ksuid__P, Name: "new_ksuid_", Package: "std/uuid"}
+
+func __new_ksuid_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := newKSUID()
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __new_ulid__P ProcFn = __new_ulid_
+var new_ulid_ Proc = Proc{Fn: __new_ulid__P, Name: "new_ulid_", Package: "std/uuid"}
+
+func __new_ulid_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := newULID()
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __new_v1__P ProcFn = __new_v1_
+var new_v1_ Proc = Proc{Fn: __new_v1__P, Name: "new_v1_", Package: "std/uuid"}
+
+func __new_v1_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := newV1()
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __new_v4__P ProcFn = __new_v4_
+var new_v4_ Proc = Proc{Fn: __new_v4__P, Name: "new_v4_", Package: "std/uuid"}
+
+func __new_v4_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := new()
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __new_v5__P ProcFn = __new_v5_
+var new_v5_ Proc = Proc{Fn: __new_v5__P, Name: "new_v5_", Package: "std/uuid"}
+
+func __new_v5_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 2:
+		namespace := ExtractObject(_args, 0)
+		name := ExtractString(_args, 1)
+		_res := newV5(namespace, name)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __new_v7__P ProcFn = __new_v7_
+var new_v7_ Proc = Proc{Fn: __new_v7__P, Name: "new_v7_", Package: "std/uuid"}
+
+func __new_v7_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 0:
+		_res := newV7()
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __parse__P ProcFn = __parse_
+var parse_ Proc = Proc{Fn: __parse__P, Name: "parse_", Package: "std/uuid"}
+
+func __parse_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		s := ExtractString(_args, 0)
+		_res := parse(s)
+		return MakeString(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __isvalid__P ProcFn = __isvalid_
+var isvalid_ Proc = Proc{Fn: __isvalid__P, Name: "isvalid_", Package: "std/uuid"}
+
+func __isvalid_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		s := ExtractString(_args, 0)
+		_res := isValid(s)
+		return MakeBoolean(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
+var __version__P ProcFn = __version_
+var version_ Proc = Proc{Fn: __version__P, Name: "version_", Package: "std/uuid"}
+
+func __version_(_args []Object) Object {
+	_c := len(_args)
+	switch {
+	case _c == 1:
+		s := ExtractString(_args, 0)
+		_res := version(s)
+		return MakeInt(_res)
+
+	default:
+		PanicArity(_c)
+	}
+	return NIL
+}
+
 func Init() {
 
 	InternsOrThunks()
@@ -12,11 +12,84 @@ func InternsOrThunks() {
 	if VerbosityLevel > 0 {
 		fmt.Fprintln(os.Stderr, "Lazily running slow version of uuid.InternsOrThunks().")
 	}
-	uuidNamespace.ResetMeta(MakeMeta(nil, `Generates UUIDs.`, "1.0"))
+	uuidNamespace.ResetMeta(MakeMeta(nil, `Generates and parses UUIDs, ULIDs and KSUIDs.`, "1.0"))
 
-	uuidNamespace.InternVar("new", new_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom()),
-			`Creates a new random UUID.`, "1.0").Plus(MakeKeyword("tag"), String{S: "String"}))
+	uuidNamespace.InternVarPacked("new", new_, &VarMeta{
+		Arglists: "([])",
+		Doc:      `Creates a new random UUID.`,
+		Added:    "1.0",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	uuidNamespace.InternVarPacked("new-ksuid", new_ksuid_, &VarMeta{
+		Arglists: "([])",
+		Doc: `Creates a new KSUID: a 27-character, lexicographically sortable identifier
+  made of a second-resolution timestamp and 128 bits of randomness.`,
+		Added: "1.2",
+		Extra: "{:tag \"String\"}",
+	})
+
+	uuidNamespace.InternVarPacked("new-ulid", new_ulid_, &VarMeta{
+		Arglists: "([])",
+		Doc: `Creates a new ULID: a 26-character, lexicographically sortable identifier
+  made of a millisecond timestamp and 80 bits of randomness.`,
+		Added: "1.2",
+		Extra: "{:tag \"String\"}",
+	})
+
+	uuidNamespace.InternVarPacked("new-v1", new_v1_, &VarMeta{
+		Arglists: "([])",
+		Doc: `Creates a new time-based (version 1) UUID.
+  A random node id is used in place of a hardware address.`,
+		Added: "1.2",
+		Extra: "{:tag \"String\"}",
+	})
+
+	uuidNamespace.InternVarPacked("new-v4", new_v4_, &VarMeta{
+		Arglists: "([])",
+		Doc:      `Creates a new random (version 4) UUID. Same as new.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"String\"}",
+	})
+
+	uuidNamespace.InternVarPacked("new-v5", new_v5_, &VarMeta{
+		Arglists: "([namespace name])",
+		Doc: `Creates a new name-based (version 5, SHA-1) UUID from namespace and name.
+  namespace is either a UUID string or one of the predefined namespaces
+  :dns, :url, :oid, :x500. The same namespace and name always produce the same UUID.`,
+		Added: "1.2",
+		Extra: "{:tag \"String\"}",
+	})
+
+	uuidNamespace.InternVarPacked("new-v7", new_v7_, &VarMeta{
+		Arglists: "([])",
+		Doc: `Creates a new time-ordered (version 7) UUID. UUIDs created later
+  sort after UUIDs created earlier (at millisecond resolution).`,
+		Added: "1.2",
+		Extra: "{:tag \"String\"}",
+	})
+
+	uuidNamespace.InternVarPacked("parse", parse_, &VarMeta{
+		Arglists: "([s])",
+		Doc: `Parses s as a UUID and returns it in canonical (lowercase, hyphenated) form.
+  Accepts the canonical form, the {...} and urn:uuid: forms, and 32 hex digits without hyphens.
+  Throws an exception if s is not a valid UUID.`,
+		Added: "1.2",
+		Extra: "{:tag \"String\"}",
+	})
+
+	uuidNamespace.InternVarPacked("valid?", isvalid_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Returns true if s can be parsed as a UUID, false otherwise.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Boolean\"}",
+	})
+
+	uuidNamespace.InternVarPacked("version", version_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Returns the version number of UUID s.`,
+		Added:    "1.2",
+		Extra:    "{:tag \"Int\"}",
+	})
 
 }
@@ -5,9 +5,16 @@ package uuid
 
 import (
 	"crypto/rand"
+	"crypto/sha1"
+	"encoding/binary"
 	"encoding/hex"
-	. "github.com/candid82/joker/core"
 	"io"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/candid82/joker/core"
 )
 
 type UUID [16]byte
@@ -42,3 +49,222 @@ func new() string {
 	uuid[8] = (uuid[8] & 0x3f) | 0x80 // Variant is 10
 	return uuid.String()
 }
+
+var (
+	nsDNS  = mustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	nsURL  = mustParse("6ba7b811-9dad-11d1-80b4-00c04fd430c8")
+	nsOID  = mustParse("6ba7b812-9dad-11d1-80b4-00c04fd430c8")
+	nsX500 = mustParse("6ba7b814-9dad-11d1-80b4-00c04fd430c8")
+)
+
+// Difference in 100-nanosecond intervals between the UUID epoch
+// (October 15, 1582) and the Unix epoch (January 1, 1970).
+const gregorianOffset = 122192928000000000
+
+var (
+	v1Mutex    sync.Mutex
+	v1LastTime uint64
+	v1ClockSeq uint16
+	v1Node     [6]byte
+	v1Inited   bool
+)
+
+func randomBytes(b []byte) {
+	if _, err := io.ReadFull(rander, b); err != nil {
+		panic(RT.NewError("Error generating random bytes: " + err.Error()))
+	}
+}
+
+func xtob(x1, x2 byte) (byte, bool) {
+	b1 := xvalues[x1]
+	b2 := xvalues[x2]
+	return (b1 << 4) | b2, b1 != 255 && b2 != 255
+}
+
+var xvalues = func() (res [256]byte) {
+	for i := range res {
+		res[i] = 255
+	}
+	for i := '0'; i <= '9'; i++ {
+		res[i] = byte(i - '0')
+	}
+	for i := 'a'; i <= 'f'; i++ {
+		res[i] = byte(i - 'a' + 10)
+		res[i-'a'+'A'] = byte(i - 'a' + 10)
+	}
+	return
+}()
+
+// parseUUID accepts the canonical form as well as the {...} and
+// urn:uuid: forms, and 32 hex digits without hyphens.
+func parseUUID(s string) (uuid UUID, ok bool) {
+	switch len(s) {
+	case 36:
+	case 36 + 9:
+		if !strings.EqualFold(s[:9], "urn:uuid:") {
+			return uuid, false
+		}
+		s = s[9:]
+	case 36 + 2:
+		if s[0] != '{' || s[len(s)-1] != '}' {
+			return uuid, false
+		}
+		s = s[1 : len(s)-1]
+	case 32:
+		for i := range uuid {
+			if uuid[i], ok = xtob(s[i*2], s[i*2+1]); !ok {
+				return uuid, false
+			}
+		}
+		return uuid, true
+	default:
+		return uuid, false
+	}
+	if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return uuid, false
+	}
+	for i, x := range [16]int{0, 2, 4, 6, 9, 11, 14, 16, 19, 21, 24, 26, 28, 30, 32, 34} {
+		if uuid[i], ok = xtob(s[x], s[x+1]); !ok {
+			return uuid, false
+		}
+	}
+	return uuid, true
+}
+
+func mustParse(s string) UUID {
+	uuid, ok := parseUUID(s)
+	if !ok {
+		panic(RT.NewError("Invalid UUID: " + s))
+	}
+	return uuid
+}
+
+func parse(s string) string {
+	return mustParse(s).String()
+}
+
+func isValid(s string) bool {
+	_, ok := parseUUID(s)
+	return ok
+}
+
+func version(s string) int {
+	return int(mustParse(s)[6] >> 4)
+}
+
+func newV1() string {
+	v1Mutex.Lock()
+	if !v1Inited {
+		var b [8]byte
+		randomBytes(b[:])
+		v1ClockSeq = binary.BigEndian.Uint16(b[:2]) & 0x3fff
+		copy(v1Node[:], b[2:])
+		v1Node[0] |= 0x01 // Multicast bit marks the node as random (RFC 4122, 4.5)
+		v1Inited = true
+	}
+	now := uint64(time.Now().UnixNano()/100) + gregorianOffset
+	if now <= v1LastTime {
+		v1ClockSeq = (v1ClockSeq + 1) & 0x3fff
+	}
+	v1LastTime = now
+	clockSeq := v1ClockSeq
+	node := v1Node
+	v1Mutex.Unlock()
+
+	var uuid UUID
+	binary.BigEndian.PutUint32(uuid[0:], uint32(now))
+	binary.BigEndian.PutUint16(uuid[4:], uint16(now>>32))
+	binary.BigEndian.PutUint16(uuid[6:], uint16(now>>48)&0x0fff|0x1000) // Version 1
+	binary.BigEndian.PutUint16(uuid[8:], clockSeq|0x8000)               // Variant is 10
+	copy(uuid[10:], node[:])
+	return uuid.String()
+}
+
+func namespaceUUID(ns Object) UUID {
+	switch ns := ns.(type) {
+	case Keyword:
+		switch ns.ToString(false) {
+		case ":dns":
+			return nsDNS
+		case ":url":
+			return nsURL
+		case ":oid":
+			return nsOID
+		case ":x500":
+			return nsX500
+		}
+		panic(RT.NewError("Unknown UUID namespace " + ns.ToString(false) +
+			". Supported namespaces are: :dns, :url, :oid, :x500"))
+	case String:
+		return mustParse(ns.S)
+	default:
+		panic(RT.NewArgTypeError(0, ns, "Keyword or String"))
+	}
+}
+
+func newV5(ns Object, name string) string {
+	space := namespaceUUID(ns)
+	h := sha1.New()
+	h.Write(space[:])
+	h.Write([]byte(name))
+	var uuid UUID
+	copy(uuid[:], h.Sum(nil))
+	uuid[6] = (uuid[6] & 0x0f) | 0x50 // Version 5
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // Variant is 10
+	return uuid.String()
+}
+
+func newV7() string {
+	var uuid UUID
+	ms := uint64(time.Now().UnixNano() / int64(time.Millisecond))
+	uuid[0] = byte(ms >> 40)
+	uuid[1] = byte(ms >> 32)
+	binary.BigEndian.PutUint32(uuid[2:], uint32(ms))
+	randomBytes(uuid[6:])
+	uuid[6] = (uuid[6] & 0x0f) | 0x70 // Version 7
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // Variant is 10
+	return uuid.String()
+}
+
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+func newULID() string {
+	var id [16]byte
+	ms := uint64(time.Now().UnixNano() / int64(time.Millisecond))
+	id[0] = byte(ms >> 40)
+	id[1] = byte(ms >> 32)
+	binary.BigEndian.PutUint32(id[2:], uint32(ms))
+	randomBytes(id[6:])
+
+	// 128 bits are encoded as 26 characters of 5 bits each, most
+	// significant first (the leading character only carries 3 bits).
+	n := big.NewInt(0).SetBytes(id[:])
+	var buf [26]byte
+	mask := big.NewInt(31)
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i] = crockford[big.NewInt(0).And(n, mask).Int64()]
+		n.Rsh(n, 5)
+	}
+	return string(buf[:])
+}
+
+const base62 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// KSUID epoch is 14e8 seconds after the Unix epoch.
+const ksuidEpoch = 1400000000
+
+func newKSUID() string {
+	var id [20]byte
+	binary.BigEndian.PutUint32(id[:], uint32(time.Now().Unix()-ksuidEpoch))
+	randomBytes(id[4:])
+
+	n := big.NewInt(0).SetBytes(id[:])
+	var buf [27]byte
+	base := big.NewInt(62)
+	rem := big.NewInt(0)
+	for i := len(buf) - 1; i >= 0; i-- {
+		n.QuoRem(n, base, rem)
+		buf[i] = base62[rem.Int64()]
+	}
+	return string(buf[:])
+}
@@ -14,14 +14,16 @@ func InternsOrThunks() {
 	}
 	yamlNamespace.ResetMeta(MakeMeta(nil, `Implements encoding and decoding of YAML.`, "1.0"))
 
-	yamlNamespace.InternVar("read-string", read_string_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("s"))),
-			`Parses the YAML-encoded data and return the result as a Joker value.`, "1.0"))
+	yamlNamespace.InternVarPacked("read-string", read_string_, &VarMeta{
+		Arglists: "([s])",
+		Doc:      `Parses the YAML-encoded data and return the result as a Joker value.`,
+		Added:    "1.0",
+	})
 
-	yamlNamespace.InternVar("write-string", write_string_,
-		MakeMeta(
-			NewListFrom(NewVectorFrom(MakeSymbol("v"))),
-			`Returns the YAML encoding of v.`, "1.0"))
+	yamlNamespace.InternVarPacked("write-string", write_string_, &VarMeta{
+		Arglists: "([v])",
+		Doc:      `Returns the YAML encoding of v.`,
+		Added:    "1.0",
+	})
 
 }
@@ -63,9 +63,9 @@ func toObject(v interface{}) Object {
 		}
 		return res
 	case map[interface{}]interface{}:
-		res := EmptyArrayMap()
+		var res Associative = EmptyArrayMap()
 		for k, v := range v {
-			res.Add(toObject(k), toObject(v))
+			res = res.Assoc(toObject(k), toObject(v))
 		}
 		return res
 	default:
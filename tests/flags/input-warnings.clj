@@ -0,0 +1 @@
+(let [a 1 b 2] "foo")
@@ -0,0 +1,3 @@
+(ns broken)
+
+(let [a 1])
@@ -0,0 +1,6 @@
+(ns app.core
+  (:require [app.util :as util]))
+
+(defn -main
+  []
+  (util/greet "world"))
@@ -0,0 +1,9 @@
+(ns app.util)
+
+(defn greet
+  [name]
+  (str "Hello, " name))
+
+(defn shout
+  [name]
+  (str "HELLO, " name))
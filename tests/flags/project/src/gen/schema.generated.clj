@@ -0,0 +1,3 @@
+(ns gen.schema)
+
+(let [b 2])
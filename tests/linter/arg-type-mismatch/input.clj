@@ -0,0 +1,4 @@
+(first 2)
+(let [x 1]
+  (rest x))
+(inc "1")
@@ -0,0 +1,17 @@
+(defn f [x]
+  (let [s (str x)
+        v [x]
+        n nil
+        m (meta x)]
+    (if true 1 2)
+    (when nil (println x))
+    (if s 1 2)
+    (if v 1 2)
+    (when-not n (println x))
+    (if m 1 2)
+    (when (seq s) (println s))
+    (when-let [y (first v)] (println y))
+    (cond (pos? x) 1 :else 2)
+    (cond-> x true inc)
+    (while nil (println x))
+    (loop [i nil] (if i i (recur 1)))))
@@ -8,3 +8,10 @@
 (defmethod m2 :v [] nil)
 
 (defmethod m3)
+
+(defmulti m4 (fn [x _] x))
+
+(defmethod m4 :a [x y] y)
+
+(derive ::b ::a)
+(isa? ::b ::a)
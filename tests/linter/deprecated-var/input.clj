@@ -0,0 +1,15 @@
+(defn new-f [x] x)
+(defn old-f
+  {:deprecated "1.2" :superseded-by 'new-f}
+  [x]
+  (new-f x))
+(defn ^:deprecated older-f [x] (old-f x))
+(def ^{:deprecated true} v 1)
+;; Should PASS
+
+(new-f 1)
+
+;; Should FAIL
+(old-f 1)
+(map older-f [1])
+(inc v)
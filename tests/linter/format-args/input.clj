@@ -0,0 +1,12 @@
+(defn f [x]
+  (let [s (str x)]
+    (format "%s and %d" s 1)
+    (format "%d" s)
+    (printf "%s %s\n" s)
+    (format "%.*f" 2 1.5)
+    (format "%t" "yes")
+    (format "100%% of %v" x)
+    (format "%[1]d %[1]s" 1)
+    (format "%s" s x)
+    (format "%z" x)
+    (format "%5.2f%" 1.5)))
@@ -0,0 +1,18 @@
+(defn f [x]
+  ;; joker:ignore[unused-binding]
+  (let [a 1]
+    x))
+
+(defn g [x]
+  (let [b 1] x)) ;; joker:ignore[unused-binding]
+
+(let [c 1] 2) ;; joker:ignore[unused-binding]
+
+(defn h [x]
+  ;; joker:ignore[wrong-arity]
+  (let [d 1]
+    x))
+
+(inc 1 2) ;; joker:ignore[wrong-arity, unused-binding]
+
+(inc 1 2)
@@ -0,0 +1,7 @@
+(inc (let [a 1] (println a) "s"))
+(let [x (try (println) "a" (catch Exception e "b"))]
+  (inc x)
+  (dec x))
+(let [y (let [z :k] (println z) z)]
+  (inc y)
+  (first y))
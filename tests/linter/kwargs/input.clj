@@ -0,0 +1,11 @@
+(defn f [a & {:keys [x y]}] [a x y])
+;; Should PASS
+(f 1)
+(f 1 :x 2)
+(f 1 {:x 2})
+(f 1 :x 2 {:y 3})
+(f 1 nil)
+
+;; Should FAIL
+(f 1 :x)
+(f 1 :x 2 :y)
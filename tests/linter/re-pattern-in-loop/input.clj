@@ -0,0 +1,24 @@
+;; Should PASS
+(def word (re-pattern "\\w+"))
+
+(defn words
+  [lines]
+  (let [re (re-pattern "\\w+")]
+    (doseq [line lines]
+      (println (re-seq re line)))))
+
+(defn matches
+  [pattern lines]
+  (doseq [line lines]
+    (println (re-find (re-pattern pattern) line))))
+
+;; Should FAIL
+(defn digits
+  [lines]
+  (doseq [line lines]
+    (println (re-seq (re-pattern "\\d+") line))))
+
+(loop [n 3]
+  (when (pos? n)
+    (re-find (re-pattern "a+") "aaa")
+    (recur (dec n))))
@@ -0,0 +1,4 @@
+(defn f [x]
+  (let [a 1]
+    (do
+      (inc x 2))))
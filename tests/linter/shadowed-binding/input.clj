@@ -0,0 +1,13 @@
+(defn f [x]
+  (let [x (inc x)
+        y (dec x)
+        y (* x y)]
+    (loop [[a & more] [x y] acc 0]
+      (if a
+        (recur more (+ acc a))
+        acc))))
+
+(defn g [s _map]
+  (let [name (str s)
+        _name name]
+    (fn [s] (str s _name))))
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/candid82/joker/core"
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchSettleTime is how long --watch waits after a change for more of
+// them (e.g. from an editor saving several files) before linting again.
+const watchSettleTime = 100 * time.Millisecond
+
+// pathWatcher watches files and directory trees. fsnotify only watches
+// the directories it's given, not the ones under them, so these are
+// registered too, including the ones created later. Files are watched
+// through their directories, so that they still are after an editor
+// replaces them when saving. Paths are absolute, as are the ones of the
+// events then.
+type pathWatcher struct {
+	*fsnotify.Watcher
+	files map[string]bool
+	dirs  []string
+}
+
+// watchLint lints with pass, then again whenever one of the files in
+// paths, or a file under one of the directories in paths, changes, until
+// interrupted. The first pass prints all the problems found, the next
+// ones only those that are new, prefixed with "+ ", and those that are
+// gone, prefixed with "- ".
+func watchLint(paths []string, pass func()) {
+	w, err := newPathWatcher(paths)
+	if err != nil {
+		panic(err)
+	}
+	defer w.Close()
+	watcher := NewLintWatcher()
+	var last []string
+	for first := true; ; first = false {
+		problems := watcher.Pass(pass)
+		if first {
+			for _, p := range problems {
+				fmt.Fprintln(Stderr, p)
+			}
+		} else {
+			printLintDelta(last, problems)
+		}
+		last = problems
+		w.wait()
+	}
+}
+
+// withExtraPaths returns the function that lints with pass, then lints
+// the files and directories in paths (those given to --watch after the
+// first one) with the configuration and dialect of the first one.
+func withExtraPaths(pass func(), paths []string) func() {
+	if len(paths) == 0 {
+		return pass
+	}
+	dialect, custom := resolveDialect(dialect, filename)
+	passes := []func(){pass}
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			passes = append(passes, dirPass(path, dialect, custom, false))
+		} else {
+			passes = append(passes, filePass(path, dialect))
+		}
+	}
+	return func() {
+		ns := GLOBAL_ENV.CurrentNamespace()
+		for _, pass := range passes {
+			pass()
+			ResetUsage()
+			GLOBAL_ENV.SetCurrentNamespace(ns)
+		}
+	}
+}
+
+func newPathWatcher(paths []string) (*pathWatcher, error) {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	w := &pathWatcher{Watcher: fw, files: map[string]bool{}}
+	for _, path := range paths {
+		path, err := filepath.Abs(path)
+		var info os.FileInfo
+		if err == nil {
+			info, err = os.Stat(path)
+		}
+		if err == nil && info.IsDir() {
+			w.dirs = append(w.dirs, path)
+			err = w.addTree(path)
+		} else if err == nil {
+			w.files[path] = true
+			err = w.Add(filepath.Dir(path))
+		}
+		if err != nil {
+			fw.Close()
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+// addTree watches dir and the directories under it, except for .git
+// directories.
+func (w *pathWatcher) addTree(dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		switch {
+		case err != nil:
+			// Such as a directory removed meanwhile.
+			return nil
+		case !info.IsDir():
+			return nil
+		case info.Name() == ".git":
+			return filepath.SkipDir
+		}
+		return w.Add(path)
+	})
+}
+
+// watches returns whether path is one of the files watched or is under
+// one of the directories watched.
+func (w *pathWatcher) watches(path string) bool {
+	if w.files[path] {
+		return true
+	}
+	for _, dir := range w.dirs {
+		if path == dir || strings.HasPrefix(path, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// wait returns once a watched file has changed and no more changes have
+// happened for watchSettleTime.
+func (w *pathWatcher) wait() {
+	var settled <-chan time.Time
+	for {
+		select {
+		case event := <-w.Events:
+			path := filepath.Clean(event.Name)
+			if !w.watches(path) || event.Op == fsnotify.Chmod {
+				continue
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(path); err == nil && info.IsDir() && info.Name() != ".git" {
+					if err := w.addTree(path); err != nil {
+						fmt.Fprintln(Stderr, "Error: ", err)
+					}
+				}
+			}
+			settled = time.After(watchSettleTime)
+		case err := <-w.Errors:
+			fmt.Fprintln(Stderr, "Error: ", err)
+		case <-settled:
+			return
+		}
+	}
+}
+
+// printLintDelta prints the problems of before missing from after,
+// prefixed with "- ", and then those of after missing from before,
+// prefixed with "+ ".
+func printLintDelta(before []string, after []string) {
+	printMissing := func(prefix string, problems []string, from []string) {
+		remaining := map[string]int{}
+		for _, p := range from {
+			remaining[p]++
+		}
+		for _, p := range problems {
+			if remaining[p] > 0 {
+				remaining[p]--
+			} else {
+				fmt.Fprintln(Stderr, prefix+p)
+			}
+		}
+	}
+	printMissing("- ", before, after)
+	printMissing("+ ", after, before)
+}